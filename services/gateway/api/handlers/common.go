@@ -8,12 +8,19 @@ import (
 	"log"
 	"net/http"
 	"os"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/zemld/PerfumeRecommendationSystem/gateway/internal/errors"
 	"github.com/zemld/PerfumeRecommendationSystem/gateway/internal/models/perfume"
 )
 
+const (
+	clientIDHeader  = "X-Client-Id"
+	variantIDHeader = "X-Variant-Id"
+)
+
 func proxyRequestToPerfumist(
 	ctx context.Context,
 	perfumistUrl string,
@@ -21,14 +28,10 @@ func proxyRequestToPerfumist(
 	timeout time.Duration,
 	requireAuth bool,
 ) (*http.Response, []byte, error) {
-	req, err := http.NewRequestWithContext(ctx, http.MethodGet, perfumistUrl, nil)
+	req, err := newPerfumistRequest(ctx, perfumistUrl, originalReq, requireAuth)
 	if err != nil {
 		return nil, nil, err
 	}
-	req.URL.RawQuery = originalReq.URL.Query().Encode()
-	if requireAuth {
-		req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", os.Getenv("PERFUMIST_INTERNAL_TOKEN")))
-	}
 
 	client := http.Client{
 		Timeout: timeout,
@@ -47,6 +50,21 @@ func proxyRequestToPerfumist(
 	return resp, body, nil
 }
 
+func newPerfumistRequest(ctx context.Context, perfumistUrl string, originalReq *http.Request, requireAuth bool) (*http.Request, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, perfumistUrl, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.URL.RawQuery = originalReq.URL.Query().Encode()
+	if clientID := originalReq.Header.Get(clientIDHeader); clientID != "" {
+		req.Header.Set(clientIDHeader, clientID)
+	}
+	if requireAuth {
+		req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", os.Getenv("PERFUMIST_INTERNAL_TOKEN")))
+	}
+	return req, nil
+}
+
 func handlePerfumistResponse(w http.ResponseWriter, resp *http.Response, body []byte) error {
 	switch resp.StatusCode {
 	case http.StatusOK:
@@ -64,13 +82,38 @@ func handlePerfumistResponse(w http.ResponseWriter, resp *http.Response, body []
 			return nil
 		}
 
+		if variantID := resp.Header.Get(variantIDHeader); variantID != "" {
+			w.Header().Set(variantIDHeader, variantID)
+		}
 		w.Header().Set("Content-Type", "application/json")
 		w.WriteHeader(http.StatusOK)
 		if _, err := w.Write(body); err != nil {
 			return err
 		}
 
-	case http.StatusBadRequest, http.StatusNotFound:
+	default:
+		return handlePerfumistError(w, resp, body)
+	}
+
+	return nil
+}
+
+func handlePerfumistError(w http.ResponseWriter, resp *http.Response, body []byte) error {
+	switch resp.StatusCode {
+	case http.StatusNotFound:
+		var errorResponse struct {
+			Error      string          `json:"error"`
+			Candidates json.RawMessage `json:"candidates"`
+		}
+		if err := json.Unmarshal(body, &errorResponse); err != nil {
+			gatewayErr := errors.ErrPerfumeNotFound(fmt.Errorf("perfumist service error"), nil)
+			gatewayErr.WriteHTTP(w)
+			return err
+		}
+		gatewayErr := errors.ErrPerfumeNotFound(fmt.Errorf("%s", errorResponse.Error), errorResponse.Candidates)
+		gatewayErr.WriteHTTP(w)
+
+	case http.StatusBadRequest:
 		var errorResponse struct {
 			Error string `json:"error"`
 		}
@@ -112,3 +155,24 @@ func writeNoContentResponse(w http.ResponseWriter) {
 		log.Printf("Error encoding no content response: %v\n", err)
 	}
 }
+
+func validateOffersParameters(r http.Request) *errors.GatewayError {
+	for _, key := range []string{"min_price", "max_price", "max_price_per_ml"} {
+		rawValue := r.URL.Query().Get(key)
+		if rawValue == "" {
+			continue
+		}
+		if value, err := strconv.ParseFloat(rawValue, 64); err != nil || value < 0 {
+			return errors.ErrBadRequest(fmt.Errorf("%s must be a non-negative number", key))
+		}
+	}
+	for _, rawVolume := range strings.Split(r.URL.Query().Get("volumes"), ",") {
+		if rawVolume = strings.TrimSpace(rawVolume); rawVolume == "" {
+			continue
+		}
+		if volume, err := strconv.Atoi(rawVolume); err != nil || volume <= 0 {
+			return errors.ErrBadRequest(fmt.Errorf("volumes must be a list of positive integers"))
+		}
+	}
+	return nil
+}
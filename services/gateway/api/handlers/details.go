@@ -0,0 +1,62 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/zemld/PerfumeRecommendationSystem/gateway/internal/config"
+	"github.com/zemld/PerfumeRecommendationSystem/gateway/internal/errors"
+	"github.com/zemld/config-manager/pkg/cm"
+)
+
+func Details(w http.ResponseWriter, r *http.Request) {
+	brand, name := strings.TrimSpace(r.PathValue("brand")), strings.TrimSpace(r.PathValue("name"))
+	if brand == "" || name == "" {
+		gatewayErr := errors.ErrBadRequest(fmt.Errorf("brand and name are required"))
+		gatewayErr.WriteHTTP(w)
+		return
+	}
+	m := config.Manager()
+	timeout := getDetailsTimeout(m)
+	ctx, cancel := context.WithTimeout(r.Context(), timeout)
+	defer cancel()
+
+	detailsUrl, err := getDetailsUrl(m)
+	if err != nil {
+		gatewayErr := errors.NewInternalError(err)
+		gatewayErr.WriteHTTP(w)
+		return
+	}
+
+	perfumistUrl := fmt.Sprintf("%s/%s/%s", detailsUrl, url.PathEscape(brand), url.PathEscape(name))
+	resp, body, err := proxyRequestToPerfumist(ctx, perfumistUrl, r, timeout, true)
+	if err != nil {
+		gatewayErr := errors.NewInternalError(err)
+		gatewayErr.WriteHTTP(w)
+		return
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		if err := handlePerfumistError(w, resp, body); err != nil {
+			log.Printf("Error handling perfumist response: %v\n", err)
+		}
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	w.Write(body)
+}
+
+func getDetailsUrl(cm cm.ConfigManager) (string, error) {
+	return cm.GetString("details_url")
+}
+
+func getDetailsTimeout(cm cm.ConfigManager) time.Duration {
+	return cm.GetDurationWithDefault("non_ai_suggest_timeout", 8*time.Second)
+}
@@ -0,0 +1,52 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+
+	"github.com/zemld/PerfumeRecommendationSystem/gateway/internal/errors"
+)
+
+// proxyRequestToPerfumeHub passes a successful perfume-hub response through as
+// is, the query of the original request is forwarded.
+func proxyRequestToPerfumeHub(ctx context.Context, w http.ResponseWriter, hubUrl string, originalReq *http.Request) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, hubUrl, nil)
+	if err != nil {
+		gatewayErr := errors.NewInternalError(err)
+		gatewayErr.WriteHTTP(w)
+		return
+	}
+	req.URL.RawQuery = originalReq.URL.Query().Encode()
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", os.Getenv("PERFUME_HUB_INTERNAL_TOKEN")))
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		gatewayErr := errors.NewInternalError(err)
+		gatewayErr.WriteHTTP(w)
+		return
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		gatewayErr := errors.NewInternalError(err)
+		gatewayErr.WriteHTTP(w)
+		return
+	}
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		w.Write(body)
+	case http.StatusBadRequest:
+		gatewayErr := errors.ErrBadRequest(fmt.Errorf("perfume hub rejected request parameters"))
+		gatewayErr.WriteHTTP(w)
+	default:
+		gatewayErr := errors.NewInternalError(fmt.Errorf("perfume hub returned status: %d", resp.StatusCode))
+		gatewayErr.WriteHTTP(w)
+	}
+}
@@ -0,0 +1,55 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/zemld/PerfumeRecommendationSystem/gateway/internal/config"
+	"github.com/zemld/PerfumeRecommendationSystem/gateway/internal/errors"
+	"github.com/zemld/PerfumeRecommendationSystem/gateway/internal/models/canonization"
+	"github.com/zemld/config-manager/pkg/cm"
+)
+
+func Search(w http.ResponseWriter, r *http.Request) {
+	if gatewayErr := validateSearchParameters(*r); gatewayErr != nil {
+		gatewayErr.WriteHTTP(w)
+		return
+	}
+	m := config.Manager()
+	ctx, cancel := context.WithTimeout(r.Context(), getSearchTimeout(m))
+	defer cancel()
+
+	searchUrl, err := getSearchUrl(m)
+	if err != nil {
+		gatewayErr := errors.NewInternalError(err)
+		gatewayErr.WriteHTTP(w)
+		return
+	}
+
+	proxyRequestToPerfumeHub(ctx, w, searchUrl, r)
+}
+
+func getSearchUrl(cm cm.ConfigManager) (string, error) {
+	return cm.GetString("search_url")
+}
+
+// Search runs on every keystroke, so a slow response is worth dropping.
+func getSearchTimeout(cm cm.ConfigManager) time.Duration {
+	return cm.GetDurationWithDefault("search_timeout", 2*time.Second)
+}
+
+func validateSearchParameters(r http.Request) *errors.GatewayError {
+	canonizer := canonization.DefaultCanonizer{}
+	if len([]rune(canonizer.CanonizeString(r.URL.Query().Get("q")))) < 2 {
+		return errors.ErrBadRequest(fmt.Errorf("q must contain at least 2 letters or digits"))
+	}
+	if limit := r.URL.Query().Get("limit"); limit != "" {
+		if value, err := strconv.Atoi(limit); err != nil || value <= 0 {
+			return errors.ErrBadRequest(fmt.Errorf("limit must be a positive integer"))
+		}
+	}
+	return nil
+}
@@ -0,0 +1,103 @@
+package handlers
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"strings"
+
+	"github.com/zemld/PerfumeRecommendationSystem/gateway/internal/errors"
+)
+
+type streamingKey struct{}
+
+// Streaming makes next proxy the server-sent events variant of its perfumist
+// endpoint.
+func Streaming(next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		next(w, r.WithContext(context.WithValue(r.Context(), streamingKey{}, true)))
+	}
+}
+
+func isStreaming(r *http.Request) bool {
+	streaming, _ := r.Context().Value(streamingKey{}).(bool)
+	return streaming
+}
+
+// streamFromPerfumist copies events as soon as they are complete. When
+// perfumist answers with a plain response, e.g. a validation error, it is
+// handled as for the non-streaming endpoints.
+func streamFromPerfumist(ctx context.Context, w http.ResponseWriter, r *http.Request, perfumistUrl string) {
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		gatewayErr := errors.NewInternalError(fmt.Errorf("streaming is not supported"))
+		gatewayErr.WriteHTTP(w)
+		return
+	}
+
+	req, err := newPerfumistRequest(ctx, perfumistUrl, r, true)
+	if err != nil {
+		gatewayErr := errors.NewInternalError(err)
+		gatewayErr.WriteHTTP(w)
+		return
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		gatewayErr := errors.NewInternalError(err)
+		gatewayErr.WriteHTTP(w)
+		return
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK || !strings.HasPrefix(resp.Header.Get("Content-Type"), "text/event-stream") {
+		body, err := io.ReadAll(resp.Body)
+		if err != nil {
+			gatewayErr := errors.NewInternalError(err)
+			gatewayErr.WriteHTTP(w)
+			return
+		}
+		if err := handlePerfumistResponse(w, resp, body); err != nil {
+			log.Printf("Error handling perfumist response: %v\n", err)
+		}
+		return
+	}
+
+	if variantID := resp.Header.Get(variantIDHeader); variantID != "" {
+		w.Header().Set(variantIDHeader, variantID)
+	}
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("X-Accel-Buffering", "no")
+	w.WriteHeader(http.StatusOK)
+	flusher.Flush()
+
+	if err := copyEvents(w, flusher, resp.Body); err != nil {
+		log.Printf("Error streaming perfumist response: %v\n", err)
+	}
+}
+
+func copyEvents(w io.Writer, flusher http.Flusher, events io.Reader) error {
+	reader := bufio.NewReader(events)
+	for {
+		line, err := reader.ReadBytes('\n')
+		if len(line) > 0 {
+			if _, writeErr := w.Write(line); writeErr != nil {
+				return writeErr
+			}
+			if len(bytes.TrimSpace(line)) == 0 {
+				flusher.Flush()
+			}
+		}
+		if err == io.EOF {
+			flusher.Flush()
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+	}
+}
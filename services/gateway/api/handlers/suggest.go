@@ -29,6 +29,11 @@ func Suggest(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if isStreaming(r) {
+		streamFromPerfumist(ctx, w, r, perfumistUrl+"/stream")
+		return
+	}
+
 	timeout := getTimeoutFromRequest(*r, m)
 	resp, body, err := proxyRequestToPerfumist(ctx, perfumistUrl, r, timeout, true)
 	if err != nil {
@@ -64,5 +69,5 @@ func validateParameters(r http.Request) *errors.GatewayError {
 	if r.URL.Query().Get("name") == "" {
 		return errors.ErrBadRequest(fmt.Errorf("name is required"))
 	}
-	return nil
+	return validateOffersParameters(r)
 }
@@ -0,0 +1,66 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/zemld/PerfumeRecommendationSystem/gateway/internal/config"
+	"github.com/zemld/PerfumeRecommendationSystem/gateway/internal/errors"
+	"github.com/zemld/config-manager/pkg/cm"
+)
+
+func SuggestByCharacteristics(w http.ResponseWriter, r *http.Request) {
+	if gatewayErr := validateCharacteristicsParameters(*r); gatewayErr != nil {
+		gatewayErr.WriteHTTP(w)
+		return
+	}
+	m := config.Manager()
+	ctx, cancel := context.WithTimeout(r.Context(), getSuggestByCharacteristicsTimeout(m))
+	defer cancel()
+
+	perfumistUrl, err := getSuggestByCharacteristicsUrl(m)
+	if err != nil {
+		gatewayErr := errors.NewInternalError(err)
+		gatewayErr.WriteHTTP(w)
+		return
+	}
+
+	if isStreaming(r) {
+		streamFromPerfumist(ctx, w, r, perfumistUrl+"/stream")
+		return
+	}
+
+	timeout := getSuggestByCharacteristicsTimeout(m)
+	resp, body, err := proxyRequestToPerfumist(ctx, perfumistUrl, r, timeout, true)
+	if err != nil {
+		gatewayErr := errors.NewInternalError(err)
+		gatewayErr.WriteHTTP(w)
+		return
+	}
+	defer resp.Body.Close()
+
+	if err := handlePerfumistResponse(w, resp, body); err != nil {
+		log.Printf("Error handling perfumist response: %v\n", err)
+	}
+}
+
+func getSuggestByCharacteristicsUrl(cm cm.ConfigManager) (string, error) {
+	return cm.GetString("suggest_by_characteristics_url")
+}
+
+func getSuggestByCharacteristicsTimeout(cm cm.ConfigManager) time.Duration {
+	return cm.GetDurationWithDefault("non_ai_suggest_timeout", 8*time.Second)
+}
+
+func validateCharacteristicsParameters(r http.Request) *errors.GatewayError {
+	for _, target := range strings.Split(r.URL.Query().Get("targets"), ",") {
+		if strings.TrimSpace(target) != "" {
+			return validateOffersParameters(r)
+		}
+	}
+	return errors.ErrBadRequest(fmt.Errorf("at least one target is required"))
+}
@@ -0,0 +1,63 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/zemld/PerfumeRecommendationSystem/gateway/internal/config"
+	"github.com/zemld/PerfumeRecommendationSystem/gateway/internal/errors"
+	"github.com/zemld/config-manager/pkg/cm"
+)
+
+func SuggestByFavourites(w http.ResponseWriter, r *http.Request) {
+	if gatewayErr := validateFavouritesParameters(*r); gatewayErr != nil {
+		gatewayErr.WriteHTTP(w)
+		return
+	}
+	m := config.Manager()
+	ctx, cancel := context.WithTimeout(r.Context(), getSuggestByFavouritesTimeout(m))
+	defer cancel()
+
+	perfumistUrl, err := getSuggestByFavouritesUrl(m)
+	if err != nil {
+		gatewayErr := errors.NewInternalError(err)
+		gatewayErr.WriteHTTP(w)
+		return
+	}
+
+	if isStreaming(r) {
+		streamFromPerfumist(ctx, w, r, perfumistUrl+"/stream")
+		return
+	}
+
+	timeout := getSuggestByFavouritesTimeout(m)
+	resp, body, err := proxyRequestToPerfumist(ctx, perfumistUrl, r, timeout, true)
+	if err != nil {
+		gatewayErr := errors.NewInternalError(err)
+		gatewayErr.WriteHTTP(w)
+		return
+	}
+	defer resp.Body.Close()
+
+	if err := handlePerfumistResponse(w, resp, body); err != nil {
+		log.Printf("Error handling perfumist response: %v\n", err)
+	}
+}
+
+func getSuggestByFavouritesUrl(cm cm.ConfigManager) (string, error) {
+	return cm.GetString("suggest_by_favourites_url")
+}
+
+func getSuggestByFavouritesTimeout(cm cm.ConfigManager) time.Duration {
+	return cm.GetDurationWithDefault("non_ai_suggest_timeout", 8*time.Second)
+}
+
+func validateFavouritesParameters(r http.Request) *errors.GatewayError {
+	if len(r.URL.Query()["perfume"]) == 0 {
+		return errors.ErrBadRequest(fmt.Errorf("at least one perfume is required"))
+	}
+	return validateOffersParameters(r)
+}
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"log"
 	"net/http"
+	"strconv"
 	"time"
 
 	"github.com/zemld/PerfumeRecommendationSystem/gateway/internal/config"
@@ -28,6 +29,11 @@ func SuggestByTags(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if isStreaming(r) {
+		streamFromPerfumist(ctx, w, r, perfumistUrl+"/stream")
+		return
+	}
+
 	timeout := getSuggestByTagsTimeout(m)
 	resp, body, err := proxyRequestToPerfumist(ctx, perfumistUrl, r, timeout, true)
 	if err != nil {
@@ -55,5 +61,10 @@ func validateTagsParameters(r http.Request) *errors.GatewayError {
 	if tags == "" {
 		return errors.ErrBadRequest(fmt.Errorf("tags is required"))
 	}
-	return nil
+	if rawMinMatch := r.URL.Query().Get("min_match"); rawMinMatch != "" {
+		if minMatch, err := strconv.Atoi(rawMinMatch); err != nil || minMatch < 0 {
+			return errors.ErrBadRequest(fmt.Errorf("min_match must be a non-negative integer"))
+		}
+	}
+	return validateOffersParameters(r)
 }
@@ -0,0 +1,61 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/zemld/PerfumeRecommendationSystem/gateway/internal/config"
+	"github.com/zemld/PerfumeRecommendationSystem/gateway/internal/errors"
+	"github.com/zemld/config-manager/pkg/cm"
+)
+
+func SuggestByText(w http.ResponseWriter, r *http.Request) {
+	if gatewayErr := validateTextParameters(*r); gatewayErr != nil {
+		gatewayErr.WriteHTTP(w)
+		return
+	}
+	m := config.Manager()
+	ctx, cancel := context.WithTimeout(r.Context(), getSuggestByTextTimeout(m))
+	defer cancel()
+
+	perfumistUrl, err := getSuggestByTextUrl(m)
+	if err != nil {
+		gatewayErr := errors.NewInternalError(err)
+		gatewayErr.WriteHTTP(w)
+		return
+	}
+
+	timeout := getSuggestByTextTimeout(m)
+	resp, body, err := proxyRequestToPerfumist(ctx, perfumistUrl, r, timeout, true)
+	if err != nil {
+		gatewayErr := errors.NewInternalError(err)
+		gatewayErr.WriteHTTP(w)
+		return
+	}
+	defer resp.Body.Close()
+
+	if err := handlePerfumistResponse(w, resp, body); err != nil {
+		log.Printf("Error handling perfumist response: %v\n", err)
+	}
+}
+
+func getSuggestByTextUrl(cm cm.ConfigManager) (string, error) {
+	return cm.GetString("suggest_by_text_url")
+}
+
+// The text is interpreted by the LLM first, so the request takes as long as an
+// AI suggestion.
+func getSuggestByTextTimeout(cm cm.ConfigManager) time.Duration {
+	return cm.GetDurationWithDefault("ai_suggest_timeout", 20*time.Second)
+}
+
+func validateTextParameters(r http.Request) *errors.GatewayError {
+	if strings.TrimSpace(r.URL.Query().Get("text")) == "" {
+		return errors.ErrBadRequest(fmt.Errorf("text is required"))
+	}
+	return validateOffersParameters(r)
+}
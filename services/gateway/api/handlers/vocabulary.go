@@ -0,0 +1,39 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/zemld/PerfumeRecommendationSystem/gateway/internal/config"
+	"github.com/zemld/PerfumeRecommendationSystem/gateway/internal/errors"
+	"github.com/zemld/config-manager/pkg/cm"
+)
+
+var VocabularyKinds = []string{"tags", "notes", "characteristics", "families", "types"}
+
+// Vocabulary proxies the vocabulary of kind from perfume-hub as is.
+func Vocabulary(kind string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		m := config.Manager()
+		ctx, cancel := context.WithTimeout(r.Context(), getVocabularyTimeout(m))
+		defer cancel()
+
+		vocabularyUrl, err := getVocabularyUrl(m)
+		if err != nil {
+			gatewayErr := errors.NewInternalError(err)
+			gatewayErr.WriteHTTP(w)
+			return
+		}
+
+		proxyRequestToPerfumeHub(ctx, w, vocabularyUrl+"/"+kind, r)
+	}
+}
+
+func getVocabularyUrl(cm cm.ConfigManager) (string, error) {
+	return cm.GetString("vocabulary_url")
+}
+
+func getVocabularyTimeout(cm cm.ConfigManager) time.Duration {
+	return cm.GetDurationWithDefault("vocabulary_timeout", 5*time.Second)
+}
@@ -5,7 +5,9 @@ import (
 	"encoding/json"
 	"log"
 	"net/http"
+	"net/url"
 	"os"
+	"strings"
 	"time"
 
 	"github.com/zemld/PerfumeRecommendationSystem/gateway/internal/models/cache"
@@ -20,7 +22,10 @@ var (
 	ttlEnv        = os.Getenv("TTL_SECONDS")
 )
 
-const defaultTTL = 1 * time.Hour
+const (
+	defaultTTL     = 1 * time.Hour
+	clientIDHeader = "X-Client-Id"
+)
 
 type responseWriter struct {
 	http.ResponseWriter
@@ -48,14 +53,14 @@ func Cache(next http.HandlerFunc) http.HandlerFunc {
 			log.Printf("Cannot create Redis cacher: %v\n", err)
 		}
 
-		if tryLoadFromCache(r.Context(), cacher, key, w) {
+		if !isEnrolled(r) && tryLoadFromCache(r.Context(), cacher, key, w) {
 			return
 		}
 
 		rw := &responseWriter{ResponseWriter: w, statusCode: http.StatusOK}
 		next(rw, r)
 
-		if rw.statusCode == http.StatusOK && len(rw.body) > 0 && cacher != nil {
+		if isCacheable(rw) && cacher != nil {
 			if err := cacher.Save(r.Context(), key, rw.body); err != nil {
 				log.Printf("Cannot cache: %v\n", err)
 			}
@@ -63,15 +68,66 @@ func Cache(next http.HandlerFunc) http.HandlerFunc {
 	}
 }
 
+// isEnrolled tells whether the client can be in an experiment. Such requests
+// skip the cache, so that perfumist assigns the variant and records the exposure.
+func isEnrolled(r *http.Request) bool {
+	return r.Header.Get(clientIDHeader) != ""
+}
+
+// isCacheable skips responses of experiment variants: they depend on the client,
+// which is not a part of the cache key.
+func isCacheable(rw *responseWriter) bool {
+	return rw.statusCode == http.StatusOK && len(rw.body) > 0 && rw.Header().Get("X-Variant-Id") == ""
+}
+
+var (
+	// canonizedParameters hold brands, names and free text, where case, spaces
+	// and punctuation don't change the suggestion.
+	canonizedParameters = []string{"brand", "name", "perfume", "dislike", "text", "describe"}
+	// exactParameters hold flags, numbers and lists, whose separators matter:
+	// 1.5 and 15 or warmth:0.7 and warmth:07 are different values.
+	exactParameters = []string{
+		"sex", "use_ai", "explain", "strategy", "tags", "min_match", "auto_resolve",
+		"exclude_notes", "exclude_tags", "notes", "level", "targets", "importance",
+		"min_price", "max_price", "max_price_per_ml", "volumes", "shops",
+		"diversify", "diversity_lambda", "brand_cap", "matcher",
+	}
+)
+
+// getCacheKey builds the key from the route and the normalized suggestion
+// parameters. Stream routes share keys with the plain ones.
 func getCacheKey(r http.Request) string {
 	canonizer := canonization.DefaultCanonizer{}
-	keys := []string{
-		r.URL.Query().Get("brand"),
-		r.URL.Query().Get("name"),
-		r.URL.Query().Get("sex"),
-		r.URL.Query().Get("use_ai"),
+	query := r.URL.Query()
+	normalized := make(url.Values)
+	for _, key := range canonizedParameters {
+		for _, value := range query[key] {
+			if value = canonizer.CanonizeString(value); value != "" {
+				normalized.Add(key, value)
+			}
+		}
+	}
+	for _, key := range exactParameters {
+		for _, value := range query[key] {
+			if value = strings.ToLower(strings.TrimSpace(value)); value != "" {
+				normalized.Add(key, value)
+			}
+		}
+	}
+	for i, tags := range normalized["tags"] {
+		normalized["tags"][i] = strings.ReplaceAll(tags, "+", "*")
+	}
+	return strings.TrimSuffix(r.URL.Path, "/stream") + "?" + normalized.Encode()
+}
+
+// namedQueryValue prefixes a value with its parameter name, so that optional
+// parameters with similar values don't share a key.
+func namedQueryValue(r http.Request, key string) string {
+	value := r.URL.Query().Get(key)
+	if value == "" {
+		return ""
 	}
-	return canonizer.Canonize(keys)
+	return key + value
 }
 
 func getTTL() time.Duration {
@@ -83,21 +139,30 @@ func getTTL() time.Duration {
 }
 
 func tryLoadFromCache(ctx context.Context, cacher cache.Loader, key string, w http.ResponseWriter) bool {
-	cached, err := cacher.Load(ctx, key)
-	if err != nil || cached == nil {
-		return false
-	}
-	var suggestions perfume.Suggestions
-	if err := json.Unmarshal(cached, &suggestions); err != nil {
-		return false
-	}
-	if len(suggestions.Perfumes) == 0 {
+	cached, ok := loadSuggestions(ctx, cacher, key)
+	if !ok {
 		return false
 	}
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(http.StatusOK)
-	if err := json.NewEncoder(w).Encode(suggestions); err != nil {
-		return false
+	if _, err := w.Write(cached); err != nil {
+		log.Printf("Cannot write cached response: %v\n", err)
 	}
 	return true
 }
+
+func loadSuggestions(ctx context.Context, cacher cache.Loader, key string) ([]byte, bool) {
+	cached, err := cacher.Load(ctx, key)
+	if err != nil || cached == nil {
+		return nil, false
+	}
+	return cached, hasSuggestions(cached)
+}
+
+func hasSuggestions(body []byte) bool {
+	var suggestions perfume.Suggestions
+	if err := json.Unmarshal(body, &suggestions); err != nil {
+		return false
+	}
+	return len(suggestions.Perfumes) > 0
+}
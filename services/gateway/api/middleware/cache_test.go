@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 	"time"
 
@@ -53,17 +54,17 @@ func TestGetCacheKey(t *testing.T) {
 		{
 			name:     "all params",
 			query:    "brand=Chanel&name=No.5&sex=female&use_ai=true",
-			expected: "chanelno5femaletrue", // canonizer нормализует строки
+			expected: "/perfume/suggest?brand=chanel&name=no5&sex=female&use_ai=true", // canonizer нормализует бренд и название
 		},
 		{
 			name:     "missing params",
 			query:    "brand=Chanel",
-			expected: "chanel", // canonizer убирает пустые значения
+			expected: "/perfume/suggest?brand=chanel", // пустые значения не попадают в ключ
 		},
 		{
 			name:     "empty query",
 			query:    "",
-			expected: "", // canonizer убирает пустые значения
+			expected: "/perfume/suggest?",
 		},
 	}
 
@@ -272,3 +273,263 @@ func TestCache_JSONEncoding(t *testing.T) {
 		t.Errorf("expected brand 'Test', got '%s'", decoded.Perfumes[0].Perfume.Brand)
 	}
 }
+
+func TestGetCacheKey_DistinguishesExplainAndTags(t *testing.T) {
+	plain := httptest.NewRequest(http.MethodGet, "/perfume/suggest?brand=Chanel&name=No.5&sex=female", nil)
+	explained := httptest.NewRequest(http.MethodGet, "/perfume/suggest?brand=Chanel&name=No.5&sex=female&explain=true", nil)
+	tagged := httptest.NewRequest(http.MethodGet, "/perfume/suggest-by-tags?sex=female&tags=woody", nil)
+	otherTagged := httptest.NewRequest(http.MethodGet, "/perfume/suggest-by-tags?sex=female&tags=sweet", nil)
+
+	if getCacheKey(*plain) == getCacheKey(*explained) {
+		t.Error("expected explain to change the cache key")
+	}
+	ai := httptest.NewRequest(http.MethodGet, "/perfume/suggest?brand=Chanel&name=No.5&sex=female&use_ai=true", nil)
+	if getCacheKey(*ai) == getCacheKey(*explained) {
+		t.Error("expected explain and use_ai to have different cache keys")
+	}
+	if getCacheKey(*tagged) == getCacheKey(*otherTagged) {
+		t.Error("expected tags to change the cache key")
+	}
+}
+
+func TestTryLoadFromCache_KeepsUnknownFields(t *testing.T) {
+	mockCache := NewMockCacher()
+	cachedData := []byte(`{"suggested":[{"perfume":{"brand":"Chanel","name":"No.5","sex":"female"},"rank":1,"score":0.95,"explanation":{"score":0.95}}]}`)
+	mockCache.Save(context.Background(), "explained:key:", cachedData)
+
+	w := httptest.NewRecorder()
+	if !tryLoadFromCache(context.Background(), mockCache, "explained:key:", w) {
+		t.Fatal("expected cache hit, got cache miss")
+	}
+	if !strings.Contains(w.Body.String(), `"explanation"`) {
+		t.Errorf("expected explanation to be served from cache, got %s", w.Body.String())
+	}
+}
+
+func TestGetCacheKey_DistinguishesFavourites(t *testing.T) {
+	centroid := httptest.NewRequest(http.MethodGet, "/perfume/suggest-by-favourites?perfume=Dior|Sauvage&perfume=Chanel|No.5", nil)
+	max := httptest.NewRequest(http.MethodGet, "/perfume/suggest-by-favourites?perfume=Dior|Sauvage&perfume=Chanel|No.5&strategy=max", nil)
+	single := httptest.NewRequest(http.MethodGet, "/perfume/suggest-by-favourites?perfume=Dior|Sauvage", nil)
+
+	if getCacheKey(*centroid) == getCacheKey(*max) {
+		t.Error("expected strategy to change the cache key")
+	}
+	if getCacheKey(*centroid) == getCacheKey(*single) {
+		t.Error("expected every perfume to be part of the cache key")
+	}
+}
+
+func TestGetCacheKey_DistinguishesExclusions(t *testing.T) {
+	base := "/perfume/suggest?brand=Dior&name=Sauvage&sex=male"
+	keys := make(map[string]string)
+	for _, query := range []string{
+		"",
+		"&exclude_notes=oud",
+		"&exclude_tags=sweet",
+		"&dislike=Chanel|Egoiste",
+		"&dislike=Chanel|Egoiste&dislike=Dior|Fahrenheit",
+		"&exclude_tags=oud",
+		"&dislike=oud",
+	} {
+		req := httptest.NewRequest(http.MethodGet, base+query, nil)
+		key := getCacheKey(*req)
+		if other, ok := keys[key]; ok {
+			t.Errorf("expected %q and %q to have different cache keys", other, query)
+		}
+		keys[key] = query
+	}
+}
+
+func TestGetCacheKey_DistinguishesNotes(t *testing.T) {
+	vanilla := httptest.NewRequest(http.MethodGet, "/perfume/suggest-by-notes?notes=vanilla", nil)
+	vanillaRose := httptest.NewRequest(http.MethodGet, "/perfume/suggest-by-notes?notes=vanilla,rose", nil)
+	vanillaBase := httptest.NewRequest(http.MethodGet, "/perfume/suggest-by-notes?notes=vanilla&level=base", nil)
+
+	if getCacheKey(*vanilla) == getCacheKey(*vanillaRose) {
+		t.Error("expected notes to change the cache key")
+	}
+	if getCacheKey(*vanilla) == getCacheKey(*vanillaBase) {
+		t.Error("expected level to change the cache key")
+	}
+}
+
+func TestGetCacheKey_DistinguishesRoutes(t *testing.T) {
+	byNotes := httptest.NewRequest(http.MethodGet, "/perfume/suggest-by-notes?notes=vanilla&sex=female", nil)
+	byTags := httptest.NewRequest(http.MethodGet, "/perfume/suggest-by-tags?tags=vanilla&sex=female", nil)
+	byNotesOnTags := httptest.NewRequest(http.MethodGet, "/perfume/suggest-by-tags?notes=vanilla&sex=female", nil)
+	streamed := httptest.NewRequest(http.MethodGet, "/perfume/suggest-by-notes/stream?notes=vanilla&sex=female", nil)
+
+	if getCacheKey(*byNotes) == getCacheKey(*byTags) {
+		t.Error("expected notes and tags requests to have different cache keys")
+	}
+	if getCacheKey(*byNotes) == getCacheKey(*byNotesOnTags) {
+		t.Error("expected the path to change the cache key")
+	}
+	if getCacheKey(*byNotes) != getCacheKey(*streamed) {
+		t.Error("expected streaming and plain requests to share the cache key")
+	}
+}
+
+func TestGetCacheKey_DistinguishesCharacteristics(t *testing.T) {
+	sweet := httptest.NewRequest(http.MethodGet, "/perfume/suggest-by-characteristics?targets=sweetness:0.8", nil)
+	sweeter := httptest.NewRequest(http.MethodGet, "/perfume/suggest-by-characteristics?targets=sweetness:0.9", nil)
+	weighted := httptest.NewRequest(http.MethodGet, "/perfume/suggest-by-characteristics?targets=sweetness:0.8&importance=sweetness:2", nil)
+
+	if getCacheKey(*sweet) == getCacheKey(*sweeter) {
+		t.Error("expected targets to change the cache key")
+	}
+	if getCacheKey(*sweet) == getCacheKey(*weighted) {
+		t.Error("expected importance to change the cache key")
+	}
+}
+
+func TestGetCacheKey_DistinguishesCharacteristicSeparators(t *testing.T) {
+	base := "/perfume/suggest-by-characteristics?"
+	for _, pair := range [][2]string{
+		{"targets=warmth:0.7,freshness:0.3", "targets=warmth:0.73,freshness:0"},
+		{"targets=warmth:0.7&importance=warmth:1.5", "targets=warmth:0.7&importance=warmth:15"},
+	} {
+		first := getCacheKey(*httptest.NewRequest(http.MethodGet, base+pair[0], nil))
+		second := getCacheKey(*httptest.NewRequest(http.MethodGet, base+pair[1], nil))
+		if first == second {
+			t.Errorf("expected %q and %q to have different cache keys, got %q", pair[0], pair[1], first)
+		}
+	}
+}
+
+func TestGetCacheKey_DistinguishesOffers(t *testing.T) {
+	base := "/perfume/suggest?brand=Dior&name=Sauvage&sex=male"
+	keys := make(map[string]string)
+	for _, query := range []string{
+		"",
+		"&min_price=1000",
+		"&max_price=1000",
+		"&max_price_per_ml=1000",
+		"&volumes=100",
+		"&volumes=50,100",
+		"&shops=goldapple",
+	} {
+		req := httptest.NewRequest(http.MethodGet, base+query, nil)
+		key := getCacheKey(*req)
+		if other, ok := keys[key]; ok {
+			t.Errorf("expected %q and %q to have different cache keys", query, other)
+		}
+		keys[key] = query
+	}
+}
+
+func TestGetCacheKey_DistinguishesDiversity(t *testing.T) {
+	base := "/perfume/suggest?brand=Dior&name=Sauvage&sex=male"
+	keys := make(map[string]string)
+	for _, query := range []string{
+		"",
+		"&diversify=true",
+		"&diversity_lambda=0.5",
+		"&brand_cap=1",
+		"&diversify=true&brand_cap=1",
+	} {
+		req := httptest.NewRequest(http.MethodGet, base+query, nil)
+		key := getCacheKey(*req)
+		if other, ok := keys[key]; ok {
+			t.Errorf("expected %q and %q to have different cache keys", query, other)
+		}
+		keys[key] = query
+	}
+}
+
+func TestGetCacheKey_DistinguishesMatcher(t *testing.T) {
+	base := "/perfume/suggest?brand=Dior&name=Sauvage&sex=male"
+	keys := make(map[string]string)
+	for _, query := range []string{
+		"",
+		"&matcher=smart",
+		"&matcher=smart_enhanced",
+		"&matcher=tags",
+	} {
+		req := httptest.NewRequest(http.MethodGet, base+query, nil)
+		key := getCacheKey(*req)
+		if other, ok := keys[key]; ok {
+			t.Errorf("expected %q and %q to have different cache keys", query, other)
+		}
+		keys[key] = query
+	}
+}
+
+func TestGetCacheKey_DistinguishesText(t *testing.T) {
+	warm := httptest.NewRequest(http.MethodGet, "/perfume/suggest-by-text?text=warm+and+cozy", nil)
+	fresh := httptest.NewRequest(http.MethodGet, "/perfume/suggest-by-text?text=fresh+for+summer", nil)
+	if getCacheKey(*warm) == getCacheKey(*fresh) {
+		t.Fatal("expected different texts to have different cache keys")
+	}
+}
+
+func TestIsCacheable_SkipsExperimentVariants(t *testing.T) {
+	rw := &responseWriter{ResponseWriter: httptest.NewRecorder(), statusCode: http.StatusOK, body: []byte(`{"suggested":[]}`)}
+	if !isCacheable(rw) {
+		t.Fatal("expected plain response to be cacheable")
+	}
+
+	rw.Header().Set("X-Variant-Id", "weights:control")
+	if isCacheable(rw) {
+		t.Fatal("expected experiment response not to be cached")
+	}
+
+	if isCacheable(&responseWriter{ResponseWriter: httptest.NewRecorder(), statusCode: http.StatusBadRequest, body: []byte("{}")}) {
+		t.Fatal("expected error response not to be cached")
+	}
+}
+
+func TestIsEnrolled(t *testing.T) {
+	anonymous := httptest.NewRequest(http.MethodGet, "/perfume/suggest?brand=Dior&name=Sauvage", nil)
+	if isEnrolled(anonymous) {
+		t.Error("expected request without client id not to be enrolled")
+	}
+
+	enrolled := httptest.NewRequest(http.MethodGet, "/perfume/suggest?brand=Dior&name=Sauvage", nil)
+	enrolled.Header.Set("X-Client-Id", "client-1")
+	if !isEnrolled(enrolled) {
+		t.Error("expected request with client id to skip the cache")
+	}
+}
+
+func TestGetCacheKey_DistinguishesTagModifiers(t *testing.T) {
+	base := "/perfume/suggest-by-tags?tags="
+	keys := make(map[string]string)
+	for _, query := range []string{
+		"woody,sweet",
+		"%2Bwoody,sweet",
+		"-woody,sweet",
+		"woody:3,sweet",
+		"woody,sweet&min_match=1",
+	} {
+		req := httptest.NewRequest(http.MethodGet, base+query, nil)
+		key := getCacheKey(*req)
+		if other, ok := keys[key]; ok {
+			t.Errorf("expected %q and %q to have different cache keys", query, other)
+		}
+		keys[key] = query
+	}
+}
+
+func TestGetCacheKey_RequiredTagMarkersShareKey(t *testing.T) {
+	asterisk := httptest.NewRequest(http.MethodGet, "/perfume/suggest-by-tags?tags=*woody,sweet", nil)
+	plus := httptest.NewRequest(http.MethodGet, "/perfume/suggest-by-tags?tags=%2Bwoody,sweet", nil)
+	if getCacheKey(*asterisk) != getCacheKey(*plus) {
+		t.Errorf("expected * and + required markers to share a cache key")
+	}
+}
+
+func TestGetCacheKey_DistinguishesDecimalPrices(t *testing.T) {
+	base := "/perfume/suggest?brand=Chanel&name=No.5&"
+	for _, pair := range [][2]string{
+		{"max_price_per_ml=1.5", "max_price_per_ml=15"},
+		{"min_price=10.5", "min_price=105"},
+		{"max_price=99.9", "max_price=999"},
+	} {
+		first := getCacheKey(*httptest.NewRequest(http.MethodGet, base+pair[0], nil))
+		second := getCacheKey(*httptest.NewRequest(http.MethodGet, base+pair[1], nil))
+		if first == second {
+			t.Errorf("expected %q and %q to have different cache keys, got %q", pair[0], pair[1], first)
+		}
+	}
+}
@@ -0,0 +1,31 @@
+package middleware
+
+import (
+	"log"
+	"net/http"
+
+	"github.com/zemld/PerfumeRecommendationSystem/gateway/internal/models/cache"
+	"github.com/zemld/PerfumeRecommendationSystem/gateway/internal/models/canonization"
+)
+
+// DetailsCache caches perfume cards for as long as suggestions: both change
+// only with the catalog.
+func DetailsCache(next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		cacher, err := cache.NewRedisCacher(redisHost, redisPort, redisPassword, getTTL())
+		if err != nil {
+			log.Printf("Cannot create Redis cacher: %v\n", err)
+			next(w, r)
+			return
+		}
+		serveCached(r.Context(), cacher, getDetailsCacheKey(*r), w, r, next)
+	}
+}
+
+func getDetailsCacheKey(r http.Request) string {
+	canonizer := canonization.DefaultCanonizer{}
+	return "details:" + canonizer.Canonize([]string{
+		namedQueryValue(r, "sex"),
+		r.PathValue("brand"),
+	}) + ":" + canonizer.CanonizeString(r.PathValue("name"))
+}
@@ -0,0 +1,26 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestGetDetailsCacheKey(t *testing.T) {
+	key := func(url string, brand string, name string) string {
+		r := httptest.NewRequest(http.MethodGet, url, nil)
+		r.SetPathValue("brand", brand)
+		r.SetPathValue("name", name)
+		return getDetailsCacheKey(*r)
+	}
+
+	if key("/perfume/Tom%20Ford/Oud%20Wood", "Tom Ford", "Oud Wood") != key("/perfume/tom-ford/oud-wood", "tom-ford", "oud-wood") {
+		t.Error("expected names canonizing to the same string to share a key")
+	}
+	if key("/perfume/Dior/Sauvage?sex=male", "Dior", "Sauvage") == key("/perfume/Dior/Sauvage", "Dior", "Sauvage") {
+		t.Error("expected sex to be a part of the key")
+	}
+	if key("/perfume/Dior/Sauvage", "Dior", "Sauvage") == key("/perfume/DiorSauvage/x", "DiorSauvage", "") {
+		t.Error("expected brand and name not to be glued together")
+	}
+}
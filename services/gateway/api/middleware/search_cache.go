@@ -0,0 +1,35 @@
+package middleware
+
+import (
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/zemld/PerfumeRecommendationSystem/gateway/internal/config"
+	"github.com/zemld/PerfumeRecommendationSystem/gateway/internal/models/cache"
+	"github.com/zemld/PerfumeRecommendationSystem/gateway/internal/models/canonization"
+)
+
+// SearchCache keeps autocomplete results for a short time: users typing the
+// same perfume repeat the same prefixes.
+func SearchCache(next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ttl := config.Manager().GetDurationWithDefault("search_cache_ttl", 10*time.Minute)
+		cacher, err := cache.NewRedisCacher(redisHost, redisPort, redisPassword, ttl)
+		if err != nil {
+			log.Printf("Cannot create Redis cacher: %v\n", err)
+			next(w, r)
+			return
+		}
+		serveCached(r.Context(), cacher, getSearchCacheKey(*r), w, r, next)
+	}
+}
+
+func getSearchCacheKey(r http.Request) string {
+	canonizer := canonization.DefaultCanonizer{}
+	return "search:" + canonizer.Canonize([]string{
+		r.URL.Query().Get("q"),
+		namedQueryValue(r, "sex"),
+		namedQueryValue(r, "limit"),
+	})
+}
@@ -0,0 +1,23 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestGetSearchCacheKey(t *testing.T) {
+	key := func(url string) string {
+		return getSearchCacheKey(*httptest.NewRequest(http.MethodGet, url, nil))
+	}
+
+	if key("/perfume/search?q=Dior%20Sauvage") != key("/perfume/search?q=dior-sauvage") {
+		t.Error("expected queries canonizing to the same string to share a key")
+	}
+	if key("/perfume/search?q=dior&sex=male") == key("/perfume/search?q=dior") {
+		t.Error("expected sex to be a part of the key")
+	}
+	if key("/perfume/search?q=dior&limit=5") == key("/perfume/search?q=dior5") {
+		t.Error("expected limit not to be confused with the query")
+	}
+}
@@ -0,0 +1,99 @@
+package middleware
+
+import (
+	"bytes"
+	"fmt"
+	"log"
+	"net/http"
+
+	"github.com/zemld/PerfumeRecommendationSystem/gateway/internal/models/cache"
+)
+
+const resultEvent = "result"
+
+// eventRecorder passes events through and keeps the data of the result event.
+type eventRecorder struct {
+	http.ResponseWriter
+	pending    []byte
+	event      string
+	result     []byte
+	statusCode int
+}
+
+func (er *eventRecorder) Write(b []byte) (int, error) {
+	er.pending = append(er.pending, b...)
+	for {
+		end := bytes.IndexByte(er.pending, '\n')
+		if end < 0 {
+			break
+		}
+		er.readLine(bytes.TrimRight(er.pending[:end], "\r"))
+		er.pending = er.pending[end+1:]
+	}
+	return er.ResponseWriter.Write(b)
+}
+
+func (er *eventRecorder) readLine(line []byte) {
+	switch {
+	case len(line) == 0:
+		er.event = ""
+	case bytes.HasPrefix(line, []byte("event:")):
+		er.event = string(bytes.TrimSpace(line[len("event:"):]))
+	case bytes.HasPrefix(line, []byte("data:")) && er.event == resultEvent:
+		er.result = append(er.result, bytes.TrimSpace(line[len("data:"):])...)
+	}
+}
+
+func (er *eventRecorder) WriteHeader(statusCode int) {
+	er.statusCode = statusCode
+	er.ResponseWriter.WriteHeader(statusCode)
+}
+
+func (er *eventRecorder) Flush() {
+	if flusher, ok := er.ResponseWriter.(http.Flusher); ok {
+		flusher.Flush()
+	}
+}
+
+// StreamCache shares the cache of the non-streaming endpoints, as the key drops
+// the /stream suffix of the path: a cached response is sent as a single result
+// event, and only the result event of a stream is saved.
+func StreamCache(next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		key := getCacheKey(*r)
+
+		cacher, err := cache.NewRedisCacher(redisHost, redisPort, redisPassword, getTTL())
+		if err != nil {
+			log.Printf("Cannot create Redis cacher: %v\n", err)
+		}
+
+		if cacher != nil && !isEnrolled(r) {
+			if cached, ok := loadSuggestions(r.Context(), cacher, key); ok {
+				writeCachedEvent(w, cached)
+				return
+			}
+		}
+
+		er := &eventRecorder{ResponseWriter: w, statusCode: http.StatusOK}
+		next(er, r)
+
+		if isStreamCacheable(er) && cacher != nil {
+			if err := cacher.Save(r.Context(), key, er.result); err != nil {
+				log.Printf("Cannot cache: %v\n", err)
+			}
+		}
+	}
+}
+
+func isStreamCacheable(er *eventRecorder) bool {
+	return er.statusCode == http.StatusOK && hasSuggestions(er.result) && er.Header().Get("X-Variant-Id") == ""
+}
+
+func writeCachedEvent(w http.ResponseWriter, cached []byte) {
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.WriteHeader(http.StatusOK)
+	if _, err := fmt.Fprintf(w, "event: %s\ndata: %s\n\n", resultEvent, bytes.TrimSpace(cached)); err != nil {
+		log.Printf("Cannot write cached response: %v\n", err)
+	}
+}
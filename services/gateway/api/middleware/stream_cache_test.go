@@ -0,0 +1,66 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestEventRecorder_KeepsResultEvent(t *testing.T) {
+	recorder := httptest.NewRecorder()
+	er := &eventRecorder{ResponseWriter: recorder, statusCode: http.StatusOK}
+
+	chunks := []string{
+		"event: partial\ndata: {\"suggested\":[{\"rank\":1}]}\n\n",
+		"event: progress\ndata: {\"processed\":200}\n\nevent: res",
+		"ult\ndata: {\"suggested\":[{\"rank\":1}]}\r\n",
+		"\r\n",
+	}
+	for _, chunk := range chunks {
+		if _, err := er.Write([]byte(chunk)); err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+	}
+
+	if string(er.result) != `{"suggested":[{"rank":1}]}` {
+		t.Fatalf("expected result event data, got %q", er.result)
+	}
+	if recorder.Body.Len() != len(chunks[0])+len(chunks[1])+len(chunks[2])+len(chunks[3]) {
+		t.Fatalf("expected events to be passed through, got %q", recorder.Body.String())
+	}
+	if !isStreamCacheable(er) {
+		t.Fatal("expected stream with suggestions to be cacheable")
+	}
+}
+
+func TestIsStreamCacheable(t *testing.T) {
+	noResult := &eventRecorder{ResponseWriter: httptest.NewRecorder(), statusCode: http.StatusOK}
+	noResult.Write([]byte("event: error\ndata: {\"status\":500,\"error\":\"failed\"}\n\n"))
+	if isStreamCacheable(noResult) {
+		t.Fatal("expected stream without result not to be cached")
+	}
+
+	empty := &eventRecorder{ResponseWriter: httptest.NewRecorder(), statusCode: http.StatusOK, result: []byte(`{"suggested":[]}`)}
+	if isStreamCacheable(empty) {
+		t.Fatal("expected empty result not to be cached")
+	}
+
+	variant := &eventRecorder{ResponseWriter: httptest.NewRecorder(), statusCode: http.StatusOK, result: []byte(`{"suggested":[{"rank":1}]}`)}
+	variant.Header().Set("X-Variant-Id", "weights:control")
+	if isStreamCacheable(variant) {
+		t.Fatal("expected experiment result not to be cached")
+	}
+}
+
+func TestWriteCachedEvent(t *testing.T) {
+	w := httptest.NewRecorder()
+	writeCachedEvent(w, []byte("{\"suggested\":[{\"rank\":1}]}\n"))
+
+	if contentType := w.Header().Get("Content-Type"); contentType != "text/event-stream" {
+		t.Fatalf("expected text/event-stream, got %q", contentType)
+	}
+	expected := "event: result\ndata: {\"suggested\":[{\"rank\":1}]}\n\n"
+	if w.Body.String() != expected {
+		t.Fatalf("expected %q, got %q", expected, w.Body.String())
+	}
+}
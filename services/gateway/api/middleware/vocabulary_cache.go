@@ -0,0 +1,52 @@
+package middleware
+
+import (
+	"context"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/zemld/PerfumeRecommendationSystem/gateway/internal/config"
+	"github.com/zemld/PerfumeRecommendationSystem/gateway/internal/models/cache"
+)
+
+type loadSaver interface {
+	cache.Loader
+	cache.Saver
+}
+
+// VocabularyCache caches successful responses by path: vocabularies take no
+// parameters and only change with the catalog, so they live longer than
+// suggestions.
+func VocabularyCache(next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ttl := config.Manager().GetDurationWithDefault("vocabulary_cache_ttl", 6*time.Hour)
+		cacher, err := cache.NewRedisCacher(redisHost, redisPort, redisPassword, ttl)
+		if err != nil {
+			log.Printf("Cannot create Redis cacher: %v\n", err)
+			next(w, r)
+			return
+		}
+		serveCached(r.Context(), cacher, "vocabulary:"+r.URL.Path, w, r, next)
+	}
+}
+
+func serveCached(ctx context.Context, cacher loadSaver, key string, w http.ResponseWriter, r *http.Request, next http.HandlerFunc) {
+	if cached, err := cacher.Load(ctx, key); err == nil && len(cached) > 0 {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		if _, err := w.Write(cached); err != nil {
+			log.Printf("Cannot write cached response: %v\n", err)
+		}
+		return
+	}
+
+	rw := &responseWriter{ResponseWriter: w, statusCode: http.StatusOK}
+	next(rw, r)
+
+	if rw.statusCode == http.StatusOK && len(rw.body) > 0 {
+		if err := cacher.Save(ctx, key, rw.body); err != nil {
+			log.Printf("Cannot cache: %v\n", err)
+		}
+	}
+}
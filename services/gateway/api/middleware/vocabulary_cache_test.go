@@ -0,0 +1,42 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestServeCached(t *testing.T) {
+	mockCache := NewMockCacher()
+	calls := 0
+	next := func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"items":[{"name":"woody","usage":{"male":10}}]}`))
+	}
+
+	for range 2 {
+		w := httptest.NewRecorder()
+		serveCached(context.Background(), mockCache, "vocabulary:/vocabulary/tags", w, httptest.NewRequest(http.MethodGet, "/vocabulary/tags", nil), next)
+		if w.Code != http.StatusOK || w.Body.String() != `{"items":[{"name":"woody","usage":{"male":10}}]}` {
+			t.Fatalf("unexpected response %d %q", w.Code, w.Body.String())
+		}
+	}
+	if calls != 1 {
+		t.Fatalf("expected the second response to come from cache, got %d calls", calls)
+	}
+}
+
+func TestServeCached_SkipsErrors(t *testing.T) {
+	mockCache := NewMockCacher()
+	next := func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+		w.Write([]byte(`{"error":"INTERNAL_ERROR"}`))
+	}
+
+	serveCached(context.Background(), mockCache, "vocabulary:/vocabulary/notes", httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/vocabulary/notes", nil), next)
+	if len(mockCache.store) != 0 {
+		t.Fatalf("expected error response not to be cached, got %v", mockCache.store)
+	}
+}
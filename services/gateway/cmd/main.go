@@ -18,6 +18,22 @@ func main() {
 
 	router.HandleFunc("GET /perfume/suggest", middleware.Cors(middleware.Cache(handlers.Suggest)))
 	router.HandleFunc("GET /perfume/suggest-by-tags", middleware.Cors(middleware.Cache(handlers.SuggestByTags)))
+	router.HandleFunc("GET /perfume/suggest-by-favourites", middleware.Cors(middleware.Cache(handlers.SuggestByFavourites)))
+	router.HandleFunc("GET /perfume/suggest-by-notes", middleware.Cors(middleware.Cache(handlers.SuggestByNotes)))
+	router.HandleFunc("GET /perfume/suggest-by-characteristics", middleware.Cors(middleware.Cache(handlers.SuggestByCharacteristics)))
+	router.HandleFunc("GET /perfume/suggest-by-text", middleware.Cors(middleware.Cache(handlers.SuggestByText)))
+	router.HandleFunc("GET /perfume/suggest/stream", middleware.Cors(middleware.StreamCache(handlers.Streaming(handlers.Suggest))))
+	router.HandleFunc("GET /perfume/suggest-by-tags/stream", middleware.Cors(middleware.StreamCache(handlers.Streaming(handlers.SuggestByTags))))
+	router.HandleFunc("GET /perfume/suggest-by-favourites/stream", middleware.Cors(middleware.StreamCache(handlers.Streaming(handlers.SuggestByFavourites))))
+	router.HandleFunc("GET /perfume/suggest-by-notes/stream", middleware.Cors(middleware.StreamCache(handlers.Streaming(handlers.SuggestByNotes))))
+	router.HandleFunc("GET /perfume/suggest-by-characteristics/stream", middleware.Cors(middleware.StreamCache(handlers.Streaming(handlers.SuggestByCharacteristics))))
+
+	router.HandleFunc("GET /perfume/{brand}/{name}", middleware.Cors(middleware.DetailsCache(handlers.Details)))
+	router.HandleFunc("GET /perfume/search", middleware.Cors(middleware.SearchCache(handlers.Search)))
+
+	for _, kind := range handlers.VocabularyKinds {
+		router.HandleFunc("GET /vocabulary/"+kind, middleware.Cors(middleware.VocabularyCache(handlers.Vocabulary(kind))))
+	}
 
 	log.Printf("Starting server on port 8000")
 	if err := http.ListenAndServe(":8000", router); err != nil {
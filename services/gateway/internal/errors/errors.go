@@ -11,6 +11,7 @@ type GatewayError struct {
 	StatusCode int
 	Message    string
 	Err        error
+	Candidates json.RawMessage
 }
 
 func (e *GatewayError) Error() string {
@@ -27,10 +28,13 @@ func (e *GatewayError) Unwrap() error {
 func (e *GatewayError) WriteHTTP(w http.ResponseWriter) {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(e.StatusCode)
-	errorResponse := map[string]string{
+	errorResponse := map[string]any{
 		"error":   e.Type,
 		"message": e.Message,
 	}
+	if len(e.Candidates) > 0 {
+		errorResponse["candidates"] = e.Candidates
+	}
 	if err := json.NewEncoder(w).Encode(errorResponse); err != nil {
 		fmt.Printf("Failed to encode error response: %v\n", err)
 	}
@@ -62,3 +66,15 @@ func ErrBadRequest(err error) *GatewayError {
 		Err:        err,
 	}
 }
+
+// ErrPerfumeNotFound keeps the perfumes the user could have meant, as they were
+// returned by the perfumist.
+func ErrPerfumeNotFound(err error, candidates json.RawMessage) *GatewayError {
+	return &GatewayError{
+		Type:       "NOT_FOUND",
+		StatusCode: http.StatusNotFound,
+		Message:    "Perfume not found",
+		Err:        err,
+		Candidates: candidates,
+	}
+}
@@ -0,0 +1,39 @@
+package handlers
+
+import (
+	"log"
+	"net/http"
+	"strings"
+
+	perfumeModels "github.com/zemld/Scently/models"
+	"github.com/zemld/Scently/perfume-hub/internal/db/core"
+	"github.com/zemld/Scently/perfume-hub/internal/errors"
+	"github.com/zemld/Scently/perfume-hub/internal/models"
+)
+
+type NotesResponse struct {
+	Notes []perfumeModels.EnrichedNote `json:"notes"`
+	State models.ProcessedState        `json:"state"`
+}
+
+func SelectNotes(w http.ResponseWriter, r *http.Request) {
+	params := models.NewNotesParameters().WithNames(strings.Split(r.URL.Query().Get("names"), ","))
+	if len(params.Names) == 0 {
+		handleError(w, errors.NewValidationError("names are required"))
+		return
+	}
+
+	notes, status := core.SelectNotes(r.Context(), params)
+	if status.Error != nil {
+		handleError(w, status.Error)
+		return
+	}
+
+	response := NotesResponse{Notes: notes, State: status}
+	log.Printf("Found notes: %d of %d\n", len(notes), len(params.Names))
+	if len(notes) == 0 {
+		WriteResponse(w, http.StatusNotFound, response)
+		return
+	}
+	WriteResponse(w, http.StatusOK, response)
+}
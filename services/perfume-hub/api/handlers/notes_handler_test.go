@@ -0,0 +1,29 @@
+package handlers
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestSelectNotes_MissingNames(t *testing.T) {
+	tests := []struct {
+		name string
+		url  string
+	}{
+		{name: "no parameter", url: "/v1/notes/get"},
+		{name: "empty parameter", url: "/v1/notes/get?names="},
+		{name: "only separators", url: "/v1/notes/get?names=,%20,"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			SelectNotes(w, httptest.NewRequest(http.MethodGet, tt.url, nil))
+
+			if w.Code != http.StatusBadRequest {
+				t.Errorf("SelectNotes() status = %d, want %d", w.Code, http.StatusBadRequest)
+			}
+		})
+	}
+}
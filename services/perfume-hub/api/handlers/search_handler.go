@@ -0,0 +1,36 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/zemld/Scently/perfume-hub/internal/db/core"
+	"github.com/zemld/Scently/perfume-hub/internal/errors"
+	"github.com/zemld/Scently/perfume-hub/internal/models"
+)
+
+type SearchResponse struct {
+	Perfumes []models.SearchResult `json:"perfumes"`
+	State    models.ProcessedState `json:"state"`
+}
+
+func Search(w http.ResponseWriter, r *http.Request) {
+	limit, _ := strconv.Atoi(r.URL.Query().Get("limit"))
+	params := models.NewSearchParameters().
+		WithQuery(r.URL.Query().Get("q")).
+		WithSex(r.URL.Query().Get("sex")).
+		WithLimit(limit)
+	if len([]rune(params.Query)) < models.MinSearchQueryLength {
+		handleError(w, errors.NewValidationError(fmt.Sprintf("q must contain at least %d letters or digits", models.MinSearchQueryLength)))
+		return
+	}
+
+	results, status := core.Search(r.Context(), params)
+	if status.Error != nil {
+		handleError(w, status.Error)
+		return
+	}
+
+	WriteResponse(w, http.StatusOK, SearchResponse{Perfumes: results, State: status})
+}
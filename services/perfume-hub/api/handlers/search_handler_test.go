@@ -0,0 +1,18 @@
+package handlers
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestSearch_ShortQuery(t *testing.T) {
+	for _, url := range []string{"/v1/perfumes/search", "/v1/perfumes/search?q=d", "/v1/perfumes/search?q=-!"} {
+		w := httptest.NewRecorder()
+		Search(w, httptest.NewRequest(http.MethodGet, url, nil))
+
+		if w.Code != http.StatusBadRequest {
+			t.Errorf("Search(%s) status = %d, want %d", url, w.Code, http.StatusBadRequest)
+		}
+	}
+}
@@ -0,0 +1,50 @@
+package handlers
+
+import (
+	"encoding/json"
+	"log"
+	"net/http"
+
+	perfumeModels "github.com/zemld/Scently/models"
+	"github.com/zemld/Scently/perfume-hub/internal/db/core"
+	"github.com/zemld/Scently/perfume-hub/internal/models"
+)
+
+// StreamPerfumes writes all perfumes as newline-delimited JSON, one perfume per
+// line after a header line with the catalog version and perfume count. Once the
+// header is written errors can't change the status, so the client sees fewer
+// perfumes than announced.
+func StreamPerfumes(stream core.StreamFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		params := models.NewStreamParameters().WithSex(r.URL.Query().Get("sex"))
+
+		encoder := json.NewEncoder(w)
+		started := false
+		onHeader := func(header models.StreamHeader) error {
+			w.Header().Set("Content-Type", "application/x-ndjson")
+			w.WriteHeader(http.StatusOK)
+			started = true
+			if err := encoder.Encode(header); err != nil {
+				return err
+			}
+			if flusher, ok := w.(http.Flusher); ok {
+				flusher.Flush()
+			}
+			return nil
+		}
+		onPerfume := func(perfume perfumeModels.Perfume) error {
+			return encoder.Encode(perfume)
+		}
+
+		status := stream(r.Context(), params, onHeader, onPerfume)
+		if status.Error != nil && !started {
+			handleError(w, status.Error)
+			return
+		}
+		if status.Error != nil {
+			log.Printf("Perfumes stream interrupted after %d perfumes: %v\n", status.SuccessfulCount, status.Error)
+			return
+		}
+		log.Printf("Streamed perfumes: %d\n", status.SuccessfulCount)
+	}
+}
@@ -0,0 +1,96 @@
+package handlers
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	perfumeModels "github.com/zemld/Scently/models"
+	"github.com/zemld/Scently/perfume-hub/internal/db/core"
+	"github.com/zemld/Scently/perfume-hub/internal/errors"
+	"github.com/zemld/Scently/perfume-hub/internal/models"
+)
+
+func fakeStream(header models.StreamHeader, perfumes []perfumeModels.Perfume, err error) core.StreamFunc {
+	return func(
+		ctx context.Context,
+		params *models.StreamParameters,
+		onHeader func(models.StreamHeader) error,
+		onPerfume func(perfumeModels.Perfume) error,
+	) models.ProcessedState {
+		if params.Sex != "female" {
+			return models.ProcessedState{Error: errors.NewValidationError("unexpected sex " + params.Sex)}
+		}
+		if err != nil {
+			return models.ProcessedState{Error: err}
+		}
+		if err := onHeader(header); err != nil {
+			return models.ProcessedState{Error: err}
+		}
+		state := models.NewProcessedState()
+		for _, perfume := range perfumes {
+			if err := onPerfume(perfume); err != nil {
+				state.Error = err
+				return state
+			}
+			state.SuccessfulCount++
+		}
+		return state
+	}
+}
+
+func TestStreamPerfumes(t *testing.T) {
+	version := time.Date(2026, 1, 9, 10, 36, 8, 0, time.UTC)
+	perfumes := []perfumeModels.Perfume{
+		{Brand: "Chanel", Name: "No. 5", Sex: "female"},
+		{Brand: "Dior", Name: "Sauvage", Sex: "unisex"},
+	}
+	w := httptest.NewRecorder()
+	handler := StreamPerfumes(fakeStream(models.StreamHeader{Version: version, Count: 2}, perfumes, nil))
+	handler(w, httptest.NewRequest(http.MethodGet, "/v1/perfumes/stream?sex=female", nil))
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("StreamPerfumes() status = %d, want %d", w.Code, http.StatusOK)
+	}
+	if contentType := w.Header().Get("Content-Type"); contentType != "application/x-ndjson" {
+		t.Errorf("StreamPerfumes() Content-Type = %q, want application/x-ndjson", contentType)
+	}
+
+	scanner := bufio.NewScanner(w.Body)
+	if !scanner.Scan() {
+		t.Fatal("StreamPerfumes() wrote no header")
+	}
+	var header models.StreamHeader
+	if err := json.Unmarshal(scanner.Bytes(), &header); err != nil {
+		t.Fatalf("header is not JSON: %v", err)
+	}
+	if !header.Version.Equal(version) || header.Count != 2 {
+		t.Errorf("header = %+v, want version %v and count 2", header, version)
+	}
+
+	var got []perfumeModels.Perfume
+	for scanner.Scan() {
+		var perfume perfumeModels.Perfume
+		if err := json.Unmarshal(scanner.Bytes(), &perfume); err != nil {
+			t.Fatalf("perfume line is not JSON: %v", err)
+		}
+		got = append(got, perfume)
+	}
+	if len(got) != 2 || got[0].Brand != "Chanel" || got[1].Name != "Sauvage" {
+		t.Errorf("perfumes = %+v, want %+v", got, perfumes)
+	}
+}
+
+func TestStreamPerfumes_ErrorBeforeHeader(t *testing.T) {
+	w := httptest.NewRecorder()
+	handler := StreamPerfumes(fakeStream(models.StreamHeader{}, nil, errors.NewDBError("connection refused", nil)))
+	handler(w, httptest.NewRequest(http.MethodGet, "/v1/perfumes/stream?sex=female", nil))
+
+	if w.Code != http.StatusInternalServerError {
+		t.Errorf("StreamPerfumes() status = %d, want %d", w.Code, http.StatusInternalServerError)
+	}
+}
@@ -0,0 +1,27 @@
+package handlers
+
+import (
+	"log"
+	"net/http"
+
+	"github.com/zemld/Scently/perfume-hub/internal/db/core"
+	"github.com/zemld/Scently/perfume-hub/internal/models"
+)
+
+type VocabularyResponse struct {
+	Items []models.VocabularyItem `json:"items"`
+	State models.ProcessedState   `json:"state"`
+}
+
+func SelectVocabulary(kind models.VocabularyKind) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		items, status := core.SelectVocabulary(r.Context(), kind)
+		if status.Error != nil {
+			handleError(w, status.Error)
+			return
+		}
+
+		log.Printf("Found %s: %d\n", kind, len(items))
+		WriteResponse(w, http.StatusOK, VocabularyResponse{Items: items, State: status})
+	}
+}
@@ -0,0 +1,18 @@
+package handlers
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/zemld/Scently/perfume-hub/internal/models"
+)
+
+func TestSelectVocabulary_UnknownKind(t *testing.T) {
+	w := httptest.NewRecorder()
+	SelectVocabulary(models.VocabularyKind("brands"))(w, httptest.NewRequest(http.MethodGet, "/v1/vocabulary/brands", nil))
+
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("SelectVocabulary() status = %d, want %d", w.Code, http.StatusBadRequest)
+	}
+}
@@ -0,0 +1,37 @@
+package rpc
+
+import (
+	"context"
+
+	"github.com/zemld/Scently/perfume-hub/api/middleware"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+// The interceptors accept the same bearer tokens as middleware.Auth, passed in
+// the "authorization" metadata.
+
+func authUnaryInterceptor(ctx context.Context, req any, _ *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+	if err := authorize(ctx); err != nil {
+		return nil, err
+	}
+	return handler(ctx, req)
+}
+
+func authStreamInterceptor(srv any, stream grpc.ServerStream, _ *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	if err := authorize(stream.Context()); err != nil {
+		return err
+	}
+	return handler(srv, stream)
+}
+
+func authorize(ctx context.Context) error {
+	rawToken := ""
+	if values := metadata.ValueFromIncomingContext(ctx, "authorization"); len(values) > 0 {
+		rawToken = values[0]
+	}
+	if authErr := middleware.Authorize(rawToken); authErr != nil {
+		return statusError(authErr)
+	}
+	return nil
+}
@@ -0,0 +1,96 @@
+package rpc
+
+import (
+	perfumeModels "github.com/zemld/Scently/models"
+	pb "github.com/zemld/Scently/shared/proto/perfume-hub/models"
+)
+
+// fromProtoSex returns the sex in the form of the sex query parameter, where
+// unisex is the default and is left empty.
+func fromProtoSex(sex pb.Perfume_Sex) string {
+	switch sex {
+	case pb.Perfume_MALE:
+		return string(perfumeModels.Male)
+	case pb.Perfume_FEMALE:
+		return string(perfumeModels.Female)
+	default:
+		return ""
+	}
+}
+
+func toProtoSex(sex perfumeModels.Sex) pb.Perfume_Sex {
+	switch sex {
+	case perfumeModels.Male:
+		return pb.Perfume_MALE
+	case perfumeModels.Female:
+		return pb.Perfume_FEMALE
+	default:
+		return pb.Perfume_UNISEX
+	}
+}
+
+func toProtoPerfumes(perfumes []perfumeModels.Perfume) []*pb.Perfume {
+	converted := make([]*pb.Perfume, 0, len(perfumes))
+	for _, perfume := range perfumes {
+		converted = append(converted, toProtoPerfume(perfume))
+	}
+	return converted
+}
+
+func toProtoPerfume(perfume perfumeModels.Perfume) *pb.Perfume {
+	shops := make([]*pb.Perfume_Shop, 0, len(perfume.Shops))
+	for _, shop := range perfume.Shops {
+		variants := make([]*pb.Perfume_Shop_Variant, 0, len(shop.Variants))
+		for _, variant := range shop.Variants {
+			variants = append(variants, &pb.Perfume_Shop_Variant{
+				Volume: int32(variant.Volume),
+				Link:   variant.Link,
+				Price:  int32(variant.Price),
+			})
+		}
+		shops = append(shops, &pb.Perfume_Shop{
+			Name:     shop.ShopName,
+			Domain:   shop.Domain,
+			ImageUrl: shop.ImageUrl,
+			Variants: variants,
+		})
+	}
+
+	properties := perfume.Properties
+	return &pb.Perfume{
+		Brand:    perfume.Brand,
+		Name:     perfume.Name,
+		Sex:      toProtoSex(perfume.Sex),
+		ImageUrl: perfume.ImageUrl,
+		Properties: &pb.Perfume_Properties{
+			Type:               properties.Type,
+			Family:             properties.Family,
+			UpperNotes:         properties.UpperNotes,
+			CoreNotes:          properties.CoreNotes,
+			BaseNotes:          properties.BaseNotes,
+			EnrichedUpperNotes: toProtoEnrichedNotes(properties.EnrichedUpperNotes),
+			EnrichedCoreNotes:  toProtoEnrichedNotes(properties.EnrichedCoreNotes),
+			EnrichedBaseNotes:  toProtoEnrichedNotes(properties.EnrichedBaseNotes),
+		},
+		Shops: shops,
+	}
+}
+
+func toProtoEnrichedNotes(notes []perfumeModels.EnrichedNote) []*pb.Perfume_Properties_EnrichedNote {
+	converted := make([]*pb.Perfume_Properties_EnrichedNote, 0, len(notes))
+	for _, note := range notes {
+		characteristics := make([]*pb.Perfume_Properties_EnrichedNote_NoteCharacteristic, 0, len(note.Characteristics))
+		for _, characteristic := range note.Characteristics {
+			characteristics = append(characteristics, &pb.Perfume_Properties_EnrichedNote_NoteCharacteristic{
+				Name:  characteristic.Name,
+				Value: characteristic.Value,
+			})
+		}
+		converted = append(converted, &pb.Perfume_Properties_EnrichedNote{
+			Name:            note.Name,
+			Tags:            note.Tags,
+			Characteristics: characteristics,
+		})
+	}
+	return converted
+}
@@ -0,0 +1,55 @@
+package rpc
+
+import (
+	"testing"
+
+	perfumeModels "github.com/zemld/Scently/models"
+	pb "github.com/zemld/Scently/shared/proto/perfume-hub/models"
+)
+
+func TestFromProtoSex(t *testing.T) {
+	for protoSex, sex := range map[pb.Perfume_Sex]string{
+		pb.Perfume_MALE:   "male",
+		pb.Perfume_FEMALE: "female",
+		pb.Perfume_UNISEX: "",
+	} {
+		if converted := fromProtoSex(protoSex); converted != sex {
+			t.Fatalf("fromProtoSex(%v) = %q, want %q", protoSex, converted, sex)
+		}
+	}
+}
+
+func TestToProtoPerfume(t *testing.T) {
+	perfume := toProtoPerfume(perfumeModels.Perfume{
+		Brand: "Dior",
+		Name:  "Sauvage",
+		Sex:   perfumeModels.Unisex,
+		Properties: perfumeModels.Properties{
+			Type:      "EDT",
+			BaseNotes: []string{"ambroxan"},
+			EnrichedBaseNotes: []perfumeModels.EnrichedNote{{
+				Name:            "ambroxan",
+				Tags:            []string{"woody"},
+				Characteristics: []perfumeModels.NoteCharacteristic{{Name: "warm", Value: 0.7}},
+			}},
+		},
+		Shops: []perfumeModels.ShopInfo{{
+			ShopName: "Gold Apple",
+			Domain:   "goldapple.ru",
+			ImageUrl: "https://goldapple.ru/sauvage.png",
+			Variants: []perfumeModels.Variant{{Volume: 60, Link: "https://goldapple.ru/sauvage", Price: 9000}},
+		}},
+	})
+
+	if perfume.GetSex() != pb.Perfume_UNISEX || perfume.GetProperties().GetType() != "EDT" {
+		t.Fatalf("unexpected perfume: %v", perfume)
+	}
+	note := perfume.GetProperties().GetEnrichedBaseNotes()[0]
+	if note.GetTags()[0] != "woody" || note.GetCharacteristics()[0].GetValue() != 0.7 {
+		t.Fatalf("unexpected enriched note: %v", note)
+	}
+	shop := perfume.GetShops()[0]
+	if shop.GetImageUrl() != "https://goldapple.ru/sauvage.png" || shop.GetVariants()[0].GetPrice() != 9000 {
+		t.Fatalf("unexpected shop: %v", shop)
+	}
+}
@@ -0,0 +1,103 @@
+package rpc
+
+import (
+	"context"
+	"log"
+	"strconv"
+	"time"
+
+	perfumeModels "github.com/zemld/Scently/models"
+	"github.com/zemld/Scently/perfume-hub/internal/db/core"
+	"github.com/zemld/Scently/perfume-hub/internal/errors"
+	"github.com/zemld/Scently/perfume-hub/internal/models"
+	perfumehubpb "github.com/zemld/Scently/shared/proto/perfume-hub"
+	pb "github.com/zemld/Scently/shared/proto/perfume-hub/models"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+type perfumeHubServer struct {
+	perfumehubpb.UnimplementedPerfumeHubServiceServer
+	selectPerfumes core.SelectFunc
+	streamPerfumes core.StreamFunc
+}
+
+// Header metadata of StreamPerfumes with the catalog version and the number of
+// perfumes in the stream.
+const (
+	CatalogVersionKey = "x-catalog-version"
+	PerfumesCountKey  = "x-perfumes-count"
+)
+
+func NewServer() *grpc.Server {
+	server := grpc.NewServer(
+		grpc.UnaryInterceptor(authUnaryInterceptor),
+		grpc.StreamInterceptor(authStreamInterceptor),
+	)
+	perfumehubpb.RegisterPerfumeHubServiceServer(server, &perfumeHubServer{selectPerfumes: core.Select, streamPerfumes: core.Stream})
+	return server
+}
+
+func (s *perfumeHubServer) GetPerfume(ctx context.Context, req *perfumehubpb.GetPerfumeRequest) (*perfumehubpb.GetPerfumeResponse, error) {
+	params := models.NewSelectParameters().
+		WithBrand(req.GetBrand()).
+		WithName(req.GetName()).
+		WithSex(fromProtoSex(req.GetSex())).
+		WithPage(int(req.GetPage()))
+
+	perfumes, state := s.selectPerfumes(ctx, params)
+	if state.Error != nil {
+		return nil, statusError(state.Error)
+	}
+	log.Printf("Found perfumes: %d\n", len(perfumes))
+	if len(perfumes) == 0 {
+		return nil, status.Error(codes.NotFound, "perfumes not found")
+	}
+	return &perfumehubpb.GetPerfumeResponse{Perfumes: toProtoPerfumes(perfumes)}, nil
+}
+
+// StreamPerfumes sends the whole catalog for the sex from one snapshot. The
+// catalog version and perfume count go first as header metadata, so once it is
+// sent errors can only end the stream and the client sees fewer perfumes than
+// announced.
+func (s *perfumeHubServer) StreamPerfumes(req *perfumehubpb.StreamPerfumesRequest, stream grpc.ServerStreamingServer[pb.Perfume]) error {
+	params := models.NewStreamParameters().WithSex(fromProtoSex(req.GetSex()))
+
+	onHeader := func(header models.StreamHeader) error {
+		return stream.SendHeader(metadata.Pairs(
+			CatalogVersionKey, header.Version.Format(time.RFC3339Nano),
+			PerfumesCountKey, strconv.Itoa(header.Count),
+		))
+	}
+	onPerfume := func(perfume perfumeModels.Perfume) error {
+		return stream.Send(toProtoPerfume(perfume))
+	}
+
+	state := s.streamPerfumes(stream.Context(), params, onHeader, onPerfume)
+	if state.Error != nil {
+		log.Printf("Perfumes stream interrupted after %d perfumes: %v\n", state.SuccessfulCount, state.Error)
+		return statusError(state.Error)
+	}
+	log.Printf("Streamed perfumes: %d\n", state.SuccessfulCount)
+	return nil
+}
+
+func statusError(err error) error {
+	serviceErr, ok := err.(errors.ServiceError)
+	if !ok {
+		return status.Error(codes.Internal, err.Error())
+	}
+
+	switch serviceErr.Type() {
+	case errors.ErrorTypeValidation:
+		return status.Error(codes.InvalidArgument, serviceErr.Error())
+	case errors.ErrorTypeNotFound:
+		return status.Error(codes.NotFound, serviceErr.Error())
+	case errors.ErrorTypeAuth:
+		return status.Error(codes.Unauthenticated, serviceErr.Error())
+	default:
+		return status.Error(codes.Internal, serviceErr.Error())
+	}
+}
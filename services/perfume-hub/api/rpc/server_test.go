@@ -0,0 +1,170 @@
+package rpc
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	perfumeModels "github.com/zemld/Scently/models"
+	"github.com/zemld/Scently/perfume-hub/internal/db/core"
+	"github.com/zemld/Scently/perfume-hub/internal/errors"
+	"github.com/zemld/Scently/perfume-hub/internal/models"
+	perfumehubpb "github.com/zemld/Scently/shared/proto/perfume-hub"
+	pb "github.com/zemld/Scently/shared/proto/perfume-hub/models"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+type fakeStream struct {
+	grpc.ServerStream
+	header metadata.MD
+	sent   []*pb.Perfume
+}
+
+func (s *fakeStream) SendHeader(md metadata.MD) error {
+	s.header = md
+	return nil
+}
+
+func (s *fakeStream) Context() context.Context {
+	return context.Background()
+}
+
+func (s *fakeStream) Send(perfume *pb.Perfume) error {
+	s.sent = append(s.sent, perfume)
+	return nil
+}
+
+func pagedSelect(pages [][]perfumeModels.Perfume, requested *[]*models.SelectParameters) func(context.Context, *models.SelectParameters) ([]perfumeModels.Perfume, models.ProcessedState) {
+	return func(_ context.Context, params *models.SelectParameters) ([]perfumeModels.Perfume, models.ProcessedState) {
+		*requested = append(*requested, params)
+		if params.Page > len(pages) {
+			return nil, models.NewProcessedState()
+		}
+		return pages[params.Page-1], models.ProcessedState{SuccessfulCount: len(pages[params.Page-1])}
+	}
+}
+
+func TestGetPerfume(t *testing.T) {
+	var requested []*models.SelectParameters
+	server := &perfumeHubServer{selectPerfumes: pagedSelect([][]perfumeModels.Perfume{
+		{{Brand: "Dior", Name: "Sauvage", Sex: perfumeModels.Male}},
+	}, &requested)}
+
+	brand, name := "Dior", "Sauvage"
+	response, err := server.GetPerfume(context.Background(), &perfumehubpb.GetPerfumeRequest{Brand: &brand, Name: &name, Sex: pb.Perfume_MALE})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if len(response.GetPerfumes()) != 1 || response.GetPerfumes()[0].GetSex() != pb.Perfume_MALE {
+		t.Fatalf("unexpected perfumes: %v", response.GetPerfumes())
+	}
+	if params := requested[0]; params.Brand != "Dior" || params.Name != "Sauvage" || params.Sex != "male" || params.Page != 1 {
+		t.Fatalf("unexpected select parameters: %+v", params)
+	}
+}
+
+func TestGetPerfume_NotFound(t *testing.T) {
+	var requested []*models.SelectParameters
+	server := &perfumeHubServer{selectPerfumes: pagedSelect(nil, &requested)}
+
+	_, err := server.GetPerfume(context.Background(), &perfumehubpb.GetPerfumeRequest{})
+	if code := status.Code(err); code != codes.NotFound {
+		t.Fatalf("expected NotFound, got %v", code)
+	}
+}
+
+func TestGetPerfume_DBError(t *testing.T) {
+	server := &perfumeHubServer{selectPerfumes: func(context.Context, *models.SelectParameters) ([]perfumeModels.Perfume, models.ProcessedState) {
+		return nil, models.ProcessedState{Error: errors.NewDBError("error executing query", nil)}
+	}}
+
+	_, err := server.GetPerfume(context.Background(), &perfumehubpb.GetPerfumeRequest{})
+	if code := status.Code(err); code != codes.Internal {
+		t.Fatalf("expected Internal, got %v", code)
+	}
+}
+
+func snapshotStream(header models.StreamHeader, perfumes []perfumeModels.Perfume, err error, requested *[]*models.StreamParameters) core.StreamFunc {
+	return func(_ context.Context, params *models.StreamParameters, onHeader func(models.StreamHeader) error, onPerfume func(perfumeModels.Perfume) error) models.ProcessedState {
+		*requested = append(*requested, params)
+		if err := onHeader(header); err != nil {
+			return models.ProcessedState{Error: err}
+		}
+		state := models.NewProcessedState()
+		for _, perfume := range perfumes {
+			if err := onPerfume(perfume); err != nil {
+				state.Error = err
+				return state
+			}
+			state.SuccessfulCount++
+		}
+		state.Error = err
+		return state
+	}
+}
+
+func TestStreamPerfumes(t *testing.T) {
+	var requested []*models.StreamParameters
+	version := time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC)
+	server := &perfumeHubServer{streamPerfumes: snapshotStream(
+		models.StreamHeader{Version: version, Count: 3},
+		[]perfumeModels.Perfume{{Brand: "Dior", Name: "Sauvage"}, {Brand: "Chanel", Name: "Bleu"}, {Brand: "Tom Ford", Name: "Oud Wood"}},
+		nil,
+		&requested,
+	)}
+
+	stream := &fakeStream{}
+	if err := server.StreamPerfumes(&perfumehubpb.StreamPerfumesRequest{Sex: pb.Perfume_FEMALE}, stream); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if len(stream.sent) != 3 || stream.sent[2].GetName() != "Oud Wood" {
+		t.Fatalf("expected the whole snapshot to be streamed, got %v", stream.sent)
+	}
+	if len(requested) != 1 || requested[0].Sex != "female" {
+		t.Fatalf("expected one stream for female perfumes, got %+v", requested)
+	}
+	if count := stream.header.Get(PerfumesCountKey); len(count) != 1 || count[0] != "3" {
+		t.Fatalf("expected perfumes count in header, got %v", stream.header)
+	}
+	if v := stream.header.Get(CatalogVersionKey); len(v) != 1 || v[0] != version.Format(time.RFC3339Nano) {
+		t.Fatalf("expected catalog version in header, got %v", stream.header)
+	}
+}
+
+func TestStreamPerfumes_Interrupted(t *testing.T) {
+	var requested []*models.StreamParameters
+	server := &perfumeHubServer{streamPerfumes: snapshotStream(
+		models.StreamHeader{Count: 2},
+		[]perfumeModels.Perfume{{Brand: "Dior", Name: "Sauvage"}},
+		errors.NewDBError("error reading cursor", nil),
+		&requested,
+	)}
+
+	stream := &fakeStream{}
+	err := server.StreamPerfumes(&perfumehubpb.StreamPerfumesRequest{}, stream)
+	if code := status.Code(err); code != codes.Internal {
+		t.Fatalf("expected Internal, got %v", code)
+	}
+	if len(stream.sent) != 1 || stream.header.Get(PerfumesCountKey)[0] != "2" {
+		t.Fatalf("expected header and sent perfumes before the error, got %v, %v", stream.header, stream.sent)
+	}
+}
+
+func TestStatusError(t *testing.T) {
+	for _, tc := range []struct {
+		err  error
+		code codes.Code
+	}{
+		{errors.NewValidationError("invalid input"), codes.InvalidArgument},
+		{errors.NewNotFoundError("not found"), codes.NotFound},
+		{errors.NewAuthError("invalid token"), codes.Unauthenticated},
+		{errors.NewDBError("error executing query", nil), codes.Internal},
+	} {
+		if code := status.Code(statusError(tc.err)); code != tc.code {
+			t.Fatalf("%v: expected %v, got %v", tc.err, tc.code, code)
+		}
+	}
+}
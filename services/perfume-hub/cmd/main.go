@@ -2,13 +2,17 @@ package main
 
 import (
 	"fmt"
+	"log"
+	"net"
 	"net/http"
 	"os"
 	"time"
 
 	"github.com/zemld/Scently/perfume-hub/api/handlers"
 	"github.com/zemld/Scently/perfume-hub/api/middleware"
+	"github.com/zemld/Scently/perfume-hub/api/rpc"
 	"github.com/zemld/Scently/perfume-hub/internal/db/core"
+	"github.com/zemld/Scently/perfume-hub/internal/models"
 )
 
 func main() {
@@ -18,7 +22,29 @@ func main() {
 	r := http.NewServeMux()
 
 	r.Handle("/v1/perfumes/get", middleware.Auth(http.HandlerFunc(handlers.Select)))
+	r.Handle("/v1/perfumes/stream", middleware.Auth(handlers.StreamPerfumes(core.Stream)))
 	r.Handle("/v1/perfumes/update", middleware.Auth(http.HandlerFunc(handlers.Update)))
+	r.Handle("/v1/perfumes/search", middleware.Auth(http.HandlerFunc(handlers.Search)))
+	r.Handle("/v1/notes/get", middleware.Auth(http.HandlerFunc(handlers.SelectNotes)))
+	for _, kind := range models.VocabularyKinds {
+		r.Handle("/v1/vocabulary/"+string(kind), middleware.Auth(handlers.SelectVocabulary(kind)))
+	}
+
+	grpcPort := os.Getenv("PERFUME_HUB_GRPC_PORT")
+	if grpcPort == "" {
+		grpcPort = "9000"
+	}
+	grpcServer := rpc.NewServer()
+	defer grpcServer.GracefulStop()
+	go func() {
+		lis, err := net.Listen("tcp", fmt.Sprintf(":%s", grpcPort))
+		if err != nil {
+			log.Fatalf("Error listening for gRPC: %v\n", err)
+		}
+		if err := grpcServer.Serve(lis); err != nil {
+			log.Fatalf("Error starting gRPC server: %v\n", err)
+		}
+	}()
 
 	http.ListenAndServe(fmt.Sprintf(":%s", os.Getenv("PERFUME_HUB_PORT")), r)
 }
@@ -6,6 +6,8 @@ require (
 	github.com/jackc/pgx/v5 v5.8.0
 	github.com/pressly/goose/v3 v3.26.0
 	github.com/zemld/Scently/models v0.0.0-20260102110023-9170a3ad7cc4
+	github.com/zemld/Scently/shared/proto/perfume-hub v0.0.0-00010101000000-000000000000
+	google.golang.org/grpc v1.79.3
 )
 
 require (
@@ -15,6 +17,12 @@ require (
 	github.com/mfridman/interpolate v0.0.2 // indirect
 	github.com/sethvargo/go-retry v0.3.0 // indirect
 	go.uber.org/multierr v1.11.0 // indirect
-	golang.org/x/sync v0.18.0 // indirect
-	golang.org/x/text v0.31.0 // indirect
+	golang.org/x/net v0.48.0 // indirect
+	golang.org/x/sync v0.19.0 // indirect
+	golang.org/x/sys v0.39.0 // indirect
+	golang.org/x/text v0.32.0 // indirect
+	google.golang.org/genproto/googleapis/rpc v0.0.0-20251202230838-ff82c1b0f217 // indirect
+	google.golang.org/protobuf v1.36.11 // indirect
 )
+
+replace github.com/zemld/Scently/shared/proto/perfume-hub => ../../shared/go/proto/perfume-hub/v1
@@ -0,0 +1,34 @@
+package core
+
+import (
+	"context"
+	"log"
+
+	perfumeModels "github.com/zemld/Scently/models"
+	queries "github.com/zemld/Scently/perfume-hub/internal/db/query"
+	"github.com/zemld/Scently/perfume-hub/internal/errors"
+	"github.com/zemld/Scently/perfume-hub/internal/models"
+)
+
+func SelectNotes(ctx context.Context, params *models.NotesParameters) ([]perfumeModels.EnrichedNote, models.ProcessedState) {
+	rows, err := Pool.Query(ctx, queries.SelectEnrichedNotes, params.Unpack()...)
+	if err != nil {
+		log.Printf("Error executing query: %v\n", err)
+		return nil, models.ProcessedState{Error: errors.NewDBError("error executing query", err)}
+	}
+	defer rows.Close()
+
+	processedState := models.NewProcessedState()
+	notes := make([]perfumeModels.EnrichedNote, 0, len(params.Names))
+	for rows.Next() {
+		var note perfumeModels.EnrichedNote
+		if err := rows.Scan(&note.Name, &note.Characteristics, &note.Tags); err != nil {
+			log.Printf("Error scanning row: %v\n", err)
+			processedState.FailedCount++
+			continue
+		}
+		notes = append(notes, note)
+		processedState.SuccessfulCount++
+	}
+	return notes, processedState
+}
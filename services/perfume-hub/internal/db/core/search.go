@@ -0,0 +1,33 @@
+package core
+
+import (
+	"context"
+	"log"
+
+	queries "github.com/zemld/Scently/perfume-hub/internal/db/query"
+	"github.com/zemld/Scently/perfume-hub/internal/errors"
+	"github.com/zemld/Scently/perfume-hub/internal/models"
+)
+
+func Search(ctx context.Context, params *models.SearchParameters) ([]models.SearchResult, models.ProcessedState) {
+	rows, err := Pool.Query(ctx, queries.SearchPerfumes, params.Unpack()...)
+	if err != nil {
+		log.Printf("Error executing query: %v\n", err)
+		return nil, models.ProcessedState{Error: errors.NewDBError("error executing query", err)}
+	}
+	defer rows.Close()
+
+	processedState := models.NewProcessedState()
+	results := make([]models.SearchResult, 0, params.Limit)
+	for rows.Next() {
+		var result models.SearchResult
+		if err := rows.Scan(&result.Brand, &result.Name, &result.Sex, &result.ImageUrl, &result.Score); err != nil {
+			log.Printf("Error scanning row: %v\n", err)
+			processedState.FailedCount++
+			continue
+		}
+		results = append(results, result)
+		processedState.SuccessfulCount++
+	}
+	return results, processedState
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"log"
 
+	"github.com/jackc/pgx/v5"
 	perfumeModels "github.com/zemld/Scently/models"
 	"github.com/zemld/Scently/perfume-hub/internal/errors"
 	"github.com/zemld/Scently/perfume-hub/internal/models"
@@ -22,15 +23,7 @@ func Select(ctx context.Context, params *models.SelectParameters) ([]perfumeMode
 	processedState := models.NewProcessedState()
 	var perfumes []perfumeModels.Perfume
 	for rows.Next() {
-		var perfume perfumeModels.Perfume
-		err := rows.Scan(
-			&perfume.Brand,
-			&perfume.Name,
-			&perfume.Sex,
-			&perfume.ImageUrl,
-			&perfume.Properties,
-			&perfume.Shops,
-		)
+		perfume, err := scanPerfume(rows)
 		if err != nil {
 			log.Printf("Error scanning row: %v\n", err)
 			processedState.FailedCount++
@@ -41,3 +34,16 @@ func Select(ctx context.Context, params *models.SelectParameters) ([]perfumeMode
 	}
 	return perfumes, processedState
 }
+
+func scanPerfume(rows pgx.Rows) (perfumeModels.Perfume, error) {
+	var perfume perfumeModels.Perfume
+	err := rows.Scan(
+		&perfume.Brand,
+		&perfume.Name,
+		&perfume.Sex,
+		&perfume.ImageUrl,
+		&perfume.Properties,
+		&perfume.Shops,
+	)
+	return perfume, err
+}
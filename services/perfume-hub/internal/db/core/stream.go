@@ -0,0 +1,93 @@
+package core
+
+import (
+	"context"
+	"log"
+
+	"github.com/jackc/pgx/v5"
+	perfumeModels "github.com/zemld/Scently/models"
+	queries "github.com/zemld/Scently/perfume-hub/internal/db/query"
+	"github.com/zemld/Scently/perfume-hub/internal/errors"
+	"github.com/zemld/Scently/perfume-hub/internal/models"
+)
+
+type StreamFunc func(
+	ctx context.Context,
+	params *models.StreamParameters,
+	onHeader func(models.StreamHeader) error,
+	onPerfume func(perfumeModels.Perfume) error,
+) models.ProcessedState
+
+// Stream reads the header and all perfumes in one repeatable read transaction,
+// so the count matches the streamed perfumes even if the catalog is updated
+// meanwhile. Perfumes are fetched from a cursor in batches. A row that can't be
+// scanned stops the stream rather than being skipped, since the header already
+// counted it. An error from a callback stops the stream and is returned as is.
+func Stream(
+	ctx context.Context,
+	params *models.StreamParameters,
+	onHeader func(models.StreamHeader) error,
+	onPerfume func(perfumeModels.Perfume) error,
+) models.ProcessedState {
+	tx, err := Pool.BeginTx(ctx, pgx.TxOptions{IsoLevel: pgx.RepeatableRead, AccessMode: pgx.ReadOnly})
+	if err != nil {
+		log.Printf("Unable to begin transaction: %v\n", err)
+		return models.ProcessedState{Error: errors.NewDBError("unable to begin transaction", err)}
+	}
+	defer tx.Rollback(ctx)
+
+	var header models.StreamHeader
+	if err := tx.QueryRow(ctx, params.GetHeaderQuery(), params.Unpack()...).Scan(&header.Version, &header.Count); err != nil {
+		log.Printf("Error selecting stream header: %v\n", err)
+		return models.ProcessedState{Error: errors.NewDBError("error selecting stream header", err)}
+	}
+	if err := onHeader(header); err != nil {
+		return models.ProcessedState{Error: err}
+	}
+
+	if _, err := tx.Exec(ctx, queries.DeclarePerfumesCursor+params.GetQuery(), params.Unpack()...); err != nil {
+		log.Printf("Error declaring cursor: %v\n", err)
+		return models.ProcessedState{Error: errors.NewDBError("error declaring cursor", err)}
+	}
+
+	processedState := models.NewProcessedState()
+	for {
+		fetched, err := fetchBatch(ctx, tx, onPerfume, &processedState)
+		if err != nil {
+			processedState.Error = err
+			return processedState
+		}
+		if fetched == 0 {
+			return processedState
+		}
+	}
+}
+
+func fetchBatch(ctx context.Context, tx pgx.Tx, onPerfume func(perfumeModels.Perfume) error, processedState *models.ProcessedState) (int, error) {
+	rows, err := tx.Query(ctx, queries.FetchPerfumesCursor)
+	if err != nil {
+		log.Printf("Error fetching from cursor: %v\n", err)
+		return 0, errors.NewDBError("error fetching from cursor", err)
+	}
+	defer rows.Close()
+
+	fetched := 0
+	for rows.Next() {
+		fetched++
+		perfume, err := scanPerfume(rows)
+		if err != nil {
+			log.Printf("Error scanning row: %v\n", err)
+			processedState.FailedCount++
+			return fetched, errors.NewDBError("error scanning row", err)
+		}
+		if err := onPerfume(perfume); err != nil {
+			return fetched, err
+		}
+		processedState.SuccessfulCount++
+	}
+	if err := rows.Err(); err != nil {
+		log.Printf("Error reading cursor: %v\n", err)
+		return fetched, errors.NewDBError("error reading cursor", err)
+	}
+	return fetched, nil
+}
@@ -0,0 +1,92 @@
+package core
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+
+	perfumeModels "github.com/zemld/Scently/models"
+	"github.com/zemld/Scently/perfume-hub/internal/models"
+)
+
+// mockRows отдает строки с брендами из brands; для индексов из scanErrs Scan
+// возвращает ошибку.
+type mockRows struct {
+	brands   []string
+	scanErrs map[int]error
+	current  int
+	closed   bool
+}
+
+func (r *mockRows) Next() bool {
+	if r.current >= len(r.brands) {
+		return false
+	}
+	r.current++
+	return true
+}
+
+func (r *mockRows) Scan(dest ...any) error {
+	if err, ok := r.scanErrs[r.current-1]; ok {
+		return err
+	}
+	*dest[0].(*string) = r.brands[r.current-1]
+	return nil
+}
+
+func (r *mockRows) Close()                                       { r.closed = true }
+func (r *mockRows) Err() error                                   { return nil }
+func (r *mockRows) CommandTag() pgconn.CommandTag                { return pgconn.NewCommandTag("FETCH") }
+func (r *mockRows) FieldDescriptions() []pgconn.FieldDescription { return nil }
+func (r *mockRows) Values() ([]any, error)                       { panic("not implemented") }
+func (r *mockRows) RawValues() [][]byte                          { panic("not implemented") }
+func (r *mockRows) Conn() *pgx.Conn                              { return nil }
+
+func TestFetchBatch(t *testing.T) {
+	tx := newMockTx()
+	tx.queryRows = &mockRows{brands: []string{"Dior", "Chanel"}}
+
+	var streamed []string
+	state := models.NewProcessedState()
+	fetched, err := fetchBatch(context.Background(), tx, func(perfume perfumeModels.Perfume) error {
+		streamed = append(streamed, perfume.Brand)
+		return nil
+	}, &state)
+	if err != nil {
+		t.Fatalf("fetchBatch() error = %v", err)
+	}
+	if fetched != 2 || state.SuccessfulCount != 2 || len(streamed) != 2 {
+		t.Fatalf("fetchBatch() fetched = %d, state = %+v, streamed = %v", fetched, state, streamed)
+	}
+}
+
+func TestFetchBatch_ScanError(t *testing.T) {
+	rows := &mockRows{
+		brands:   []string{"Dior", "Chanel", "Tom Ford"},
+		scanErrs: map[int]error{1: errors.New("can't scan shops")},
+	}
+	tx := newMockTx()
+	tx.queryRows = rows
+
+	var streamed []string
+	state := models.NewProcessedState()
+	_, err := fetchBatch(context.Background(), tx, func(perfume perfumeModels.Perfume) error {
+		streamed = append(streamed, perfume.Brand)
+		return nil
+	}, &state)
+	if err == nil {
+		t.Fatal("fetchBatch() error = nil, want scan error")
+	}
+	if len(streamed) != 1 || streamed[0] != "Dior" {
+		t.Fatalf("fetchBatch() streamed = %v, want only perfumes before the failed row", streamed)
+	}
+	if state.SuccessfulCount != 1 || state.FailedCount != 1 {
+		t.Fatalf("fetchBatch() state = %+v, want 1 successful and 1 failed", state)
+	}
+	if !rows.closed {
+		t.Fatal("fetchBatch() didn't close rows")
+	}
+}
@@ -45,6 +45,7 @@ type mockTx struct {
 	execErrs      map[string]error
 	execErrCounts map[string]int // счетчик вызовов для каждого SQL запроса
 	execErrOnCall map[string]int // на каком вызове вернуть ошибку (0 = всегда)
+	queryRows     pgx.Rows       // строки, которые вернет Query
 }
 
 func newMockTx() *mockTx {
@@ -88,9 +89,14 @@ func (m *mockTx) LargeObjects() pgx.LargeObjects                         { panic
 func (m *mockTx) Prepare(context.Context, string, string) (*pgconn.StatementDescription, error) {
 	panic("not implemented")
 }
-func (m *mockTx) Query(context.Context, string, ...any) (pgx.Rows, error) { panic("not implemented") }
-func (m *mockTx) QueryRow(context.Context, string, ...any) pgx.Row        { panic("not implemented") }
-func (m *mockTx) Conn() *pgx.Conn                                         { return nil }
+func (m *mockTx) Query(context.Context, string, ...any) (pgx.Rows, error) {
+	if m.queryRows == nil {
+		panic("not implemented")
+	}
+	return m.queryRows, nil
+}
+func (m *mockTx) QueryRow(context.Context, string, ...any) pgx.Row { panic("not implemented") }
+func (m *mockTx) Conn() *pgx.Conn                                  { return nil }
 
 func TestNewUpdateStatus(t *testing.T) {
 	tests := []struct {
@@ -0,0 +1,37 @@
+package core
+
+import (
+	"context"
+	"log"
+
+	"github.com/zemld/Scently/perfume-hub/internal/errors"
+	"github.com/zemld/Scently/perfume-hub/internal/models"
+)
+
+func SelectVocabulary(ctx context.Context, kind models.VocabularyKind) ([]models.VocabularyItem, models.ProcessedState) {
+	query := kind.GetQuery()
+	if query == "" {
+		return nil, models.ProcessedState{Error: errors.NewValidationError("unknown vocabulary " + string(kind))}
+	}
+
+	rows, err := Pool.Query(ctx, query)
+	if err != nil {
+		log.Printf("Error executing query: %v\n", err)
+		return nil, models.ProcessedState{Error: errors.NewDBError("error executing query", err)}
+	}
+	defer rows.Close()
+
+	processedState := models.NewProcessedState()
+	items := make([]models.VocabularyItem, 0)
+	for rows.Next() {
+		var item models.VocabularyItem
+		if err := rows.Scan(&item.Name, &item.Usage, &item.Characteristics, &item.Tags); err != nil {
+			log.Printf("Error scanning row: %v\n", err)
+			processedState.FailedCount++
+			continue
+		}
+		items = append(items, item)
+		processedState.SuccessfulCount++
+	}
+	return items, processedState
+}
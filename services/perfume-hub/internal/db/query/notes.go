@@ -0,0 +1,21 @@
+package queries
+
+const SelectEnrichedNotes = `
+	SELECT
+		n.name,
+		COALESCE(
+			(SELECT jsonb_agg(jsonb_build_object('name', nc.characteristic_name, 'value', nc.value))
+			FROM notes_with_characteristics nc
+			WHERE nc.note_name = n.name),
+			'[]'::jsonb
+		) AS characteristics,
+		COALESCE(
+			(SELECT jsonb_agg(nt.tag_name)
+			FROM notes_with_tags nt
+			WHERE nt.note_name = n.name),
+			'[]'::jsonb
+		) AS tags
+	FROM notes n
+	WHERE n.name = ANY($1)
+	ORDER BY n.name
+	`
@@ -0,0 +1,24 @@
+package queries
+
+// SearchPerfumes matches the canonized query against a substring of canonized
+// brand and name, so both "sauvage" and "diorsauvge" find Dior Sauvage.
+// Prefix matches go first.
+const SearchPerfumes = `
+	SELECT
+		pb.brand,
+		pb.name,
+		s.sex,
+		COALESCE(pb.image_url, ''),
+		word_similarity($1, pb.canonized_brand || pb.canonized_name) AS score
+	FROM perfume_base_info pb
+	INNER JOIN sexes s ON s.id = pb.sex_id
+	WHERE $1 <% (pb.canonized_brand || pb.canonized_name)
+		AND ($2 = '' OR s.sex = $2 OR ($2 <> 'unisex' AND s.sex = 'unisex'))
+	ORDER BY
+		(pb.canonized_brand || pb.canonized_name LIKE $1 || '%' OR pb.canonized_name LIKE $1 || '%') DESC,
+		score DESC,
+		s.sex = $2 DESC,
+		pb.brand,
+		pb.name
+	LIMIT $3
+	`
@@ -0,0 +1,16 @@
+package queries
+
+const (
+	// SelectStreamHeader returns the catalog version, which is the time of the
+	// last update of any perfume, and the number of perfumes to stream.
+	SelectStreamHeader = `SELECT
+		(SELECT COALESCE(MAX(updated_at), 'epoch'::timestamp) FROM perfume_base_info),
+		COUNT(*)
+	FROM perfume_base_info_with_pages pb
+	`
+	OrderStreamedPerfumes = `
+	ORDER BY espv.canonized_brand, espv.canonized_name, espv.sex_id
+	`
+	DeclarePerfumesCursor = `DECLARE perfumes_stream NO SCROLL CURSOR FOR `
+	FetchPerfumesCursor   = `FETCH FORWARD 400 FROM perfumes_stream`
+)
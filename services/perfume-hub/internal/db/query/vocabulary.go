@@ -0,0 +1,91 @@
+package queries
+
+const (
+	withPerfumeNotes = `WITH
+	perfume_notes AS (
+		SELECT canonized_brand, canonized_name, sex_id, note FROM upper_notes
+		UNION
+		SELECT canonized_brand, canonized_name, sex_id, note FROM core_notes
+		UNION
+		SELECT canonized_brand, canonized_name, sex_id, note FROM base_notes
+	),`
+	selectWordUsage = `
+		COALESCE(
+			(SELECT jsonb_object_agg(u.sex, u.count) FROM usage u WHERE u.name = w.name),
+			'{}'::jsonb
+		) AS usage`
+	withoutNoteDetails = `
+		'[]'::jsonb AS characteristics,
+		'[]'::jsonb AS tags`
+
+	SelectTagsVocabulary = withPerfumeNotes + `
+	usage AS (
+		SELECT nt.tag_name AS name, s.sex AS sex, COUNT(DISTINCT (pn.canonized_brand, pn.canonized_name, pn.sex_id)) AS count
+		FROM perfume_notes pn
+		INNER JOIN notes_with_tags nt ON nt.note_name = pn.note
+		INNER JOIN sexes s ON s.id = pn.sex_id
+		GROUP BY nt.tag_name, s.sex
+	)
+	SELECT w.name,` + selectWordUsage + `,` + withoutNoteDetails + `
+	FROM tags w
+	ORDER BY w.name
+	`
+	SelectNotesVocabulary = withPerfumeNotes + `
+	usage AS (
+		SELECT pn.note AS name, s.sex AS sex, COUNT(DISTINCT (pn.canonized_brand, pn.canonized_name, pn.sex_id)) AS count
+		FROM perfume_notes pn
+		INNER JOIN sexes s ON s.id = pn.sex_id
+		GROUP BY pn.note, s.sex
+	)
+	SELECT w.name,` + selectWordUsage + `,
+		COALESCE(
+			(SELECT jsonb_agg(jsonb_build_object('name', nc.characteristic_name, 'value', nc.value))
+			FROM notes_with_characteristics nc
+			WHERE nc.note_name = w.name),
+			'[]'::jsonb
+		) AS characteristics,
+		COALESCE(
+			(SELECT jsonb_agg(nt.tag_name)
+			FROM notes_with_tags nt
+			WHERE nt.note_name = w.name),
+			'[]'::jsonb
+		) AS tags
+	FROM notes w
+	ORDER BY w.name
+	`
+	SelectCharacteristicsVocabulary = withPerfumeNotes + `
+	usage AS (
+		SELECT nc.characteristic_name AS name, s.sex AS sex, COUNT(DISTINCT (pn.canonized_brand, pn.canonized_name, pn.sex_id)) AS count
+		FROM perfume_notes pn
+		INNER JOIN notes_with_characteristics nc ON nc.note_name = pn.note AND nc.value > 0
+		INNER JOIN sexes s ON s.id = pn.sex_id
+		GROUP BY nc.characteristic_name, s.sex
+	)
+	SELECT w.name,` + selectWordUsage + `,` + withoutNoteDetails + `
+	FROM characteristics w
+	ORDER BY w.name
+	`
+	SelectFamiliesVocabulary = `WITH
+	usage AS (
+		SELECT f.family AS name, s.sex AS sex, COUNT(*) AS count
+		FROM families f
+		INNER JOIN sexes s ON s.id = f.sex_id
+		GROUP BY f.family, s.sex
+	)
+	SELECT w.name,` + selectWordUsage + `,` + withoutNoteDetails + `
+	FROM (SELECT DISTINCT family AS name FROM families) w
+	ORDER BY w.name
+	`
+	SelectTypesVocabulary = `WITH
+	usage AS (
+		SELECT pb.type AS name, s.sex AS sex, COUNT(*) AS count
+		FROM perfume_base_info pb
+		INNER JOIN sexes s ON s.id = pb.sex_id
+		WHERE pb.type IS NOT NULL AND btrim(pb.type) <> ''
+		GROUP BY pb.type, s.sex
+	)
+	SELECT w.name,` + selectWordUsage + `,` + withoutNoteDetails + `
+	FROM (SELECT DISTINCT name FROM usage) w
+	ORDER BY w.name
+	`
+)
@@ -30,6 +30,10 @@ type SelectParameters struct {
 	parametersCount int
 }
 
+type NotesParameters struct {
+	Names []string
+}
+
 func NewUpdateParameters() *UpdateParameters {
 	return &UpdateParameters{IsHard: false}
 }
@@ -132,6 +136,27 @@ func (p SelectParameters) Unpack() []any {
 	return args
 }
 
+func NewNotesParameters() *NotesParameters {
+	return &NotesParameters{Names: []string{}}
+}
+
+func (p *NotesParameters) WithNames(names []string) *NotesParameters {
+	seen := make(map[string]struct{}, len(names))
+	for _, name := range names {
+		name = strings.ToLower(strings.TrimSpace(name))
+		if _, ok := seen[name]; ok || name == "" {
+			continue
+		}
+		seen[name] = struct{}{}
+		p.Names = append(p.Names, name)
+	}
+	return p
+}
+
+func (p NotesParameters) Unpack() []any {
+	return []any{p.Names}
+}
+
 func canonize(s string) string {
 	canonized := strings.Builder{}
 
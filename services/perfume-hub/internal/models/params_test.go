@@ -246,3 +246,29 @@ func TestSelectParameters_GetQuery(t *testing.T) {
 		})
 	}
 }
+
+func TestNotesParameters_WithNames(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    []string
+		expected []string
+	}{
+		{"empty", []string{}, []string{}},
+		{"lowercases and trims", []string{" Vanilla ", "ROSE"}, []string{"vanilla", "rose"}},
+		{"drops duplicates", []string{"vanilla", "Vanilla", "rose"}, []string{"vanilla", "rose"}},
+		{"drops empty names", []string{"", " ", "musk"}, []string{"musk"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := NewNotesParameters().WithNames(tt.input)
+			if !reflect.DeepEqual(got.Names, tt.expected) {
+				t.Errorf("WithNames(%v) = %v, want %v", tt.input, got.Names, tt.expected)
+			}
+			unpacked := got.Unpack()
+			if len(unpacked) != 1 || !reflect.DeepEqual(unpacked[0], tt.expected) {
+				t.Errorf("Unpack() = %v, want [%v]", unpacked, tt.expected)
+			}
+		})
+	}
+}
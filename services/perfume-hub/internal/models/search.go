@@ -0,0 +1,52 @@
+package models
+
+const (
+	DefaultSearchLimit   = 10
+	MaxSearchLimit       = 50
+	MinSearchQueryLength = 2
+)
+
+type SearchParameters struct {
+	Query string
+	Sex   string
+	Limit int
+}
+
+type SearchResult struct {
+	Brand    string  `json:"brand"`
+	Name     string  `json:"name"`
+	Sex      string  `json:"sex"`
+	ImageUrl string  `json:"image_url"`
+	Score    float64 `json:"score"`
+}
+
+func NewSearchParameters() *SearchParameters {
+	return &SearchParameters{Limit: DefaultSearchLimit}
+}
+
+func (p *SearchParameters) WithQuery(query string) *SearchParameters {
+	p.Query = canonize(query)
+	return p
+}
+
+// WithSex keeps perfumes of the sex and unisex ones. Without a sex perfumes of
+// every sex are searched.
+func (p *SearchParameters) WithSex(sex string) *SearchParameters {
+	if sex != "male" && sex != "female" && sex != "unisex" {
+		sex = ""
+	}
+	p.Sex = sex
+	return p
+}
+
+func (p *SearchParameters) WithLimit(limit int) *SearchParameters {
+	if limit <= 0 {
+		limit = DefaultSearchLimit
+	}
+	p.Limit = min(limit, MaxSearchLimit)
+	return p
+}
+
+func (p SearchParameters) Unpack() []any {
+	return []any{p.Query, p.Sex, p.Limit}
+}
@@ -0,0 +1,30 @@
+package models
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestSearchParameters(t *testing.T) {
+	tests := []struct {
+		name string
+		p    *SearchParameters
+		want []any
+	}{
+		{"defaults", NewSearchParameters(), []any{"", "", DefaultSearchLimit}},
+		{"canonized query", NewSearchParameters().WithQuery("Dior Sauvge"), []any{"diorsauvge", "", DefaultSearchLimit}},
+		{"known sex", NewSearchParameters().WithSex("female"), []any{"", "female", DefaultSearchLimit}},
+		{"unknown sex", NewSearchParameters().WithSex("other"), []any{"", "", DefaultSearchLimit}},
+		{"limit", NewSearchParameters().WithLimit(5), []any{"", "", 5}},
+		{"non-positive limit", NewSearchParameters().WithLimit(0), []any{"", "", DefaultSearchLimit}},
+		{"capped limit", NewSearchParameters().WithLimit(1000), []any{"", "", MaxSearchLimit}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.p.Unpack(); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Unpack() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
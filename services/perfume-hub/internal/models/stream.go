@@ -0,0 +1,48 @@
+package models
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	queries "github.com/zemld/Scently/perfume-hub/internal/db/query"
+)
+
+// StreamHeader is the first record of a perfumes stream.
+type StreamHeader struct {
+	Version time.Time `json:"version"`
+	Count   int       `json:"count"`
+}
+
+type StreamParameters struct {
+	Sex string
+}
+
+func NewStreamParameters() *StreamParameters {
+	return &StreamParameters{}
+}
+
+func (p *StreamParameters) WithSex(sex string) *StreamParameters {
+	p.Sex = sex
+	return p
+}
+
+func (p *StreamParameters) GetQuery() string {
+	choosingPerfumesQuery := p.filterBySex(strings.TrimSpace(queries.SelectPerfumesBaseInfo))
+	return fmt.Sprintf(queries.WithSelect, choosingPerfumesQuery) + queries.EnrichSelectedPerfumes + queries.OrderStreamedPerfumes
+}
+
+func (p *StreamParameters) GetHeaderQuery() string {
+	return p.filterBySex(strings.TrimSpace(queries.SelectStreamHeader))
+}
+
+func (p *StreamParameters) filterBySex(query string) string {
+	return NewSelectParameters().WithSex(p.Sex).updateQueryWithSexFilter(query)
+}
+
+func (p StreamParameters) Unpack() []any {
+	if p.Sex == "male" || p.Sex == "female" {
+		return []any{p.Sex}
+	}
+	return nil
+}
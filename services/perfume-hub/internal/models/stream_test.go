@@ -0,0 +1,67 @@
+package models
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	queries "github.com/zemld/Scently/perfume-hub/internal/db/query"
+)
+
+func TestStreamParameters_GetHeaderQuery(t *testing.T) {
+	baseQuery := strings.TrimSpace(queries.SelectStreamHeader)
+
+	tests := []struct {
+		name string
+		p    *StreamParameters
+		want string
+	}{
+		{"no sex - defaults to unisex", NewStreamParameters(), baseQuery + " WHERE sex = 'unisex'"},
+		{"sex unisex", NewStreamParameters().WithSex("unisex"), baseQuery + " WHERE sex = 'unisex'"},
+		{"sex female", NewStreamParameters().WithSex("female"), baseQuery + " WHERE (sex = 'unisex' OR sex = $1)"},
+		{"sex male", NewStreamParameters().WithSex("male"), baseQuery + " WHERE (sex = 'unisex' OR sex = $1)"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.p.GetHeaderQuery(); got != tt.want {
+				t.Errorf("GetHeaderQuery() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestStreamParameters_GetQuery(t *testing.T) {
+	query := NewStreamParameters().WithSex("female").GetQuery()
+
+	if !strings.Contains(query, "FROM perfume_base_info_with_pages pb WHERE (sex = 'unisex' OR sex = $1)\n") {
+		t.Errorf("GetQuery() does not filter by sex: %q", query)
+	}
+	if strings.Contains(query, "page_number") {
+		t.Errorf("GetQuery() should not filter by page: %q", query)
+	}
+	if !strings.HasSuffix(query, queries.OrderStreamedPerfumes) {
+		t.Errorf("GetQuery() should order perfumes: %q", query)
+	}
+}
+
+func TestStreamParameters_Unpack(t *testing.T) {
+	tests := []struct {
+		name     string
+		p        *StreamParameters
+		wantArgs []any
+	}{
+		{"no sex", NewStreamParameters(), nil},
+		{"sex unisex", NewStreamParameters().WithSex("unisex"), nil},
+		{"sex female", NewStreamParameters().WithSex("female"), []any{"female"}},
+		{"sex male", NewStreamParameters().WithSex("male"), []any{"male"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.p.Unpack(); !reflect.DeepEqual(got, tt.wantArgs) {
+				t.Errorf("Unpack() = %#v, want %#v", got, tt.wantArgs)
+			}
+		})
+	}
+}
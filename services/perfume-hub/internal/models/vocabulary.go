@@ -0,0 +1,49 @@
+package models
+
+import (
+	"github.com/zemld/Scently/models"
+	queries "github.com/zemld/Scently/perfume-hub/internal/db/query"
+)
+
+type VocabularyKind string
+
+const (
+	TagsVocabulary            VocabularyKind = "tags"
+	NotesVocabulary           VocabularyKind = "notes"
+	CharacteristicsVocabulary VocabularyKind = "characteristics"
+	FamiliesVocabulary        VocabularyKind = "families"
+	TypesVocabulary           VocabularyKind = "types"
+)
+
+var VocabularyKinds = []VocabularyKind{
+	TagsVocabulary,
+	NotesVocabulary,
+	CharacteristicsVocabulary,
+	FamiliesVocabulary,
+	TypesVocabulary,
+}
+
+// VocabularyItem is a word used by the catalog with the number of perfumes of
+// each sex using it. Only notes have tags and characteristics.
+type VocabularyItem struct {
+	Name            string                      `json:"name"`
+	Usage           map[string]int              `json:"usage"`
+	Tags            []string                    `json:"tags,omitempty"`
+	Characteristics []models.NoteCharacteristic `json:"characteristics,omitempty"`
+}
+
+func (k VocabularyKind) GetQuery() string {
+	switch k {
+	case TagsVocabulary:
+		return queries.SelectTagsVocabulary
+	case NotesVocabulary:
+		return queries.SelectNotesVocabulary
+	case CharacteristicsVocabulary:
+		return queries.SelectCharacteristicsVocabulary
+	case FamiliesVocabulary:
+		return queries.SelectFamiliesVocabulary
+	case TypesVocabulary:
+		return queries.SelectTypesVocabulary
+	}
+	return ""
+}
@@ -0,0 +1,21 @@
+package models
+
+import "testing"
+
+func TestVocabularyKind_GetQuery(t *testing.T) {
+	queries := make(map[string]VocabularyKind)
+	for _, kind := range VocabularyKinds {
+		query := kind.GetQuery()
+		if query == "" {
+			t.Errorf("GetQuery() for %s is empty", kind)
+		}
+		if other, ok := queries[query]; ok {
+			t.Errorf("GetQuery() for %s is the same as for %s", kind, other)
+		}
+		queries[query] = kind
+	}
+
+	if query := VocabularyKind("brands").GetQuery(); query != "" {
+		t.Errorf("GetQuery() for unknown kind = %q, want empty", query)
+	}
+}
@@ -0,0 +1,14 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE EXTENSION IF NOT EXISTS pg_trgm;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+CREATE INDEX IF NOT EXISTS idx_perfume_base_info_search ON perfume_base_info
+USING gin ((canonized_brand || canonized_name) gin_trgm_ops);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS idx_perfume_base_info_search;
+-- +goose StatementEnd
@@ -5,11 +5,10 @@ import (
 	"net/http"
 
 	"github.com/zemld/Scently/perfumist/internal/config"
-	"github.com/zemld/Scently/perfumist/internal/models/advising"
+	"github.com/zemld/Scently/perfumist/internal/service"
 )
 
 func AISuggest(w http.ResponseWriter, r *http.Request) {
-	ctx := r.Context()
 	params, err := generalParseSimilarParameters(r)
 	if err != nil {
 		log.Printf("Error parsing parameters: %v\n", err)
@@ -17,26 +16,24 @@ func AISuggest(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	perfumeHubFetcher, err := createPerfumeHubFetcher(config.Manager())
+	exclusions, err := parseExclusionsParameters(r, config.Manager().GetIntWithDefault("max_disliked_count", 10))
 	if err != nil {
-		log.Printf("Error creating perfume hub fetcher: %v\n", err)
 		handleError(w, err)
 		return
 	}
-	aiFetcher := createAIFetcher(config.Manager())
-	log.Printf("AI fetcher created: %+v\n", aiFetcher)
-	aiAdvisor := advising.NewAI(
-		aiFetcher,
-		perfumeHubFetcher,
-		config.Manager(),
-	)
 
-	suggested, err := aiAdvisor.Advise(ctx, params)
+	offers, err := parseOffersParameters(r)
 	if err != nil {
-		log.Printf("Error advising: %v\n", err)
 		handleError(w, err)
 		return
 	}
 
-	WriteResponse(w, SuggestResponse{Suggested: suggested}, http.StatusOK)
+	advisor, err := service.NewAIAdvisor(config.Manager(), createFavouriteResolver(r, config.Manager()), parseDescribeParameter(r))
+	if err != nil {
+		log.Printf("Error creating AI advisor: %v\n", err)
+		handleError(w, err)
+		return
+	}
+
+	writeSuggestions(w, r, advisor, *params.WithExclusions(exclusions).WithOffers(offers))
 }
@@ -0,0 +1,155 @@
+package handlers
+
+import (
+	"fmt"
+	"maps"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/zemld/Scently/perfumist/internal/config"
+	"github.com/zemld/Scently/perfumist/internal/errors"
+	"github.com/zemld/Scently/perfumist/internal/models/advising"
+	"github.com/zemld/Scently/perfumist/internal/models/matching"
+	"github.com/zemld/Scently/perfumist/internal/models/parameters"
+	"github.com/zemld/Scently/perfumist/internal/service"
+)
+
+func SuggestByCharacteristics(w http.ResponseWriter, r *http.Request) {
+	targets, err := parseTargetsParameter(r)
+	if err != nil {
+		handleError(w, err)
+		return
+	}
+	importance, err := parseImportanceParameter(r)
+	if err != nil {
+		handleError(w, err)
+		return
+	}
+
+	exclusions, err := parseExclusionsParameters(r, config.Manager().GetIntWithDefault("max_disliked_count", 10))
+	if err != nil {
+		handleError(w, err)
+		return
+	}
+
+	offers, err := parseOffersParameters(r)
+	if err != nil {
+		handleError(w, err)
+		return
+	}
+
+	diversity, err := parseDiversityParameters(r, config.Manager())
+	if err != nil {
+		handleError(w, err)
+		return
+	}
+
+	advisor := advising.NewCharacteristicsBased(
+		matching.NewCharacteristicsTarget(
+			*matching.NewBaseWeights(
+				config.Manager().GetFloatWithDefault("upper_notes_weight", 0.2),
+				config.Manager().GetFloatWithDefault("core_notes_weight", 0.35),
+				config.Manager().GetFloatWithDefault("base_notes_weight", 0.45),
+			),
+			targets,
+			importance,
+		),
+		service.PerfumesCatalog(),
+		config.Manager(),
+	)
+
+	writeSuggestions(w, r, advisor, *parameters.NewGet().WithSex(parseSexParameter(r)).WithExclusions(exclusions).WithOffers(offers).WithDiversity(diversity))
+}
+
+// parseTargetsParameter reads targets like "sweetness:0.8,base.woodiness:0.9".
+// A target without a level applies to every level unless the level overrides it.
+func parseTargetsParameter(r *http.Request) (map[matching.NoteLevel]map[string]float64, error) {
+	items := parseListParameter(r, parameters.TargetsParamKey)
+	if len(items) == 0 {
+		return nil, errors.NewValidationError(parameters.TargetsParamKey, "at least one target is required")
+	}
+
+	common := make(map[string]float64)
+	leveled := make(map[matching.NoteLevel]map[string]float64)
+	for _, item := range items {
+		name, value, err := parseCharacteristicValue(parameters.TargetsParamKey, item)
+		if err != nil {
+			return nil, err
+		}
+		if value < 0 || value > 1 {
+			return nil, errors.NewValidationError(parameters.TargetsParamKey, fmt.Sprintf("target for %s must be between 0 and 1", name))
+		}
+
+		rawLevel, characteristic, hasLevel := strings.Cut(name, ".")
+		if !hasLevel {
+			characteristic = name
+		}
+		if err := validateCharacteristic(parameters.TargetsParamKey, characteristic); err != nil {
+			return nil, err
+		}
+		if !hasLevel {
+			common[characteristic] = value
+			continue
+		}
+		level, ok := matching.ParseNoteLevel(rawLevel)
+		if !ok {
+			return nil, errors.NewValidationError(parameters.TargetsParamKey, fmt.Sprintf("unknown level %q, expected one of upper, core, base", rawLevel))
+		}
+		if leveled[level] == nil {
+			leveled[level] = make(map[string]float64)
+		}
+		leveled[level][characteristic] = value
+	}
+
+	targets := make(map[matching.NoteLevel]map[string]float64)
+	for _, level := range matching.NoteLevels {
+		target := maps.Clone(common)
+		maps.Copy(target, leveled[level])
+		if len(target) > 0 {
+			targets[level] = target
+		}
+	}
+	return targets, nil
+}
+
+func parseImportanceParameter(r *http.Request) (map[string]float64, error) {
+	importance := make(map[string]float64)
+	for _, item := range parseListParameter(r, parameters.ImportanceParamKey) {
+		name, value, err := parseCharacteristicValue(parameters.ImportanceParamKey, item)
+		if err != nil {
+			return nil, err
+		}
+		if err := validateCharacteristic(parameters.ImportanceParamKey, name); err != nil {
+			return nil, err
+		}
+		if value < 0 {
+			return nil, errors.NewValidationError(parameters.ImportanceParamKey, fmt.Sprintf("importance of %s must not be negative", name))
+		}
+		importance[name] = value
+	}
+	return importance, nil
+}
+
+func parseCharacteristicValue(key string, item string) (string, float64, error) {
+	name, rawValue, ok := strings.Cut(item, ":")
+	if !ok {
+		return "", 0, errors.NewValidationError(key, "must be in characteristic:value format")
+	}
+	value, err := strconv.ParseFloat(strings.TrimSpace(rawValue), 64)
+	if err != nil {
+		return "", 0, errors.NewValidationError(key, fmt.Sprintf("invalid value %q", rawValue))
+	}
+	return strings.ToLower(strings.TrimSpace(name)), value, nil
+}
+
+func validateCharacteristic(key string, characteristic string) error {
+	if !slices.Contains(matching.CharacteristicNames, characteristic) {
+		return errors.NewValidationError(
+			key,
+			fmt.Sprintf("unknown characteristic %q, expected one of %s", characteristic, strings.Join(matching.CharacteristicNames, ", ")),
+		)
+	}
+	return nil
+}
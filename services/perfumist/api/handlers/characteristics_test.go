@@ -0,0 +1,81 @@
+package handlers
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/zemld/Scently/perfumist/internal/errors"
+	"github.com/zemld/Scently/perfumist/internal/models/matching"
+)
+
+func TestParseTargetsParameter(t *testing.T) {
+	t.Parallel()
+
+	req := httptest.NewRequest(http.MethodGet, "/?targets=sweetness:0.8,base.woodiness:0.9,base.sweetness:0.2", nil)
+	targets, err := parseTargetsParameter(req)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if len(targets) != 3 {
+		t.Fatalf("expected targets on every level, got %v", targets)
+	}
+	if targets[matching.UpperLevel]["sweetness"] != 0.8 || len(targets[matching.UpperLevel]) != 1 {
+		t.Fatalf("unexpected upper targets %v", targets[matching.UpperLevel])
+	}
+	if targets[matching.BaseLevel]["sweetness"] != 0.2 || targets[matching.BaseLevel]["woodiness"] != 0.9 {
+		t.Fatalf("expected base targets to override common ones, got %v", targets[matching.BaseLevel])
+	}
+}
+
+func TestParseTargetsParameter_SingleLevel(t *testing.T) {
+	t.Parallel()
+
+	req := httptest.NewRequest(http.MethodGet, "/?targets=core.warmth:1", nil)
+	targets, err := parseTargetsParameter(req)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if len(targets) != 1 || targets[matching.CoreLevel]["warmth"] != 1 {
+		t.Fatalf("expected only core target, got %v", targets)
+	}
+}
+
+func TestParseTargetsParameter_Invalid(t *testing.T) {
+	t.Parallel()
+
+	for _, url := range []string{
+		"/",
+		"/?targets=sweetness",
+		"/?targets=sweetness:high",
+		"/?targets=sweetness:1.5",
+		"/?targets=bitterness:0.5",
+		"/?targets=heart.sweetness:0.5",
+	} {
+		req := httptest.NewRequest(http.MethodGet, url, nil)
+		_, err := parseTargetsParameter(req)
+		if _, ok := err.(*errors.ValidationError); !ok {
+			t.Fatalf("%s: expected ValidationError, got %v", url, err)
+		}
+	}
+}
+
+func TestParseImportanceParameter(t *testing.T) {
+	t.Parallel()
+
+	req := httptest.NewRequest(http.MethodGet, "/?importance=sweetness:2,%20Freshness:0", nil)
+	importance, err := parseImportanceParameter(req)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if importance["sweetness"] != 2 || importance["freshness"] != 0 {
+		t.Fatalf("unexpected importance %v", importance)
+	}
+
+	for _, url := range []string{"/?importance=sweetness:-1", "/?importance=bitterness:1"} {
+		req := httptest.NewRequest(http.MethodGet, url, nil)
+		if _, err := parseImportanceParameter(req); err == nil {
+			t.Fatalf("%s: expected error", url)
+		}
+	}
+}
@@ -1,23 +1,36 @@
 package handlers
 
 import (
+	"context"
 	"encoding/json"
+	"fmt"
 	"net/http"
-	"os"
+	"strconv"
+	"strings"
 
 	"github.com/zemld/Scently/models"
 	"github.com/zemld/Scently/perfumist/internal/errors"
-	"github.com/zemld/Scently/perfumist/internal/models/fetching"
+	"github.com/zemld/Scently/perfumist/internal/models/advising"
+	"github.com/zemld/Scently/perfumist/internal/models/experiments"
+	"github.com/zemld/Scently/perfumist/internal/models/matching"
 	"github.com/zemld/Scently/perfumist/internal/models/parameters"
+	"github.com/zemld/Scently/perfumist/internal/service"
 	"github.com/zemld/config-manager/pkg/cm"
 )
 
 type SuggestResponse struct {
 	Suggested []models.Ranked `json:"suggested"`
+	VariantID string          `json:"variant_id,omitempty"`
+}
+
+type ExplainedSuggestResponse struct {
+	Suggested []advising.Explained `json:"suggested"`
+	VariantID string               `json:"variant_id,omitempty"`
 }
 
 type ErrorResponse struct {
-	Error string `json:"error"`
+	Error      string             `json:"error"`
+	Candidates []errors.Candidate `json:"candidates,omitempty"`
 }
 
 func generalParseSimilarParameters(r *http.Request) (parameters.RequestPerfume, error) {
@@ -29,6 +42,163 @@ func generalParseSimilarParameters(r *http.Request) (parameters.RequestPerfume,
 	return *params, params.Validate()
 }
 
+func parseFavouritesParameters(r *http.Request, maxCount int) ([]parameters.RequestPerfume, error) {
+	if len(r.URL.Query()[parameters.FavouritesParamKey]) == 0 {
+		return nil, errors.NewValidationError(parameters.FavouritesParamKey, "at least one perfume is required")
+	}
+	return parsePerfumesParameter(r, parameters.FavouritesParamKey, maxCount)
+}
+
+func parseExclusionsParameters(r *http.Request, maxDislikedCount int) (parameters.Exclusions, error) {
+	disliked, err := parsePerfumesParameter(r, parameters.DislikedParamKey, maxDislikedCount)
+	if err != nil {
+		return parameters.Exclusions{}, err
+	}
+	return parameters.Exclusions{
+		Notes:    parseListParameter(r, parameters.ExcludedNotesParamKey),
+		Tags:     parseListParameter(r, parameters.ExcludedTagsParamKey),
+		Disliked: disliked,
+	}, nil
+}
+
+func parseOffersParameters(r *http.Request) (parameters.OfferFilter, error) {
+	minPrice, err := parseNonNegativeParameter(r, parameters.MinPriceParamKey)
+	if err != nil {
+		return parameters.OfferFilter{}, err
+	}
+	maxPrice, err := parseNonNegativeParameter(r, parameters.MaxPriceParamKey)
+	if err != nil {
+		return parameters.OfferFilter{}, err
+	}
+	if maxPrice > 0 && minPrice > maxPrice {
+		return parameters.OfferFilter{}, errors.NewValidationError(parameters.MinPriceParamKey, "must not exceed max_price")
+	}
+	maxPricePerMl, err := parseNonNegativeParameter(r, parameters.MaxPricePerMlParamKey)
+	if err != nil {
+		return parameters.OfferFilter{}, err
+	}
+
+	volumes := make([]int, 0)
+	for _, rawVolume := range parseListParameter(r, parameters.VolumesParamKey) {
+		volume, err := strconv.Atoi(rawVolume)
+		if err != nil || volume <= 0 {
+			return parameters.OfferFilter{}, errors.NewValidationError(parameters.VolumesParamKey, "must be a list of positive integers")
+		}
+		volumes = append(volumes, volume)
+	}
+
+	return parameters.OfferFilter{
+		MinPrice:      int(minPrice),
+		MaxPrice:      int(maxPrice),
+		MaxPricePerMl: maxPricePerMl,
+		Volumes:       volumes,
+		Shops:         parseListParameter(r, parameters.ShopsParamKey),
+	}, nil
+}
+
+func parseDiversityParameters(r *http.Request, cm cm.ConfigManager) (parameters.Diversity, error) {
+	query := r.URL.Query()
+	diversity := service.DefaultDiversity(cm)
+
+	if rawLambda := query.Get(parameters.DiversityLambdaParamKey); rawLambda != "" {
+		lambda, err := strconv.ParseFloat(rawLambda, 64)
+		if err != nil || lambda < 0 || lambda > 1 {
+			return parameters.Diversity{}, errors.NewValidationError(parameters.DiversityLambdaParamKey, "must be a number between 0 and 1")
+		}
+		diversity.Lambda = lambda
+		diversity.Enabled = true
+	}
+	if rawBrandCap := query.Get(parameters.BrandCapParamKey); rawBrandCap != "" {
+		brandCap, err := strconv.Atoi(rawBrandCap)
+		if err != nil || brandCap < 0 {
+			return parameters.Diversity{}, errors.NewValidationError(parameters.BrandCapParamKey, "must be a non-negative integer")
+		}
+		diversity.BrandCap = brandCap
+		diversity.Enabled = true
+	}
+	if rawDiversify := query.Get(parameters.DiversifyParamKey); rawDiversify != "" {
+		diversify, err := strconv.ParseBool(rawDiversify)
+		if err != nil {
+			return parameters.Diversity{}, errors.NewValidationError(parameters.DiversifyParamKey, "must be a boolean")
+		}
+		diversity.Enabled = diversify
+	}
+	return diversity, nil
+}
+
+func parseNonNegativeParameter(r *http.Request, key string) (float64, error) {
+	rawValue := r.URL.Query().Get(key)
+	if rawValue == "" {
+		return 0, nil
+	}
+	value, err := strconv.ParseFloat(rawValue, 64)
+	if err != nil || value < 0 {
+		return 0, errors.NewValidationError(key, "must be a non-negative number")
+	}
+	return value, nil
+}
+
+func parsePerfumesParameter(r *http.Request, key string, maxCount int) ([]parameters.RequestPerfume, error) {
+	rawPerfumes := r.URL.Query()[key]
+	if len(rawPerfumes) > maxCount {
+		return nil, errors.NewValidationError(key, fmt.Sprintf("at most %d perfumes are allowed", maxCount))
+	}
+
+	sex := parseSexParameter(r)
+	perfumes := make([]parameters.RequestPerfume, 0, len(rawPerfumes))
+	for _, rawPerfume := range rawPerfumes {
+		parts := strings.Split(rawPerfume, "|")
+		if len(parts) < 2 || len(parts) > 3 {
+			return nil, errors.NewValidationError(key, "must be in brand|name[|sex] format")
+		}
+		perfume := parameters.NewGet().
+			WithBrand(strings.TrimSpace(parts[0])).
+			WithName(strings.TrimSpace(parts[1])).
+			WithSex(sex)
+		if len(parts) == 3 {
+			perfume.WithSex(models.Sex(strings.TrimSpace(parts[2])))
+		}
+		if err := perfume.Validate(); err != nil {
+			return nil, err
+		}
+		perfumes = append(perfumes, *perfume)
+	}
+	return perfumes, nil
+}
+
+func parseListParameter(r *http.Request, key string) []string {
+	items := make([]string, 0)
+	for _, item := range strings.Split(r.URL.Query().Get(key), ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+func parseStrategyParameter(r *http.Request) (matching.ProfileStrategy, error) {
+	strategy, ok := matching.ParseProfileStrategy(r.URL.Query().Get(parameters.StrategyParamKey))
+	if !ok {
+		return "", errors.NewValidationError(parameters.StrategyParamKey, "must be one of centroid, max")
+	}
+	return strategy, nil
+}
+
+func parseExplainParameter(r *http.Request) bool {
+	explain, err := strconv.ParseBool(r.URL.Query().Get(parameters.ExplainParamKey))
+	return err == nil && explain
+}
+
+func parseDescribeParameter(r *http.Request) bool {
+	describe, err := strconv.ParseBool(r.URL.Query().Get(parameters.DescribeParamKey))
+	return err == nil && describe
+}
+
+func parseAutoResolveParameter(r *http.Request) bool {
+	autoResolve, err := strconv.ParseBool(r.URL.Query().Get(parameters.AutoResolveParamKey))
+	return err == nil && autoResolve
+}
+
 func parseSexParameter(r *http.Request) models.Sex {
 	query := r.URL.Query()
 	sex := query.Get(parameters.SexParamKey)
@@ -39,48 +209,80 @@ func parseSexParameter(r *http.Request) models.Sex {
 	return models.Sex(sex)
 }
 
-func createPerfumeHubFetcher(cm cm.ConfigManager) (fetching.Fetcher, error) {
-	getPerfumesUrl, err := cm.GetString("get_perfumes_url")
+func createFavouriteResolver(r *http.Request, cm cm.ConfigManager) *advising.FavouriteResolver {
+	return service.NewFavouriteResolver(cm, parseAutoResolveParameter(r))
+}
+
+func writeSuggestions(w http.ResponseWriter, r *http.Request, advisor advising.Advisor, params parameters.RequestPerfume) {
+	if isStreaming(r) {
+		streamSuggestions(w, r, advisor, params)
+		return
+	}
+
+	response, err := suggestionsResponse(r.Context(), r, advisor, params)
 	if err != nil {
-		return nil, errors.NewServiceError("failed to get get_perfumes_url", err)
+		handleError(w, err)
+		return
+	}
+	assignment, _ := experiments.AssignmentFromContext(r.Context())
+	setVariantHeader(w, assignment)
+	WriteResponse(w, response, http.StatusOK)
+}
+
+func suggestionsResponse(ctx context.Context, r *http.Request, advisor advising.Advisor, params parameters.RequestPerfume) (any, error) {
+	assignment, _ := experiments.AssignmentFromContext(r.Context())
+
+	explain, describe := parseExplainParameter(r), parseDescribeParameter(r)
+	if explainingAdvisor, ok := advisor.(advising.ExplainingAdvisor); ok && (explain || describe) {
+		explained, err := explainingAdvisor.AdviseWithExplanations(ctx, params)
+		if err != nil {
+			return nil, err
+		}
+		if !explain {
+			for i := range explained {
+				explained[i].Explanation = nil
+			}
+		}
+		return ExplainedSuggestResponse{Suggested: explained, VariantID: assignment.ID()}, nil
 	}
-	perfumeHubInternalTokenEnv, err := cm.GetString("perfume_hub_internal_token_env_name")
+
+	suggested, err := advisor.Advise(ctx, params)
 	if err != nil {
-		return nil, errors.NewServiceError("failed to get perfume_hub_internal_token_env_name", err)
+		return nil, err
 	}
-	return fetching.NewPerfumeHub(getPerfumesUrl, os.Getenv(perfumeHubInternalTokenEnv), cm), nil
+	return SuggestResponse{Suggested: suggested, VariantID: assignment.ID()}, nil
 }
 
-func createAIFetcher(cm cm.ConfigManager) fetching.Fetcher {
-	return fetching.NewAI(
-		os.Getenv("BASE_URL"),
-		os.Getenv("FOLDER_ID"),
-		os.Getenv("MODEL_NAME"),
-		os.Getenv("API_KEY"),
-		cm,
-	)
+func setVariantHeader(w http.ResponseWriter, assignment experiments.Assignment) {
+	if variantID := assignment.ID(); variantID != "" {
+		w.Header().Set(parameters.VariantIDHeader, variantID)
+	}
 }
 
 func handleError(w http.ResponseWriter, err error) {
-	var status int
-	var errorMsg string
-
-	switch e := err.(type) {
-	case *errors.ValidationError:
-		status = http.StatusBadRequest
-		errorMsg = e.Error()
-	case *errors.NotFoundError:
-		status = http.StatusNotFound
-		errorMsg = e.Error()
-	case *errors.ServiceError:
-		status = http.StatusInternalServerError
-		errorMsg = e.Error()
-	default:
-		status = http.StatusInternalServerError
-		errorMsg = "internal server error"
+	status, errorMsg := errorStatus(err)
+	WriteResponse(w, ErrorResponse{Error: errorMsg, Candidates: errorCandidates(err)}, status)
+}
+
+func errorCandidates(err error) []errors.Candidate {
+	if notFoundErr, ok := err.(*errors.NotFoundError); ok {
+		return notFoundErr.Candidates
 	}
+	return nil
+}
 
-	WriteResponse(w, ErrorResponse{Error: errorMsg}, status)
+func errorStatus(err error) (int, string) {
+	kind, message := service.ClassifyError(err)
+	switch kind {
+	case service.ValidationError:
+		return http.StatusBadRequest, message
+	case service.NotFoundError:
+		return http.StatusNotFound, message
+	case service.AuthError:
+		return http.StatusUnauthorized, message
+	default:
+		return http.StatusInternalServerError, message
+	}
 }
 
 func WriteResponse(w http.ResponseWriter, response any, status int) {
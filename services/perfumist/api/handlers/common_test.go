@@ -1,15 +1,19 @@
 package handlers
 
 import (
+	"context"
 	"encoding/json"
 	"net/http"
 	"net/http/httptest"
-	"os"
+	"strings"
 	"testing"
 
 	"github.com/zemld/Scently/models"
 	"github.com/zemld/Scently/perfumist/internal/config"
 	"github.com/zemld/Scently/perfumist/internal/errors"
+	"github.com/zemld/Scently/perfumist/internal/models/advising"
+	"github.com/zemld/Scently/perfumist/internal/models/matching"
+	"github.com/zemld/Scently/perfumist/internal/models/parameters"
 )
 
 func TestGeneralParseSimilarParameters_ValidParams(t *testing.T) {
@@ -121,147 +125,6 @@ func TestGeneralParseSimilarParameters_EmptyParams(t *testing.T) {
 	}
 }
 
-func TestCreatePerfumeHubFetcher_Success(t *testing.T) {
-	t.Parallel()
-
-	mockCM := &config.MockConfigManager{
-		GetStringFunc: func(key string) (string, error) {
-			switch key {
-			case "get_perfumes_url":
-				return "http://test:8000/v1/perfumes", nil
-			case "perfume_hub_internal_token_env_name":
-				return "TEST_TOKEN", nil
-			default:
-				return "", nil
-			}
-		},
-	}
-
-	originalToken := os.Getenv("TEST_TOKEN")
-	defer func() {
-		if originalToken != "" {
-			os.Setenv("TEST_TOKEN", originalToken)
-		} else {
-			os.Unsetenv("TEST_TOKEN")
-		}
-	}()
-	os.Setenv("TEST_TOKEN", "test-token-value")
-
-	fetcher, err := createPerfumeHubFetcher(mockCM)
-
-	if err != nil {
-		t.Fatalf("expected no error, got %v", err)
-	}
-	if fetcher == nil {
-		t.Fatal("expected non-nil fetcher")
-	}
-}
-
-func TestCreatePerfumeHubFetcher_GetPerfumesUrlError(t *testing.T) {
-	t.Parallel()
-
-	mockCM := &config.MockConfigManager{
-		GetStringFunc: func(key string) (string, error) {
-			if key == "get_perfumes_url" {
-				return "", errors.NewServiceError("config error", nil)
-			}
-			return "", nil
-		},
-	}
-
-	fetcher, err := createPerfumeHubFetcher(mockCM)
-
-	if err == nil {
-		t.Fatal("expected error when get_perfumes_url fails")
-	}
-	if fetcher != nil {
-		t.Fatal("expected nil fetcher on error")
-	}
-	serviceErr, ok := err.(*errors.ServiceError)
-	if !ok {
-		t.Fatalf("expected ServiceError, got %T", err)
-	}
-	if serviceErr.Message != "failed to get get_perfumes_url" {
-		t.Fatalf("expected message %q, got %q", "failed to get get_perfumes_url", serviceErr.Message)
-	}
-}
-
-func TestCreatePerfumeHubFetcher_PerfumeHubInternalTokenEnvError(t *testing.T) {
-	t.Parallel()
-
-	mockCM := &config.MockConfigManager{
-		GetStringFunc: func(key string) (string, error) {
-			switch key {
-			case "get_perfumes_url":
-				return "http://test:8000/v1/perfumes", nil
-			case "perfume_hub_internal_token_env_name":
-				return "", errors.NewServiceError("config error", nil)
-			default:
-				return "", nil
-			}
-		},
-	}
-
-	fetcher, err := createPerfumeHubFetcher(mockCM)
-
-	if err == nil {
-		t.Fatal("expected error when perfume_hub_internal_token_env_name fails")
-	}
-	if fetcher != nil {
-		t.Fatal("expected nil fetcher on error")
-	}
-	serviceErr, ok := err.(*errors.ServiceError)
-	if !ok {
-		t.Fatalf("expected ServiceError, got %T", err)
-	}
-	if serviceErr.Message != "failed to get perfume_hub_internal_token_env_name" {
-		t.Fatalf("expected message %q, got %q", "failed to get perfume_hub_internal_token_env_name", serviceErr.Message)
-	}
-}
-
-func TestCreateAIFetcher_Success(t *testing.T) {
-	t.Parallel()
-
-	originalBaseURL := os.Getenv("BASE_URL")
-	originalFolderID := os.Getenv("FOLDER_ID")
-	originalModelName := os.Getenv("MODEL_NAME")
-	originalAPIKey := os.Getenv("API_KEY")
-	defer func() {
-		if originalBaseURL != "" {
-			os.Setenv("BASE_URL", originalBaseURL)
-		} else {
-			os.Unsetenv("BASE_URL")
-		}
-		if originalFolderID != "" {
-			os.Setenv("FOLDER_ID", originalFolderID)
-		} else {
-			os.Unsetenv("FOLDER_ID")
-		}
-		if originalModelName != "" {
-			os.Setenv("MODEL_NAME", originalModelName)
-		} else {
-			os.Unsetenv("MODEL_NAME")
-		}
-		if originalAPIKey != "" {
-			os.Setenv("API_KEY", originalAPIKey)
-		} else {
-			os.Unsetenv("API_KEY")
-		}
-	}()
-
-	os.Setenv("BASE_URL", "http://test:8000")
-	os.Setenv("FOLDER_ID", "test-folder")
-	os.Setenv("MODEL_NAME", "test-model")
-	os.Setenv("API_KEY", "test-key")
-
-	mockCM := &config.MockConfigManager{}
-	fetcher := createAIFetcher(mockCM)
-
-	if fetcher == nil {
-		t.Fatal("expected non-nil fetcher")
-	}
-}
-
 func TestHandleError_ValidationError(t *testing.T) {
 	t.Parallel()
 
@@ -305,6 +168,41 @@ func TestHandleError_NotFoundError(t *testing.T) {
 	}
 }
 
+func TestHandleError_NotFoundErrorWithCandidates(t *testing.T) {
+	t.Parallel()
+
+	w := httptest.NewRecorder()
+	candidates := []errors.Candidate{{Brand: "Dior", Name: "Sauvage", Sex: models.Male, Score: 0.95}}
+	handleError(w, errors.NewPerfumeNotFoundError("perfume Dior Sauvge not found", candidates))
+
+	if w.Code != http.StatusNotFound {
+		t.Fatalf("expected status %d, got %d", http.StatusNotFound, w.Code)
+	}
+
+	var response ErrorResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &response); err != nil {
+		t.Fatalf("failed to unmarshal response: %v", err)
+	}
+	if len(response.Candidates) != 1 || response.Candidates[0] != candidates[0] {
+		t.Fatalf("expected candidates %+v, got %+v", candidates, response.Candidates)
+	}
+}
+
+func TestParseAutoResolveParameter(t *testing.T) {
+	t.Parallel()
+
+	for url, expected := range map[string]bool{
+		"/?auto_resolve=true":  true,
+		"/?auto_resolve=false": false,
+		"/?auto_resolve=maybe": false,
+		"/":                    false,
+	} {
+		if got := parseAutoResolveParameter(httptest.NewRequest(http.MethodGet, url, nil)); got != expected {
+			t.Errorf("%s: expected %v, got %v", url, expected, got)
+		}
+	}
+}
+
 func TestHandleError_ServiceError(t *testing.T) {
 	t.Parallel()
 
@@ -423,3 +321,293 @@ type customError struct {
 func (e *customError) Error() string {
 	return e.msg
 }
+
+func TestParseExplainParameter(t *testing.T) {
+	t.Parallel()
+
+	cases := map[string]bool{
+		"/?explain=true":  true,
+		"/?explain=1":     true,
+		"/?explain=false": false,
+		"/?explain=maybe": false,
+		"/":               false,
+	}
+	for url, expected := range cases {
+		req := httptest.NewRequest(http.MethodGet, url, nil)
+		if got := parseExplainParameter(req); got != expected {
+			t.Fatalf("%s: expected %v, got %v", url, expected, got)
+		}
+	}
+}
+
+type mockExplainingAdvisor struct {
+	explainCalled bool
+}
+
+func (m *mockExplainingAdvisor) Advise(ctx context.Context, params parameters.RequestPerfume) ([]models.Ranked, error) {
+	return []models.Ranked{{Perfume: models.Perfume{Brand: "Dior"}, Score: 0.5, Rank: 1}}, nil
+}
+
+func (m *mockExplainingAdvisor) AdviseWithExplanations(ctx context.Context, params parameters.RequestPerfume) ([]advising.Explained, error) {
+	m.explainCalled = true
+	return []advising.Explained{{
+		Ranked:      models.Ranked{Perfume: models.Perfume{Brand: "Dior"}, Score: 0.5, Rank: 1},
+		Explanation: &matching.Explanation{Score: 0.5},
+	}}, nil
+}
+
+func TestWriteSuggestions_Explain(t *testing.T) {
+	t.Parallel()
+
+	advisor := &mockExplainingAdvisor{}
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodGet, "/?explain=true", nil)
+	writeSuggestions(w, req, advisor, parameters.RequestPerfume{})
+
+	if !advisor.explainCalled {
+		t.Fatal("expected explaining advisor to be used")
+	}
+	var response ExplainedSuggestResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &response); err != nil {
+		t.Fatalf("failed to unmarshal response: %v", err)
+	}
+	if len(response.Suggested) != 1 || response.Suggested[0].Explanation == nil {
+		t.Fatalf("expected explained suggestion, got %+v", response.Suggested)
+	}
+	if response.Suggested[0].Perfume.Brand != "Dior" {
+		t.Fatalf("expected brand %q, got %q", "Dior", response.Suggested[0].Perfume.Brand)
+	}
+}
+
+func TestWriteSuggestions_WithoutExplain(t *testing.T) {
+	t.Parallel()
+
+	advisor := &mockExplainingAdvisor{}
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	writeSuggestions(w, req, advisor, parameters.RequestPerfume{})
+
+	if advisor.explainCalled {
+		t.Fatal("expected plain advising without explain parameter")
+	}
+	if strings.Contains(w.Body.String(), "explanation") {
+		t.Fatalf("unexpected explanation in response: %s", w.Body.String())
+	}
+}
+
+func TestParseFavouritesParameters(t *testing.T) {
+	t.Parallel()
+
+	req := httptest.NewRequest(http.MethodGet, "/?sex=male&perfume=Dior|Sauvage&perfume=Chanel|No.5|female", nil)
+	favourites, err := parseFavouritesParameters(req, 10)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if len(favourites) != 2 {
+		t.Fatalf("expected 2 favourites, got %d", len(favourites))
+	}
+	if favourites[0].Brand != "Dior" || favourites[0].Name != "Sauvage" || favourites[0].Sex != models.Male {
+		t.Fatalf("unexpected first favourite %+v", favourites[0])
+	}
+	if favourites[1].Name != "No.5" || favourites[1].Sex != models.Female {
+		t.Fatalf("unexpected second favourite %+v", favourites[1])
+	}
+}
+
+func TestParseFavouritesParameters_Invalid(t *testing.T) {
+	t.Parallel()
+
+	for _, url := range []string{
+		"/",
+		"/?perfume=Dior",
+		"/?perfume=Dior|",
+		"/?perfume=a|b|male|extra",
+		"/?perfume=a|b&perfume=c|d&perfume=e|f",
+	} {
+		req := httptest.NewRequest(http.MethodGet, url, nil)
+		_, err := parseFavouritesParameters(req, 2)
+		if _, ok := err.(*errors.ValidationError); !ok {
+			t.Fatalf("%s: expected ValidationError, got %v", url, err)
+		}
+	}
+}
+
+func TestParseStrategyParameter(t *testing.T) {
+	t.Parallel()
+
+	req := httptest.NewRequest(http.MethodGet, "/?strategy=max", nil)
+	if strategy, err := parseStrategyParameter(req); err != nil || strategy != matching.MaxStrategy {
+		t.Fatalf("expected max strategy, got %q (%v)", strategy, err)
+	}
+	req = httptest.NewRequest(http.MethodGet, "/", nil)
+	if strategy, err := parseStrategyParameter(req); err != nil || strategy != matching.CentroidStrategy {
+		t.Fatalf("expected centroid strategy by default, got %q (%v)", strategy, err)
+	}
+	req = httptest.NewRequest(http.MethodGet, "/?strategy=median", nil)
+	if _, err := parseStrategyParameter(req); err == nil {
+		t.Fatal("expected error for unknown strategy")
+	}
+}
+
+func TestParseExclusionsParameters(t *testing.T) {
+	t.Parallel()
+
+	req := httptest.NewRequest(http.MethodGet, "/?sex=female&exclude_notes=Oud,%20Patchouli,&exclude_tags=sweet&dislike=Dior|Poison&dislike=Tom%20Ford|Lost%20Cherry|unisex", nil)
+	exclusions, err := parseExclusionsParameters(req, 10)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if len(exclusions.Notes) != 2 || exclusions.Notes[0] != "Oud" || exclusions.Notes[1] != "Patchouli" {
+		t.Fatalf("unexpected excluded notes %v", exclusions.Notes)
+	}
+	if len(exclusions.Tags) != 1 || exclusions.Tags[0] != "sweet" {
+		t.Fatalf("unexpected excluded tags %v", exclusions.Tags)
+	}
+	if len(exclusions.Disliked) != 2 {
+		t.Fatalf("expected 2 disliked perfumes, got %d", len(exclusions.Disliked))
+	}
+	if exclusions.Disliked[0].Sex != models.Female || exclusions.Disliked[1].Name != "Lost Cherry" {
+		t.Fatalf("unexpected disliked perfumes %+v", exclusions.Disliked)
+	}
+}
+
+func TestParseExclusionsParameters_Empty(t *testing.T) {
+	t.Parallel()
+
+	req := httptest.NewRequest(http.MethodGet, "/?brand=Dior&name=Sauvage", nil)
+	exclusions, err := parseExclusionsParameters(req, 10)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if !exclusions.IsEmpty() {
+		t.Fatalf("expected no exclusions, got %+v", exclusions)
+	}
+}
+
+func TestParseExclusionsParameters_Invalid(t *testing.T) {
+	t.Parallel()
+
+	for _, url := range []string{
+		"/?dislike=Dior",
+		"/?dislike=a|b&dislike=c|d",
+	} {
+		req := httptest.NewRequest(http.MethodGet, url, nil)
+		_, err := parseExclusionsParameters(req, 1)
+		if _, ok := err.(*errors.ValidationError); !ok {
+			t.Fatalf("%s: expected ValidationError, got %v", url, err)
+		}
+	}
+}
+
+func TestParseOffersParameters(t *testing.T) {
+	t.Parallel()
+
+	req := httptest.NewRequest(http.MethodGet, "/?min_price=1000&max_price=15000&max_price_per_ml=150.5&volumes=50,%20100&shops=Gold%20Apple,randewoo.ru", nil)
+	offers, err := parseOffersParameters(req)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if offers.MinPrice != 1000 || offers.MaxPrice != 15000 || offers.MaxPricePerMl != 150.5 {
+		t.Fatalf("unexpected prices %+v", offers)
+	}
+	if len(offers.Volumes) != 2 || offers.Volumes[0] != 50 || offers.Volumes[1] != 100 {
+		t.Fatalf("unexpected volumes %v", offers.Volumes)
+	}
+	if len(offers.Shops) != 2 || offers.Shops[0] != "Gold Apple" || offers.Shops[1] != "randewoo.ru" {
+		t.Fatalf("unexpected shops %v", offers.Shops)
+	}
+}
+
+func TestParseOffersParameters_Empty(t *testing.T) {
+	t.Parallel()
+
+	offers, err := parseOffersParameters(httptest.NewRequest(http.MethodGet, "/", nil))
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if !offers.IsEmpty() {
+		t.Fatalf("expected empty filter, got %+v", offers)
+	}
+}
+
+func TestParseOffersParameters_Invalid(t *testing.T) {
+	t.Parallel()
+
+	for _, url := range []string{
+		"/?min_price=cheap",
+		"/?max_price=-1",
+		"/?min_price=2000&max_price=1000",
+		"/?max_price_per_ml=abc",
+		"/?volumes=50,big",
+		"/?volumes=0",
+	} {
+		req := httptest.NewRequest(http.MethodGet, url, nil)
+		_, err := parseOffersParameters(req)
+		if _, ok := err.(*errors.ValidationError); !ok {
+			t.Fatalf("%s: expected ValidationError, got %v", url, err)
+		}
+	}
+}
+
+func TestParseDiversityParameters_ConfigDefaults(t *testing.T) {
+	t.Parallel()
+
+	mockCM := &config.MockConfigManager{
+		GetBoolWithDefaultFunc: func(key string, defaultValue bool) bool {
+			return key == "diversity_enabled" || defaultValue
+		},
+		GetIntWithDefaultFunc: func(key string, defaultValue int) int {
+			if key == "diversity_brand_cap" {
+				return 2
+			}
+			return defaultValue
+		},
+	}
+	diversity, err := parseDiversityParameters(httptest.NewRequest(http.MethodGet, "/", nil), mockCM)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if !diversity.Enabled || diversity.Lambda != 0.7 || diversity.BrandCap != 2 {
+		t.Fatalf("unexpected diversity %+v", diversity)
+	}
+
+	diversity, err = parseDiversityParameters(httptest.NewRequest(http.MethodGet, "/?diversify=false", nil), mockCM)
+	if err != nil || diversity.Enabled {
+		t.Fatalf("expected request to disable diversity, got %+v (%v)", diversity, err)
+	}
+}
+
+func TestParseDiversityParameters_RequestOverrides(t *testing.T) {
+	t.Parallel()
+
+	req := httptest.NewRequest(http.MethodGet, "/?diversity_lambda=0.4&brand_cap=1", nil)
+	diversity, err := parseDiversityParameters(req, &config.MockConfigManager{})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if !diversity.Enabled || diversity.Lambda != 0.4 || diversity.BrandCap != 1 {
+		t.Fatalf("unexpected diversity %+v", diversity)
+	}
+
+	diversity, err = parseDiversityParameters(httptest.NewRequest(http.MethodGet, "/", nil), &config.MockConfigManager{})
+	if err != nil || diversity.Enabled {
+		t.Fatalf("expected diversity to be disabled by default, got %+v (%v)", diversity, err)
+	}
+}
+
+func TestParseDiversityParameters_Invalid(t *testing.T) {
+	t.Parallel()
+
+	for _, url := range []string{
+		"/?diversity_lambda=1.5",
+		"/?diversity_lambda=much",
+		"/?brand_cap=-1",
+		"/?diversify=maybe",
+	} {
+		req := httptest.NewRequest(http.MethodGet, url, nil)
+		_, err := parseDiversityParameters(req, &config.MockConfigManager{})
+		if _, ok := err.(*errors.ValidationError); !ok {
+			t.Fatalf("%s: expected ValidationError, got %v", url, err)
+		}
+	}
+}
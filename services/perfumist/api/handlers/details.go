@@ -0,0 +1,34 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/zemld/Scently/perfumist/internal/config"
+	"github.com/zemld/Scently/perfumist/internal/models/advising"
+	"github.com/zemld/Scently/perfumist/internal/models/parameters"
+	"github.com/zemld/Scently/perfumist/internal/service"
+)
+
+func Details(w http.ResponseWriter, r *http.Request) {
+	params := parameters.NewGet().
+		WithBrand(r.PathValue(parameters.BrandParamKey)).
+		WithName(r.PathValue(parameters.NameParamKey)).
+		WithSex(parseSexParameter(r))
+	if err := params.Validate(); err != nil {
+		handleError(w, err)
+		return
+	}
+
+	details, err := advising.FetchDetails(
+		r.Context(),
+		service.PerfumesCatalog(),
+		createFavouriteResolver(r, config.Manager()),
+		config.Manager(),
+		*params,
+	)
+	if err != nil {
+		handleError(w, err)
+		return
+	}
+	WriteResponse(w, details, http.StatusOK)
+}
@@ -0,0 +1,20 @@
+package handlers
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestDetails_MissingName(t *testing.T) {
+	t.Parallel()
+
+	req := httptest.NewRequest(http.MethodGet, "/v2/perfume/Dior/", nil)
+	req.SetPathValue("brand", "Dior")
+	w := httptest.NewRecorder()
+	Details(w, req)
+
+	if w.Code != http.StatusBadRequest {
+		t.Fatalf("expected status %d, got %d", http.StatusBadRequest, w.Code)
+	}
+}
@@ -0,0 +1,41 @@
+package handlers
+
+import (
+	"log"
+	"net/http"
+	"os"
+	"time"
+
+	"github.com/zemld/Scently/perfumist/internal/models/experiments"
+	"github.com/zemld/Scently/perfumist/internal/models/parameters"
+	"github.com/zemld/config-manager/pkg/cm"
+)
+
+var exposureLogger experiments.ExposureLogger = experiments.NewJSONExposureLogger(os.Stdout)
+
+// assignExperiment buckets the client into a variant of the running experiment
+// and puts the assignment into the request context. Requests without a client id
+// and misconfigured experiments are served with the global config.
+func assignExperiment(r *http.Request, cm cm.ConfigManager, logger experiments.ExposureLogger) *http.Request {
+	name := cm.GetStringWithDefault("experiment", "")
+	clientID := r.Header.Get(parameters.ClientIDHeader)
+	if name == "" || clientID == "" {
+		return r
+	}
+
+	experiment, err := experiments.NewExperiment(name, cm.GetStringWithDefault("experiment_variants", ""))
+	if err != nil {
+		log.Printf("Cannot run experiment: %v\n", err)
+		return r
+	}
+
+	assignment := experiment.Assign(clientID)
+	logger.LogExposure(experiments.Exposure{
+		Experiment: assignment.Experiment,
+		Variant:    assignment.Variant,
+		Subject:    clientID,
+		Endpoint:   r.URL.Path,
+		Time:       time.Now().UTC(),
+	})
+	return r.WithContext(experiments.WithAssignment(r.Context(), assignment))
+}
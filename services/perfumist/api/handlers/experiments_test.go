@@ -0,0 +1,131 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/zemld/Scently/perfumist/internal/config"
+	"github.com/zemld/Scently/perfumist/internal/models/experiments"
+	"github.com/zemld/Scently/perfumist/internal/models/matching"
+	"github.com/zemld/Scently/perfumist/internal/models/parameters"
+)
+
+type recordingExposureLogger struct {
+	exposures []experiments.Exposure
+}
+
+func (l *recordingExposureLogger) LogExposure(exposure experiments.Exposure) {
+	l.exposures = append(l.exposures, exposure)
+}
+
+func experimentConfig(variants string) *config.MockConfigManager {
+	return &config.MockConfigManager{
+		GetStringWithDefaultFunc: func(key string, defaultValue string) string {
+			switch key {
+			case "experiment":
+				return "weights"
+			case "experiment_variants":
+				return variants
+			}
+			return defaultValue
+		},
+		GetFloatWithDefaultFunc: func(key string, defaultValue float64) float64 {
+			if key == "weights_treatment_tags_weight" {
+				return 0.9
+			}
+			return defaultValue
+		},
+	}
+}
+
+func TestAssignExperiment(t *testing.T) {
+	t.Parallel()
+
+	logger := &recordingExposureLogger{}
+	req := httptest.NewRequest(http.MethodGet, "/v2/perfume/suggest", nil)
+	req.Header.Set(parameters.ClientIDHeader, "client-1")
+
+	req = assignExperiment(req, experimentConfig("treatment:1"), logger)
+	assignment, ok := experiments.AssignmentFromContext(req.Context())
+	if !ok || assignment.ID() != "weights:treatment" {
+		t.Fatalf("expected weights:treatment assignment, got %+v", assignment)
+	}
+	if len(logger.exposures) != 1 || logger.exposures[0].Subject != "client-1" || logger.exposures[0].Endpoint != "/v2/perfume/suggest" {
+		t.Fatalf("unexpected exposures %+v", logger.exposures)
+	}
+}
+
+func TestAssignExperiment_NotEnrolled(t *testing.T) {
+	t.Parallel()
+
+	logger := &recordingExposureLogger{}
+	withoutClient := assignExperiment(httptest.NewRequest(http.MethodGet, "/", nil), experimentConfig("treatment:1"), logger)
+	if _, ok := experiments.AssignmentFromContext(withoutClient.Context()); ok {
+		t.Fatal("expected no assignment without client id")
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.Header.Set(parameters.ClientIDHeader, "client-1")
+	misconfigured := assignExperiment(req, experimentConfig("treatment:none"), logger)
+	if _, ok := experiments.AssignmentFromContext(misconfigured.Context()); ok {
+		t.Fatal("expected no assignment for misconfigured experiment")
+	}
+
+	withoutExperiment := assignExperiment(req, &config.MockConfigManager{}, logger)
+	if _, ok := experiments.AssignmentFromContext(withoutExperiment.Context()); ok {
+		t.Fatal("expected no assignment without experiment")
+	}
+	if len(logger.exposures) != 0 {
+		t.Fatalf("expected no exposures, got %+v", logger.exposures)
+	}
+}
+
+func TestCreateMatcher_UsesVariantWeights(t *testing.T) {
+	t.Parallel()
+
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.Header.Set(parameters.ClientIDHeader, "client-1")
+	mockCM := experimentConfig("treatment:1")
+
+	matcher, err := createMatcher(assignExperiment(req, mockCM, &recordingExposureLogger{}), mockCM)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if weights := matcher.(*matching.CombinedMatcher).Weights; weights.TagsWeight != 0.9 {
+		t.Fatalf("expected variant tags weight 0.9, got %f", weights.TagsWeight)
+	}
+
+	matcher, _ = createMatcher(req, mockCM)
+	if weights := matcher.(*matching.CombinedMatcher).Weights; weights.TagsWeight != 0.5 {
+		t.Fatalf("expected global tags weight 0.5, got %f", weights.TagsWeight)
+	}
+}
+
+func TestWriteSuggestions_ReturnsVariant(t *testing.T) {
+	t.Parallel()
+
+	assignment := experiments.Assignment{Experiment: "weights", Variant: "control"}
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req = req.WithContext(experiments.WithAssignment(req.Context(), assignment))
+	w := httptest.NewRecorder()
+	writeSuggestions(w, req, &mockExplainingAdvisor{}, parameters.RequestPerfume{})
+
+	if header := w.Header().Get(parameters.VariantIDHeader); header != "weights:control" {
+		t.Fatalf("expected variant header weights:control, got %q", header)
+	}
+	var response SuggestResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &response); err != nil {
+		t.Fatalf("failed to unmarshal response: %v", err)
+	}
+	if response.VariantID != "weights:control" {
+		t.Fatalf("expected variant_id weights:control, got %q", response.VariantID)
+	}
+
+	w = httptest.NewRecorder()
+	writeSuggestions(w, httptest.NewRequest(http.MethodGet, "/", nil), &mockExplainingAdvisor{}, parameters.RequestPerfume{})
+	if w.Header().Get(parameters.VariantIDHeader) != "" {
+		t.Fatal("expected no variant header outside of experiments")
+	}
+}
@@ -0,0 +1,57 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/zemld/Scently/perfumist/internal/config"
+	"github.com/zemld/Scently/perfumist/internal/models/advising"
+	"github.com/zemld/Scently/perfumist/internal/models/parameters"
+	"github.com/zemld/Scently/perfumist/internal/service"
+)
+
+func SuggestByFavourites(w http.ResponseWriter, r *http.Request) {
+	favourites, err := parseFavouritesParameters(r, config.Manager().GetIntWithDefault("max_favourites_count", 10))
+	if err != nil {
+		handleError(w, err)
+		return
+	}
+	strategy, err := parseStrategyParameter(r)
+	if err != nil {
+		handleError(w, err)
+		return
+	}
+
+	exclusions, err := parseExclusionsParameters(r, config.Manager().GetIntWithDefault("max_disliked_count", 10))
+	if err != nil {
+		handleError(w, err)
+		return
+	}
+
+	offers, err := parseOffersParameters(r)
+	if err != nil {
+		handleError(w, err)
+		return
+	}
+
+	diversity, err := parseDiversityParameters(r, config.Manager())
+	if err != nil {
+		handleError(w, err)
+		return
+	}
+
+	matcher, err := createMatcher(r, config.Manager())
+	if err != nil {
+		handleError(w, err)
+		return
+	}
+
+	advisor := advising.NewMulti(
+		service.PerfumesCatalog(),
+		matcher,
+		config.Manager(),
+		favourites,
+		strategy,
+	)
+
+	writeSuggestions(w, r, advisor, *parameters.NewGet().WithSex(parseSexParameter(r)).WithExclusions(exclusions).WithOffers(offers).WithDiversity(diversity))
+}
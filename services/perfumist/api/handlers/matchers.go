@@ -0,0 +1,19 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/zemld/Scently/perfumist/internal/models/experiments"
+	"github.com/zemld/Scently/perfumist/internal/models/matching"
+	"github.com/zemld/Scently/perfumist/internal/models/parameters"
+	"github.com/zemld/Scently/perfumist/internal/service"
+	"github.com/zemld/config-manager/pkg/cm"
+)
+
+func createMatcher(r *http.Request, cm cm.ConfigManager) (matching.Matcher, error) {
+	variantPrefix := ""
+	if assignment, ok := experiments.AssignmentFromContext(r.Context()); ok {
+		variantPrefix = assignment.ConfigPrefix()
+	}
+	return service.MatcherByAlg(matching.AlgType(r.URL.Query().Get(parameters.MatcherParamKey)), variantPrefix, cm)
+}
@@ -0,0 +1,61 @@
+package handlers
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/zemld/Scently/perfumist/internal/config"
+	"github.com/zemld/Scently/perfumist/internal/errors"
+	"github.com/zemld/Scently/perfumist/internal/models/matching"
+)
+
+func TestCreateMatcher_Default(t *testing.T) {
+	t.Parallel()
+
+	matcher, err := createMatcher(httptest.NewRequest(http.MethodGet, "/", nil), &config.MockConfigManager{})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if _, ok := matcher.(*matching.CombinedMatcher); !ok {
+		t.Fatalf("expected CombinedMatcher, got %T", matcher)
+	}
+}
+
+func TestCreateMatcher_FromConfigAndRequest(t *testing.T) {
+	t.Parallel()
+
+	mockCM := &config.MockConfigManager{
+		GetStringWithDefaultFunc: func(key string, defaultValue string) string {
+			if key == "matcher" {
+				return "tags_overlay"
+			}
+			return defaultValue
+		},
+	}
+
+	matcher, err := createMatcher(httptest.NewRequest(http.MethodGet, "/", nil), mockCM)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if _, ok := matcher.(*matching.TagsOverlay); !ok {
+		t.Fatalf("expected TagsOverlay from config, got %T", matcher)
+	}
+
+	matcher, err = createMatcher(httptest.NewRequest(http.MethodGet, "/?matcher=smart", nil), mockCM)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if _, ok := matcher.(*matching.Smart); !ok {
+		t.Fatalf("expected request to override config, got %T", matcher)
+	}
+}
+
+func TestCreateMatcher_Unknown(t *testing.T) {
+	t.Parallel()
+
+	_, err := createMatcher(httptest.NewRequest(http.MethodGet, "/?matcher=random", nil), &config.MockConfigManager{})
+	if _, ok := err.(*errors.ValidationError); !ok {
+		t.Fatalf("expected ValidationError, got %v", err)
+	}
+}
@@ -0,0 +1,105 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+	"os"
+
+	"github.com/zemld/Scently/perfumist/internal/config"
+	"github.com/zemld/Scently/perfumist/internal/errors"
+	"github.com/zemld/Scently/perfumist/internal/models/advising"
+	"github.com/zemld/Scently/perfumist/internal/models/fetching"
+	"github.com/zemld/Scently/perfumist/internal/models/matching"
+	"github.com/zemld/Scently/perfumist/internal/models/parameters"
+	"github.com/zemld/Scently/perfumist/internal/service"
+	"github.com/zemld/config-manager/pkg/cm"
+)
+
+func SuggestByNotes(w http.ResponseWriter, r *http.Request) {
+	notes, err := parseNotesParameter(r, config.Manager().GetIntWithDefault("max_notes_count", 20))
+	if err != nil {
+		handleError(w, err)
+		return
+	}
+	levels, err := parseNoteLevelParameter(r)
+	if err != nil {
+		handleError(w, err)
+		return
+	}
+
+	exclusions, err := parseExclusionsParameters(r, config.Manager().GetIntWithDefault("max_disliked_count", 10))
+	if err != nil {
+		handleError(w, err)
+		return
+	}
+
+	offers, err := parseOffersParameters(r)
+	if err != nil {
+		handleError(w, err)
+		return
+	}
+
+	diversity, err := parseDiversityParameters(r, config.Manager())
+	if err != nil {
+		handleError(w, err)
+		return
+	}
+
+	matcher, err := createMatcher(r, config.Manager())
+	if err != nil {
+		handleError(w, err)
+		return
+	}
+
+	notesFetcher, err := createNotesFetcher(config.Manager())
+	if err != nil {
+		handleError(w, err)
+		return
+	}
+
+	advisor := advising.NewNotesBased(
+		service.PerfumesCatalog(),
+		notesFetcher,
+		matcher,
+		config.Manager(),
+		notes,
+		levels,
+	)
+
+	writeSuggestions(w, r, advisor, *parameters.NewGet().WithSex(parseSexParameter(r)).WithExclusions(exclusions).WithOffers(offers).WithDiversity(diversity))
+}
+
+func parseNotesParameter(r *http.Request, maxCount int) ([]string, error) {
+	notes := parseListParameter(r, parameters.NotesParamKey)
+	if len(notes) == 0 {
+		return nil, errors.NewValidationError(parameters.NotesParamKey, "at least one note is required")
+	}
+	if len(notes) > maxCount {
+		return nil, errors.NewValidationError(parameters.NotesParamKey, fmt.Sprintf("at most %d notes are allowed", maxCount))
+	}
+	return notes, nil
+}
+
+func parseNoteLevelParameter(r *http.Request) ([]matching.NoteLevel, error) {
+	rawLevel := r.URL.Query().Get(parameters.NoteLevelParamKey)
+	if rawLevel == "" {
+		return nil, nil
+	}
+	level, ok := matching.ParseNoteLevel(rawLevel)
+	if !ok {
+		return nil, errors.NewValidationError(parameters.NoteLevelParamKey, "must be one of upper, core, base")
+	}
+	return []matching.NoteLevel{level}, nil
+}
+
+func createNotesFetcher(cm cm.ConfigManager) (fetching.NotesFetcher, error) {
+	getNotesUrl, err := cm.GetString("get_notes_url")
+	if err != nil {
+		return nil, errors.NewServiceError("failed to get get_notes_url", err)
+	}
+	perfumeHubInternalTokenEnv, err := cm.GetString("perfume_hub_internal_token_env_name")
+	if err != nil {
+		return nil, errors.NewServiceError("failed to get perfume_hub_internal_token_env_name", err)
+	}
+	return fetching.NewPerfumeHubNotes(getNotesUrl, os.Getenv(perfumeHubInternalTokenEnv)), nil
+}
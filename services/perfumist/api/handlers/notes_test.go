@@ -0,0 +1,78 @@
+package handlers
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/zemld/Scently/perfumist/internal/config"
+	"github.com/zemld/Scently/perfumist/internal/errors"
+	"github.com/zemld/Scently/perfumist/internal/models/matching"
+)
+
+func TestParseNotesParameter(t *testing.T) {
+	t.Parallel()
+
+	req := httptest.NewRequest(http.MethodGet, "/?notes=vanilla,%20rose%20,,musk", nil)
+	notes, err := parseNotesParameter(req, 5)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if len(notes) != 3 || notes[0] != "vanilla" || notes[1] != "rose" || notes[2] != "musk" {
+		t.Fatalf("unexpected notes %v", notes)
+	}
+}
+
+func TestParseNotesParameter_Invalid(t *testing.T) {
+	t.Parallel()
+
+	for _, url := range []string{
+		"/",
+		"/?notes=",
+		"/?notes=a,b,c",
+	} {
+		req := httptest.NewRequest(http.MethodGet, url, nil)
+		_, err := parseNotesParameter(req, 2)
+		if _, ok := err.(*errors.ValidationError); !ok {
+			t.Fatalf("%s: expected ValidationError, got %v", url, err)
+		}
+	}
+}
+
+func TestParseNoteLevelParameter(t *testing.T) {
+	t.Parallel()
+
+	req := httptest.NewRequest(http.MethodGet, "/?level=base", nil)
+	if levels, err := parseNoteLevelParameter(req); err != nil || len(levels) != 1 || levels[0] != matching.BaseLevel {
+		t.Fatalf("expected base level, got %v (%v)", levels, err)
+	}
+	req = httptest.NewRequest(http.MethodGet, "/", nil)
+	if levels, err := parseNoteLevelParameter(req); err != nil || levels != nil {
+		t.Fatalf("expected no levels by default, got %v (%v)", levels, err)
+	}
+	req = httptest.NewRequest(http.MethodGet, "/?level=heart", nil)
+	if _, err := parseNoteLevelParameter(req); err == nil {
+		t.Fatal("expected error for unknown level")
+	}
+}
+
+func TestCreateNotesFetcher(t *testing.T) {
+	t.Parallel()
+
+	mockCM := &config.MockConfigManager{
+		GetStringFunc: func(key string) (string, error) {
+			if key == "get_notes_url" {
+				return "", errors.NewServiceError("config error", nil)
+			}
+			return "", nil
+		},
+	}
+	if _, err := createNotesFetcher(mockCM); err == nil {
+		t.Fatal("expected error when get_notes_url fails")
+	}
+
+	fetcher, err := createNotesFetcher(&config.MockConfigManager{})
+	if err != nil || fetcher == nil {
+		t.Fatalf("expected fetcher, got %v (%v)", fetcher, err)
+	}
+}
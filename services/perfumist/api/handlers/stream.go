@@ -0,0 +1,102 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"sync"
+
+	"github.com/zemld/Scently/perfumist/internal/errors"
+	"github.com/zemld/Scently/perfumist/internal/models/advising"
+	"github.com/zemld/Scently/perfumist/internal/models/experiments"
+	"github.com/zemld/Scently/perfumist/internal/models/parameters"
+)
+
+const (
+	ResultEvent = "result"
+	ErrorEvent  = "error"
+)
+
+type StreamErrorResponse struct {
+	Status     int                `json:"status"`
+	Error      string             `json:"error"`
+	Candidates []errors.Candidate `json:"candidates,omitempty"`
+}
+
+type streamingKey struct{}
+
+// Streaming makes next send its suggestions as server-sent events: the
+// advisor's updates as they come and the usual response as the final "result"
+// event. Errors found before advising starts keep their plain status codes.
+func Streaming(next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		next(w, r.WithContext(context.WithValue(r.Context(), streamingKey{}, true)))
+	}
+}
+
+func isStreaming(r *http.Request) bool {
+	streaming, _ := r.Context().Value(streamingKey{}).(bool)
+	return streaming
+}
+
+type eventWriter struct {
+	mu      sync.Mutex
+	w       http.ResponseWriter
+	flusher http.Flusher
+	closed  bool
+}
+
+func (e *eventWriter) write(event string, data any) {
+	payload, err := json.Marshal(data)
+	if err != nil {
+		log.Printf("Cannot marshal %s event: %v\n", event, err)
+		return
+	}
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if e.closed {
+		return
+	}
+	fmt.Fprintf(e.w, "event: %s\ndata: %s\n\n", event, payload)
+	e.flusher.Flush()
+}
+
+// close writes the last event; updates coming after it are dropped.
+func (e *eventWriter) close(event string, data any) {
+	e.write(event, data)
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.closed = true
+}
+
+func streamSuggestions(w http.ResponseWriter, r *http.Request, advisor advising.Advisor, params parameters.RequestPerfume) {
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		handleError(w, errors.NewServiceError("streaming is not supported", nil))
+		return
+	}
+
+	assignment, _ := experiments.AssignmentFromContext(r.Context())
+	setVariantHeader(w, assignment)
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("X-Accel-Buffering", "no")
+	w.WriteHeader(http.StatusOK)
+	flusher.Flush()
+
+	events := &eventWriter{w: w, flusher: flusher}
+	ctx := advising.WithProgress(r.Context(), func(update advising.Update) {
+		events.write(update.Event, update)
+	})
+	response, err := suggestionsResponse(ctx, r, advisor, params)
+	if err != nil {
+		status, errorMsg := errorStatus(err)
+		events.close(ErrorEvent, StreamErrorResponse{Status: status, Error: errorMsg, Candidates: errorCandidates(err)})
+		return
+	}
+	events.close(ResultEvent, response)
+}
@@ -0,0 +1,88 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/zemld/Scently/models"
+	"github.com/zemld/Scently/perfumist/internal/errors"
+	"github.com/zemld/Scently/perfumist/internal/models/parameters"
+)
+
+type stubAdvisor struct {
+	suggested []models.Ranked
+	err       error
+}
+
+func (a stubAdvisor) Advise(ctx context.Context, params parameters.RequestPerfume) ([]models.Ranked, error) {
+	return a.suggested, a.err
+}
+
+func TestWriteSuggestions_Streaming(t *testing.T) {
+	t.Parallel()
+
+	handler := Streaming(func(w http.ResponseWriter, r *http.Request) {
+		advisor := stubAdvisor{suggested: []models.Ranked{{Perfume: models.Perfume{Brand: "Chanel", Name: "No5"}, Rank: 1}}}
+		writeSuggestions(w, r, advisor, *parameters.NewGet())
+	})
+	w := httptest.NewRecorder()
+	handler(w, httptest.NewRequest(http.MethodGet, "/", nil))
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status %d, got %d", http.StatusOK, w.Code)
+	}
+	if contentType := w.Header().Get("Content-Type"); contentType != "text/event-stream" {
+		t.Fatalf("expected text/event-stream, got %q", contentType)
+	}
+	body := w.Body.String()
+	if !strings.HasPrefix(body, "event: result\ndata: {\"suggested\":[") || !strings.HasSuffix(body, "\n\n") {
+		t.Fatalf("expected a single result event, got %q", body)
+	}
+	if !strings.Contains(body, `"name":"No5"`) {
+		t.Fatalf("expected suggestion in result event, got %q", body)
+	}
+}
+
+func TestWriteSuggestions_StreamingError(t *testing.T) {
+	t.Parallel()
+
+	handler := Streaming(func(w http.ResponseWriter, r *http.Request) {
+		writeSuggestions(w, r, stubAdvisor{err: errors.NewNotFoundError("perfume not found")}, *parameters.NewGet())
+	})
+	w := httptest.NewRecorder()
+	handler(w, httptest.NewRequest(http.MethodGet, "/", nil))
+
+	expected := "event: error\ndata: {\"status\":404,\"error\":\"perfume not found\"}\n\n"
+	if w.Body.String() != expected {
+		t.Fatalf("expected %q, got %q", expected, w.Body.String())
+	}
+}
+
+func TestWriteSuggestions_NotStreaming(t *testing.T) {
+	t.Parallel()
+
+	w := httptest.NewRecorder()
+	writeSuggestions(w, httptest.NewRequest(http.MethodGet, "/", nil), stubAdvisor{}, *parameters.NewGet())
+
+	if contentType := w.Header().Get("Content-Type"); contentType != "application/json" {
+		t.Fatalf("expected application/json, got %q", contentType)
+	}
+}
+
+func TestEventWriter_DropsEventsAfterClose(t *testing.T) {
+	t.Parallel()
+
+	w := httptest.NewRecorder()
+	events := &eventWriter{w: w, flusher: w}
+	events.write("progress", map[string]int{"processed": 10})
+	events.close(ResultEvent, map[string]int{})
+	events.write("progress", map[string]int{"processed": 20})
+
+	expected := "event: progress\ndata: {\"processed\":10}\n\nevent: result\ndata: {}\n\n"
+	if w.Body.String() != expected {
+		t.Fatalf("expected %q, got %q", expected, w.Body.String())
+	}
+}
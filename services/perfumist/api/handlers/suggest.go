@@ -5,48 +5,49 @@ import (
 
 	"github.com/zemld/Scently/perfumist/internal/config"
 	"github.com/zemld/Scently/perfumist/internal/models/advising"
-	"github.com/zemld/Scently/perfumist/internal/models/matching"
+	"github.com/zemld/Scently/perfumist/internal/service"
 )
 
 func Suggest(w http.ResponseWriter, r *http.Request) {
-	ctx := r.Context()
-
 	params, err := generalParseSimilarParameters(r)
 	if err != nil {
 		handleError(w, err)
 		return
 	}
 
-	perfumeHubFetcher, err := createPerfumeHubFetcher(config.Manager())
+	exclusions, err := parseExclusionsParameters(r, config.Manager().GetIntWithDefault("max_disliked_count", 10))
 	if err != nil {
 		handleError(w, err)
 		return
 	}
-	_ = perfumeHubFetcher
 
-	advisor := advising.NewBase(
-		perfumeHubFetcher,
-		matching.NewCombinedMatcher(
-			*matching.NewWeights(
-				config.Manager().GetFloatWithDefault("family_weight", 0.4),
-				config.Manager().GetFloatWithDefault("notes_weight", 0.55),
-				config.Manager().GetFloatWithDefault("type_weight", 0.05),
-				config.Manager().GetFloatWithDefault("upper_notes_weight", 0.2),
-				config.Manager().GetFloatWithDefault("core_notes_weight", 0.35),
-				config.Manager().GetFloatWithDefault("base_notes_weight", 0.45),
-				config.Manager().GetFloatWithDefault("characteristics_weight", 0.3),
-				config.Manager().GetFloatWithDefault("tags_weight", 0.5),
-				config.Manager().GetFloatWithDefault("overlay_weight", 0.2),
-			),
-		),
-		config.Manager(),
-	)
+	offers, err := parseOffersParameters(r)
+	if err != nil {
+		handleError(w, err)
+		return
+	}
+
+	diversity, err := parseDiversityParameters(r, config.Manager())
+	if err != nil {
+		handleError(w, err)
+		return
+	}
 
-	suggested, err := advisor.Advise(ctx, params)
+	r = assignExperiment(r, config.Manager(), exposureLogger)
+	matcher, err := createMatcher(r, config.Manager())
 	if err != nil {
 		handleError(w, err)
 		return
 	}
 
-	WriteResponse(w, SuggestResponse{Suggested: suggested}, http.StatusOK)
+	advisor := advising.NewBase(
+		service.PerfumesCatalog(),
+		matcher,
+		config.Manager(),
+	).WithResolver(createFavouriteResolver(r, config.Manager()))
+	if parseDescribeParameter(r) {
+		advisor.WithDescriber(service.NewDescriber(config.Manager()))
+	}
+
+	writeSuggestions(w, r, advisor, *params.WithExclusions(exclusions).WithOffers(offers).WithDiversity(diversity))
 }
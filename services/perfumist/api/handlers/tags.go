@@ -1,53 +1,77 @@
 package handlers
 
 import (
+	"fmt"
 	"log"
 	"net/http"
-	"strings"
+	"strconv"
 
 	"github.com/zemld/Scently/perfumist/internal/config"
 	"github.com/zemld/Scently/perfumist/internal/errors"
-	"github.com/zemld/Scently/perfumist/internal/models/advising"
-	"github.com/zemld/Scently/perfumist/internal/models/matching"
 	"github.com/zemld/Scently/perfumist/internal/models/parameters"
+	"github.com/zemld/Scently/perfumist/internal/service"
 )
 
 func SuggestByTags(w http.ResponseWriter, r *http.Request) {
 	log.Println("SuggestByTags request received")
-	ctx := r.Context()
 
 	sex := parseSexParameter(r)
-	rawTags := r.URL.Query().Get("tags")
-	tags := strings.Split(rawTags, ",")
-	log.Printf("Tags: %+v, length: %d", tags, len(tags))
-	if len(tags) == 0 || rawTags == "" {
-		handleError(w, errors.NewValidationError("tags", "are required"))
+	vocabulary, err := service.CatalogVocabulary(r.Context(), config.Manager())
+	if err != nil {
+		handleError(w, err)
+		return
+	}
+	tags, err := parseTagsParameter(r, vocabulary.Tags)
+	if err != nil {
+		handleError(w, err)
+		return
+	}
+	log.Printf("Tags: %+v", tags)
+
+	minMatch, err := parseMinMatchParameter(r, config.Manager().GetIntWithDefault("minimal_tag_count", 3), len(tags.Weighted))
+	if err != nil {
+		handleError(w, err)
+		return
+	}
+
+	exclusions, err := parseExclusionsParameters(r, config.Manager().GetIntWithDefault("max_disliked_count", 10))
+	if err != nil {
+		handleError(w, err)
 		return
 	}
+	exclusions.Tags = append(exclusions.Tags, tags.Forbidden...)
 
-	perfumeHubFetcher, err := createPerfumeHubFetcher(config.Manager())
+	offers, err := parseOffersParameters(r)
 	if err != nil {
 		handleError(w, err)
 		return
 	}
 
-	advisor := advising.NewTagsBased(
-		matching.NewTagsBasedAdapter(
-			matching.Weights{
-				UpperNotesWeight: config.Manager().GetFloatWithDefault("upper_notes_weight", 0.2),
-				CoreNotesWeight:  config.Manager().GetFloatWithDefault("core_notes_weight", 0.35),
-				BaseNotesWeight:  config.Manager().GetFloatWithDefault("base_notes_weight", 0.45),
-			},
-			tags,
-		),
-		perfumeHubFetcher,
-		config.Manager(),
-	)
-
-	suggested, err := advisor.Advise(ctx, *parameters.NewGet().WithSex(sex))
+	diversity, err := parseDiversityParameters(r, config.Manager())
 	if err != nil {
 		handleError(w, err)
 		return
 	}
-	WriteResponse(w, SuggestResponse{Suggested: suggested}, http.StatusOK)
+
+	advisor := service.NewTagsQueryAdvisor(tags, minMatch, config.Manager())
+
+	writeSuggestions(w, r, advisor, *parameters.NewGet().WithSex(sex).WithExclusions(exclusions).WithOffers(offers).WithDiversity(diversity))
+}
+
+func parseTagsParameter(r *http.Request, vocabulary []string) (service.TagsQuery, error) {
+	return service.ParseTags(parseListParameter(r, parameters.TagsParamKey), vocabulary)
+}
+
+// parseMinMatchParameter defaults to minimal_tag_count, capped by the number of
+// requested tags so that short queries are not left without suggestions.
+func parseMinMatchParameter(r *http.Request, defaultValue int, tagsCount int) (int, error) {
+	rawValue := r.URL.Query().Get(parameters.MinMatchParamKey)
+	if rawValue == "" {
+		return service.DefaultMinMatch(defaultValue, tagsCount), nil
+	}
+	value, err := strconv.Atoi(rawValue)
+	if err != nil || value < 0 || value > tagsCount {
+		return 0, errors.NewValidationError(parameters.MinMatchParamKey, fmt.Sprintf("must be an integer between 0 and %d", tagsCount))
+	}
+	return value, nil
 }
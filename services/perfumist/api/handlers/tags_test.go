@@ -0,0 +1,110 @@
+package handlers
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/zemld/Scently/perfumist/internal/errors"
+)
+
+var testTags = []string{"fresh", "sweet", "warm", "woody"}
+
+func TestParseTagsParameter(t *testing.T) {
+	t.Parallel()
+
+	req := httptest.NewRequest(http.MethodGet, "/?tags=Warm:3,%2Bwoody,-sweet,warm", nil)
+	query, err := parseTagsParameter(req, testTags)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if len(query.Weighted) != 2 || query.Weighted["warm"] != 4 || query.Weighted["woody"] != 1 {
+		t.Fatalf("expected summed weights of warm and woody, got %v", query.Weighted)
+	}
+	if !slices.Equal(query.Required, []string{"woody"}) {
+		t.Fatalf("expected woody to be required, got %v", query.Required)
+	}
+	if !slices.Equal(query.Forbidden, []string{"sweet"}) {
+		t.Fatalf("expected sweet to be excluded, got %v", query.Forbidden)
+	}
+}
+
+func TestParseTagsParameter_RequiredMarkers(t *testing.T) {
+	t.Parallel()
+
+	for _, rawQuery := range []string{"tags=*woody,warm", "tags=%2Bwoody,warm"} {
+		req := httptest.NewRequest(http.MethodGet, "/?"+rawQuery, nil)
+		query, err := parseTagsParameter(req, testTags)
+		if err != nil {
+			t.Fatalf("%s: expected no error, got %v", rawQuery, err)
+		}
+		if !slices.Equal(query.Required, []string{"woody"}) {
+			t.Fatalf("%s: expected woody to be required, got %v", rawQuery, query.Required)
+		}
+	}
+}
+
+func TestParseTagsParameter_EmptyVocabulary(t *testing.T) {
+	t.Parallel()
+
+	req := httptest.NewRequest(http.MethodGet, "/?tags=cozy", nil)
+	if _, err := parseTagsParameter(req, nil); err == nil {
+		t.Fatal("expected unknown tag without vocabulary")
+	}
+}
+
+func TestParseTagsParameter_UnknownTag(t *testing.T) {
+	t.Parallel()
+
+	req := httptest.NewRequest(http.MethodGet, "/?tags=warm,cozy", nil)
+	_, err := parseTagsParameter(req, testTags)
+	if _, ok := err.(*errors.ValidationError); !ok {
+		t.Fatalf("expected ValidationError, got %v", err)
+	}
+	if !strings.Contains(err.Error(), "fresh, sweet, warm, woody") {
+		t.Fatalf("expected error to list the vocabulary, got %q", err.Error())
+	}
+}
+
+func TestParseTagsParameter_Invalid(t *testing.T) {
+	t.Parallel()
+
+	for _, url := range []string{
+		"/",
+		"/?tags=warm:0",
+		"/?tags=warm:heavy",
+		"/?tags=-sweet",
+		"/?tags=-sweet:2,warm",
+		"/?tags=warm,-warm",
+		"/?tags=%2B,warm",
+	} {
+		req := httptest.NewRequest(http.MethodGet, url, nil)
+		_, err := parseTagsParameter(req, testTags)
+		if _, ok := err.(*errors.ValidationError); !ok {
+			t.Fatalf("%s: expected ValidationError, got %v", url, err)
+		}
+	}
+}
+
+func TestParseMinMatchParameter(t *testing.T) {
+	t.Parallel()
+
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	if minMatch, err := parseMinMatchParameter(req, 3, 2); err != nil || minMatch != 2 {
+		t.Fatalf("expected default capped by tags count, got %d, %v", minMatch, err)
+	}
+
+	req = httptest.NewRequest(http.MethodGet, "/?min_match=1", nil)
+	if minMatch, err := parseMinMatchParameter(req, 3, 2); err != nil || minMatch != 1 {
+		t.Fatalf("expected 1, got %d, %v", minMatch, err)
+	}
+
+	for _, url := range []string{"/?min_match=3", "/?min_match=-1", "/?min_match=all"} {
+		req := httptest.NewRequest(http.MethodGet, url, nil)
+		if _, err := parseMinMatchParameter(req, 3, 2); err == nil {
+			t.Fatalf("%s: expected error", url)
+		}
+	}
+}
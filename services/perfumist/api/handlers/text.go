@@ -0,0 +1,109 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/zemld/Scently/models"
+	"github.com/zemld/Scently/perfumist/internal/config"
+	"github.com/zemld/Scently/perfumist/internal/errors"
+	"github.com/zemld/Scently/perfumist/internal/models/advising"
+	"github.com/zemld/Scently/perfumist/internal/models/fetching"
+	"github.com/zemld/Scently/perfumist/internal/models/matching"
+	"github.com/zemld/Scently/perfumist/internal/models/parameters"
+	"github.com/zemld/Scently/perfumist/internal/service"
+)
+
+type TextSuggestResponse struct {
+	Interpreted fetching.Constraints `json:"interpreted"`
+	Suggested   []models.Ranked      `json:"suggested"`
+}
+
+type ExplainedTextSuggestResponse struct {
+	Interpreted fetching.Constraints `json:"interpreted"`
+	Suggested   []advising.Explained `json:"suggested"`
+}
+
+func SuggestByText(w http.ResponseWriter, r *http.Request) {
+	text, err := parseTextParameter(r, config.Manager().GetIntWithDefault("text_max_length", 300))
+	if err != nil {
+		handleError(w, err)
+		return
+	}
+
+	exclusions, err := parseExclusionsParameters(r, config.Manager().GetIntWithDefault("max_disliked_count", 10))
+	if err != nil {
+		handleError(w, err)
+		return
+	}
+
+	offers, err := parseOffersParameters(r)
+	if err != nil {
+		handleError(w, err)
+		return
+	}
+
+	diversity, err := parseDiversityParameters(r, config.Manager())
+	if err != nil {
+		handleError(w, err)
+		return
+	}
+
+	vocabulary, err := service.CatalogVocabulary(r.Context(), config.Manager())
+	if err != nil {
+		handleError(w, err)
+		return
+	}
+	interpreter := fetching.NewTextInterpreter(service.NewLLMProvider(config.Manager()), config.Manager())
+	constraints, err := interpreter.Interpret(r.Context(), text, vocabulary)
+	if err != nil {
+		handleError(w, err)
+		return
+	}
+
+	advisor := advising.NewTagsBased(
+		matching.NewQuery(
+			*matching.NewBaseWeights(
+				config.Manager().GetFloatWithDefault("upper_notes_weight", 0.2),
+				config.Manager().GetFloatWithDefault("core_notes_weight", 0.35),
+				config.Manager().GetFloatWithDefault("base_notes_weight", 0.45),
+			),
+			constraints.Tags,
+			constraints.Notes,
+			constraints.Characteristics,
+		),
+		service.PerfumesCatalog(),
+		config.Manager(),
+	)
+	params := *parameters.NewGet().WithSex(parseSexParameter(r)).WithExclusions(exclusions).WithOffers(offers).WithDiversity(diversity)
+
+	if parseExplainParameter(r) {
+		explained, err := advisor.AdviseWithExplanations(r.Context(), params)
+		if err != nil {
+			handleError(w, err)
+			return
+		}
+		WriteResponse(w, ExplainedTextSuggestResponse{Interpreted: constraints, Suggested: explained}, http.StatusOK)
+		return
+	}
+
+	suggested, err := advisor.Advise(r.Context(), params)
+	if err != nil {
+		handleError(w, err)
+		return
+	}
+	WriteResponse(w, TextSuggestResponse{Interpreted: constraints, Suggested: suggested}, http.StatusOK)
+}
+
+func parseTextParameter(r *http.Request, maxLength int) (string, error) {
+	text := strings.TrimSpace(r.URL.Query().Get(parameters.TextParamKey))
+	if text == "" {
+		return "", errors.NewValidationError(parameters.TextParamKey, "is required")
+	}
+	if utf8.RuneCountInString(text) > maxLength {
+		return "", errors.NewValidationError(parameters.TextParamKey, fmt.Sprintf("must be at most %d characters long", maxLength))
+	}
+	return text, nil
+}
@@ -0,0 +1,41 @@
+package handlers
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/zemld/Scently/perfumist/internal/errors"
+)
+
+func TestParseTextParameter(t *testing.T) {
+	t.Parallel()
+
+	req := httptest.NewRequest(http.MethodGet, "/?text="+url.QueryEscape("  что-то тёплое на зиму "), nil)
+	text, err := parseTextParameter(req, 21)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if text != "что-то тёплое на зиму" {
+		t.Fatalf("expected trimmed text, got %q", text)
+	}
+}
+
+func TestParseTextParameter_Invalid(t *testing.T) {
+	t.Parallel()
+
+	for _, rawURL := range []string{
+		"/",
+		"/?text=%20%20",
+		"/?text=" + strings.Repeat("a", 21),
+	} {
+		req := httptest.NewRequest(http.MethodGet, rawURL, nil)
+		if _, err := parseTextParameter(req, 20); err == nil {
+			t.Fatalf("expected error for %s", rawURL)
+		} else if _, ok := err.(*errors.ValidationError); !ok {
+			t.Fatalf("expected validation error for %s, got %v", rawURL, err)
+		}
+	}
+}
@@ -14,15 +14,7 @@ const prefix = "Bearer "
 
 func Auth(next http.HandlerFunc) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
-		rawToken := r.Header.Get("Authorization")
-		if !strings.HasPrefix(rawToken, prefix) {
-			authErr := errors.NewAuthError("missing or invalid authorization header")
-			handleAuthError(w, authErr)
-			return
-		}
-		token := strings.TrimPrefix(rawToken, prefix)
-		if token != perfumistToken {
-			authErr := errors.NewAuthError("invalid token")
+		if authErr := Authorize(r.Header.Get("Authorization")); authErr != nil {
 			handleAuthError(w, authErr)
 			return
 		}
@@ -30,6 +22,18 @@ func Auth(next http.HandlerFunc) http.HandlerFunc {
 	}
 }
 
+// Authorize checks a raw "Bearer <token>" authorization value against the
+// internal token of perfumist.
+func Authorize(rawToken string) *errors.AuthError {
+	if !strings.HasPrefix(rawToken, prefix) {
+		return errors.NewAuthError("missing or invalid authorization header")
+	}
+	if strings.TrimPrefix(rawToken, prefix) != perfumistToken {
+		return errors.NewAuthError("invalid token")
+	}
+	return nil
+}
+
 func handleAuthError(w http.ResponseWriter, err *errors.AuthError) {
 	w.Header().Set("Content-Type", "text/plain")
 	w.WriteHeader(err.HTTPStatus())
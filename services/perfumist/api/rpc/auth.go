@@ -0,0 +1,22 @@
+package rpc
+
+import (
+	"context"
+
+	"github.com/zemld/Scently/perfumist/api/middleware"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+// authInterceptor accepts the same bearer tokens as middleware.Auth, passed in
+// the "authorization" metadata.
+func authInterceptor(ctx context.Context, req any, _ *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+	rawToken := ""
+	if values := metadata.ValueFromIncomingContext(ctx, "authorization"); len(values) > 0 {
+		rawToken = values[0]
+	}
+	if authErr := middleware.Authorize(rawToken); authErr != nil {
+		return nil, statusError(ctx, authErr)
+	}
+	return handler(ctx, req)
+}
@@ -0,0 +1,52 @@
+package rpc
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+func callWithAuthorization(values ...string) (bool, error) {
+	ctx := context.Background()
+	if len(values) > 0 {
+		ctx = metadata.NewIncomingContext(ctx, metadata.Pairs("authorization", values[0]))
+	}
+
+	called := false
+	_, err := authInterceptor(ctx, nil, nil, func(context.Context, any) (any, error) {
+		called = true
+		return nil, nil
+	})
+	return called, err
+}
+
+func TestAuthInterceptor_AllowsValidToken(t *testing.T) {
+	called, err := callWithAuthorization("Bearer " + os.Getenv("PERFUMIST_INTERNAL_TOKEN"))
+	if err != nil || !called {
+		t.Fatalf("expected handler to be called, got %v", err)
+	}
+}
+
+func TestAuthInterceptor_RejectsRequestWithoutToken(t *testing.T) {
+	called, err := callWithAuthorization()
+	if called {
+		t.Fatalf("handler should not be called")
+	}
+	if code := status.Code(err); code != codes.Unauthenticated {
+		t.Fatalf("expected Unauthenticated, got %v", code)
+	}
+}
+
+func TestAuthInterceptor_RejectsInvalidToken(t *testing.T) {
+	called, err := callWithAuthorization("Bearer " + os.Getenv("PERFUMIST_INTERNAL_TOKEN") + "-invalid")
+	if called {
+		t.Fatalf("handler should not be called")
+	}
+	if code := status.Code(err); code != codes.Unauthenticated {
+		t.Fatalf("expected Unauthenticated, got %v", code)
+	}
+}
@@ -0,0 +1,126 @@
+package rpc
+
+import (
+	"github.com/zemld/Scently/models"
+	perfumistpb "github.com/zemld/Scently/shared/proto/perfumist"
+	pb "github.com/zemld/Scently/shared/proto/perfumist/models"
+)
+
+func fromProtoSex(sex pb.Perfume_Sex) models.Sex {
+	switch sex {
+	case pb.Perfume_MALE:
+		return models.Male
+	case pb.Perfume_FEMALE:
+		return models.Female
+	default:
+		return models.Unisex
+	}
+}
+
+func toProtoSex(sex models.Sex) pb.Perfume_Sex {
+	switch sex {
+	case models.Male:
+		return pb.Perfume_MALE
+	case models.Female:
+		return pb.Perfume_FEMALE
+	default:
+		return pb.Perfume_UNISEX
+	}
+}
+
+// toProtoSuggestResponse also fills perfumes, which clients without ranks read.
+func toProtoSuggestResponse(ranked []models.Ranked) *perfumistpb.SuggestResponse {
+	suggested := toProtoRanked(ranked)
+	perfumes := make([]*pb.Perfume, 0, len(suggested))
+	for _, r := range suggested {
+		perfumes = append(perfumes, r.GetPerfume())
+	}
+	return &perfumistpb.SuggestResponse{Perfumes: perfumes, Suggested: suggested}
+}
+
+func toProtoRanked(ranked []models.Ranked) []*pb.RankedPerfume {
+	converted := make([]*pb.RankedPerfume, 0, len(ranked))
+	for _, r := range ranked {
+		converted = append(converted, &pb.RankedPerfume{
+			Perfume:         toProtoPerfume(r.Perfume),
+			Rank:            int32(r.Rank),
+			SimilarityScore: r.Score,
+		})
+	}
+	return converted
+}
+
+func toProtoPerfume(perfume models.Perfume) *pb.Perfume {
+	shops := make([]*pb.Perfume_Shop, 0, len(perfume.Shops))
+	for _, shop := range perfume.Shops {
+		variants := make([]*pb.Perfume_Shop_Variant, 0, len(shop.Variants))
+		for _, variant := range shop.Variants {
+			variants = append(variants, &pb.Perfume_Shop_Variant{
+				Volume: int32(variant.Volume),
+				Link:   variant.Link,
+				Price:  int32(variant.Price),
+			})
+		}
+		shops = append(shops, &pb.Perfume_Shop{
+			Name:     shop.ShopName,
+			Domain:   shop.Domain,
+			Variants: variants,
+		})
+	}
+
+	return &pb.Perfume{
+		Brand:      perfume.Brand,
+		Name:       perfume.Name,
+		Sex:        toProtoSex(perfume.Sex),
+		ImageUrl:   perfume.ImageUrl,
+		Properties: toProtoProperties(perfume.Properties),
+		Shops:      shops,
+	}
+}
+
+func toProtoProperties(properties models.Properties) *pb.Perfume_Properties {
+	var tags map[string]int32
+	if properties.Tags != nil {
+		tags = make(map[string]int32, len(properties.Tags))
+		for tag, count := range properties.Tags {
+			tags[tag] = int32(count)
+		}
+	}
+
+	return &pb.Perfume_Properties{
+		Type:                 properties.Type,
+		Family:               properties.Family,
+		UpperNotes:           properties.UpperNotes,
+		CoreNotes:            properties.CoreNotes,
+		BaseNotes:            properties.BaseNotes,
+		EnrichedUpperNotes:   toProtoEnrichedNotes(properties.EnrichedUpperNotes),
+		EnrichedCoreNotes:    toProtoEnrichedNotes(properties.EnrichedCoreNotes),
+		EnrichedBaseNotes:    toProtoEnrichedNotes(properties.EnrichedBaseNotes),
+		Tags:                 tags,
+		UpperCharacteristics: properties.UpperCharacteristics,
+		CoreCharacteristics:  properties.CoreCharacteristics,
+		BaseCharacteristics:  properties.BaseCharacteristics,
+	}
+}
+
+func toProtoEnrichedNotes(notes []models.EnrichedNote) []*pb.Perfume_Properties_EnrichedNote {
+	if notes == nil {
+		return nil
+	}
+	converted := make([]*pb.Perfume_Properties_EnrichedNote, 0, len(notes))
+	for _, note := range notes {
+		characteristics := make([]*pb.Perfume_Properties_EnrichedNote_NoteCharacteristic, 0, len(note.Characteristics))
+		for _, characteristic := range note.Characteristics {
+			characteristics = append(characteristics, &pb.Perfume_Properties_EnrichedNote_NoteCharacteristic{
+				Name:  characteristic.Name,
+				Value: characteristic.Value,
+			})
+		}
+		converted = append(converted, &pb.Perfume_Properties_EnrichedNote{
+			Name:            note.Name,
+			Tags:            note.Tags,
+			Characteristics: characteristics,
+		})
+	}
+	return converted
+}
@@ -0,0 +1,91 @@
+package rpc
+
+import (
+	"testing"
+
+	"github.com/zemld/Scently/models"
+	pb "github.com/zemld/Scently/shared/proto/perfumist/models"
+)
+
+func TestSexConversion(t *testing.T) {
+	t.Parallel()
+
+	for sex, protoSex := range map[models.Sex]pb.Perfume_Sex{
+		models.Male:   pb.Perfume_MALE,
+		models.Female: pb.Perfume_FEMALE,
+		models.Unisex: pb.Perfume_UNISEX,
+	} {
+		if converted := toProtoSex(sex); converted != protoSex {
+			t.Fatalf("toProtoSex(%s) = %v, want %v", sex, converted, protoSex)
+		}
+		if converted := fromProtoSex(protoSex); converted != sex {
+			t.Fatalf("fromProtoSex(%v) = %s, want %s", protoSex, converted, sex)
+		}
+	}
+	if converted := toProtoSex(""); converted != pb.Perfume_UNISEX {
+		t.Fatalf("expected unknown sex to be unisex, got %v", converted)
+	}
+}
+
+func TestToProtoRanked(t *testing.T) {
+	t.Parallel()
+
+	ranked := []models.Ranked{{
+		Perfume: models.Perfume{
+			Brand:    "Dior",
+			Name:     "Sauvage",
+			Sex:      models.Male,
+			ImageUrl: "https://example.com/sauvage.png",
+			Properties: models.Properties{
+				Type:       "EDT",
+				Family:     []string{"fougere"},
+				UpperNotes: []string{"bergamot"},
+				EnrichedUpperNotes: []models.EnrichedNote{{
+					Name:            "bergamot",
+					Tags:            []string{"fresh"},
+					Characteristics: []models.NoteCharacteristic{{Name: "citrus", Value: 0.9}},
+				}},
+				Tags:                 map[string]int{"fresh": 3},
+				UpperCharacteristics: map[string]float64{"citrus": 0.9},
+			},
+			Shops: []models.ShopInfo{{
+				ShopName: "Gold Apple",
+				Domain:   "goldapple.ru",
+				Variants: []models.Variant{{Volume: 100, Link: "https://goldapple.ru/sauvage", Price: 12000}},
+			}},
+		},
+		Rank:  1,
+		Score: 0.87,
+	}}
+
+	converted := toProtoRanked(ranked)
+	if len(converted) != 1 {
+		t.Fatalf("expected 1 perfume, got %d", len(converted))
+	}
+	got := converted[0]
+	if got.GetRank() != 1 || got.GetSimilarityScore() != 0.87 {
+		t.Fatalf("unexpected rank or score: %v", got)
+	}
+
+	perfume := got.GetPerfume()
+	if perfume.GetBrand() != "Dior" || perfume.GetName() != "Sauvage" || perfume.GetSex() != pb.Perfume_MALE {
+		t.Fatalf("unexpected perfume: %v", perfume)
+	}
+	if perfume.GetImageUrl() != "https://example.com/sauvage.png" {
+		t.Fatalf("unexpected image url: %s", perfume.GetImageUrl())
+	}
+
+	properties := perfume.GetProperties()
+	if properties.GetType() != "EDT" || properties.GetUpperNotes()[0] != "bergamot" || properties.GetTags()["fresh"] != 3 {
+		t.Fatalf("unexpected properties: %v", properties)
+	}
+	note := properties.GetEnrichedUpperNotes()[0]
+	if note.GetName() != "bergamot" || note.GetCharacteristics()[0].GetValue() != 0.9 {
+		t.Fatalf("unexpected enriched note: %v", note)
+	}
+
+	variant := perfume.GetShops()[0].GetVariants()[0]
+	if perfume.GetShops()[0].GetName() != "Gold Apple" || variant.GetVolume() != 100 || variant.GetPrice() != 12000 {
+		t.Fatalf("unexpected shops: %v", perfume.GetShops())
+	}
+}
@@ -0,0 +1,109 @@
+package rpc
+
+import (
+	"context"
+	"log"
+
+	"github.com/zemld/Scently/perfumist/internal/models/advising"
+	"github.com/zemld/Scently/perfumist/internal/models/parameters"
+	"github.com/zemld/Scently/perfumist/internal/service"
+	perfumistpb "github.com/zemld/Scently/shared/proto/perfumist"
+	"github.com/zemld/config-manager/pkg/cm"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type perfumistServer struct {
+	perfumistpb.UnimplementedPerfumistServiceServer
+	cm cm.ConfigManager
+}
+
+// NewServer serves PerfumistService with the advisors of the HTTP service.
+// Advisors get the request context, so the deadline set by the client bounds
+// their calls to perfume-hub and the LLM.
+func NewServer(cm cm.ConfigManager) *grpc.Server {
+	server := grpc.NewServer(grpc.UnaryInterceptor(authInterceptor))
+	perfumistpb.RegisterPerfumistServiceServer(server, &perfumistServer{cm: cm})
+	return server
+}
+
+func (s *perfumistServer) Suggest(ctx context.Context, req *perfumistpb.SuggestRequest) (*perfumistpb.SuggestResponse, error) {
+	log.Println("gRPC Suggest request received")
+
+	params := parameters.NewGet().
+		WithBrand(req.GetBrand()).
+		WithName(req.GetName()).
+		WithSex(fromProtoSex(req.GetSex())).
+		WithDiversity(service.DefaultDiversity(s.cm))
+	if err := params.Validate(); err != nil {
+		return nil, statusError(ctx, err)
+	}
+
+	advisor, err := service.NewSuggestAdvisor(s.cm, false)
+	if err != nil {
+		return nil, statusError(ctx, err)
+	}
+	return advise(ctx, advisor, *params)
+}
+
+func (s *perfumistServer) AISuggest(ctx context.Context, req *perfumistpb.SuggestRequest) (*perfumistpb.SuggestResponse, error) {
+	log.Println("gRPC AISuggest request received")
+
+	params := parameters.NewGet().
+		WithBrand(req.GetBrand()).
+		WithName(req.GetName()).
+		WithSex(fromProtoSex(req.GetSex()))
+	if err := params.Validate(); err != nil {
+		return nil, statusError(ctx, err)
+	}
+
+	advisor, err := service.NewAISuggestAdvisor(s.cm, false)
+	if err != nil {
+		return nil, statusError(ctx, err)
+	}
+	return advise(ctx, advisor, *params)
+}
+
+func (s *perfumistServer) SuggestByTags(ctx context.Context, req *perfumistpb.SuggestByTagsRequest) (*perfumistpb.SuggestResponse, error) {
+	log.Println("gRPC SuggestByTags request received")
+
+	advisor, forbidden, err := service.NewTagsAdvisor(ctx, s.cm, req.GetTags())
+	if err != nil {
+		return nil, statusError(ctx, err)
+	}
+
+	params := parameters.NewGet().
+		WithSex(fromProtoSex(req.GetSex())).
+		WithExclusions(parameters.Exclusions{Tags: forbidden}).
+		WithDiversity(service.DefaultDiversity(s.cm))
+	return advise(ctx, advisor, *params)
+}
+
+func advise(ctx context.Context, advisor advising.Advisor, params parameters.RequestPerfume) (*perfumistpb.SuggestResponse, error) {
+	suggested, err := advisor.Advise(ctx, params)
+	if err != nil {
+		return nil, statusError(ctx, err)
+	}
+	return toProtoSuggestResponse(suggested), nil
+}
+
+// statusError maps errors to codes as the HTTP handlers map them to statuses.
+// Once the request is cancelled or past its deadline, that is
+// reported instead of whatever the advisor failed with.
+func statusError(ctx context.Context, err error) error {
+	if ctx.Err() != nil {
+		return status.FromContextError(ctx.Err()).Err()
+	}
+	kind, message := service.ClassifyError(err)
+	switch kind {
+	case service.ValidationError:
+		return status.Error(codes.InvalidArgument, message)
+	case service.NotFoundError:
+		return status.Error(codes.NotFound, message)
+	case service.AuthError:
+		return status.Error(codes.Unauthenticated, message)
+	default:
+		return status.Error(codes.Internal, message)
+	}
+}
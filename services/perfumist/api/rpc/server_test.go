@@ -0,0 +1,78 @@
+package rpc
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/zemld/Scently/models"
+	"github.com/zemld/Scently/perfumist/internal/errors"
+	"github.com/zemld/Scently/perfumist/internal/models/parameters"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type mockAdvisor struct {
+	suggested []models.Ranked
+	err       error
+	deadline  time.Time
+}
+
+func (a *mockAdvisor) Advise(ctx context.Context, _ parameters.RequestPerfume) ([]models.Ranked, error) {
+	a.deadline, _ = ctx.Deadline()
+	return a.suggested, a.err
+}
+
+func TestAdvise_PropagatesDeadline(t *testing.T) {
+	t.Parallel()
+
+	deadline := time.Now().Add(time.Minute)
+	ctx, cancel := context.WithDeadline(context.Background(), deadline)
+	defer cancel()
+
+	advisor := &mockAdvisor{suggested: []models.Ranked{{Perfume: models.Perfume{Brand: "Dior", Name: "Sauvage"}, Rank: 1}}}
+	response, err := advise(ctx, advisor, *parameters.NewGet())
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if !advisor.deadline.Equal(deadline) {
+		t.Fatalf("expected advisor to get deadline %v, got %v", deadline, advisor.deadline)
+	}
+	if len(response.GetSuggested()) != 1 || response.GetSuggested()[0].GetPerfume().GetName() != "Sauvage" {
+		t.Fatalf("unexpected response: %v", response)
+	}
+	if len(response.GetPerfumes()) != 1 || response.GetPerfumes()[0].GetName() != "Sauvage" {
+		t.Fatalf("expected perfumes for older clients, got %v", response.GetPerfumes())
+	}
+}
+
+func TestAdvise_DeadlineExceeded(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithDeadline(context.Background(), time.Now().Add(-time.Second))
+	defer cancel()
+
+	_, err := advise(ctx, &mockAdvisor{err: errors.NewServiceError("failed to fetch perfumes", ctx.Err())}, *parameters.NewGet())
+	if code := status.Code(err); code != codes.DeadlineExceeded {
+		t.Fatalf("expected DeadlineExceeded, got %v", code)
+	}
+}
+
+func TestStatusError(t *testing.T) {
+	t.Parallel()
+
+	for _, tc := range []struct {
+		err  error
+		code codes.Code
+	}{
+		{errors.NewValidationError("brand", "is required"), codes.InvalidArgument},
+		{errors.NewNotFoundError("perfume not found"), codes.NotFound},
+		{errors.NewAuthError("invalid token"), codes.Unauthenticated},
+		{errors.NewServiceError("failed to fetch perfumes", nil), codes.Internal},
+		{context.Canceled, codes.Internal},
+	} {
+		if code := status.Code(statusError(context.Background(), tc.err)); code != tc.code {
+			t.Fatalf("%v: expected %v, got %v", tc.err, tc.code, code)
+		}
+	}
+}
@@ -1,24 +1,61 @@
 package main
 
 import (
+	"context"
 	"log"
+	"net"
 	"net/http"
 	"time"
 
 	"github.com/zemld/Scently/perfumist/api/handlers"
 	"github.com/zemld/Scently/perfumist/api/middleware"
+	"github.com/zemld/Scently/perfumist/api/rpc"
 	"github.com/zemld/Scently/perfumist/internal/config"
+	"github.com/zemld/Scently/perfumist/internal/models/fetching"
+	"github.com/zemld/Scently/perfumist/internal/service"
 )
 
+const configLoadInterval = 10 * time.Second
+
 func main() {
-	config.Manager().StartLoading(10 * time.Second)
+	config.Manager().StartLoading(configLoadInterval)
 	defer config.Manager().StopLoading()
 
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go fetching.WatchPrompts(ctx, config.Manager(), configLoadInterval)
+
+	service.PerfumesCatalog().StartRefreshing()
+	defer service.PerfumesCatalog().StopRefreshing()
+
 	r := http.NewServeMux()
 
 	r.HandleFunc("GET /v2/perfume/suggest", middleware.Auth(handlers.Suggest))
 	r.HandleFunc("GET /v2/perfume/ai-suggest", middleware.Auth(handlers.AISuggest))
 	r.HandleFunc("GET /v2/perfume/suggest-by-tags", middleware.Auth(handlers.SuggestByTags))
+	r.HandleFunc("GET /v2/perfume/suggest-by-favourites", middleware.Auth(handlers.SuggestByFavourites))
+	r.HandleFunc("GET /v2/perfume/suggest-by-notes", middleware.Auth(handlers.SuggestByNotes))
+	r.HandleFunc("GET /v2/perfume/suggest-by-characteristics", middleware.Auth(handlers.SuggestByCharacteristics))
+	r.HandleFunc("GET /v2/perfume/suggest-by-text", middleware.Auth(handlers.SuggestByText))
+	r.HandleFunc("GET /v2/perfume/{brand}/{name}", middleware.Auth(handlers.Details))
+	r.HandleFunc("GET /v2/perfume/suggest/stream", middleware.Auth(handlers.Streaming(handlers.Suggest)))
+	r.HandleFunc("GET /v2/perfume/ai-suggest/stream", middleware.Auth(handlers.Streaming(handlers.AISuggest)))
+	r.HandleFunc("GET /v2/perfume/suggest-by-tags/stream", middleware.Auth(handlers.Streaming(handlers.SuggestByTags)))
+	r.HandleFunc("GET /v2/perfume/suggest-by-favourites/stream", middleware.Auth(handlers.Streaming(handlers.SuggestByFavourites)))
+	r.HandleFunc("GET /v2/perfume/suggest-by-notes/stream", middleware.Auth(handlers.Streaming(handlers.SuggestByNotes)))
+	r.HandleFunc("GET /v2/perfume/suggest-by-characteristics/stream", middleware.Auth(handlers.Streaming(handlers.SuggestByCharacteristics)))
+
+	grpcServer := rpc.NewServer(config.Manager())
+	defer grpcServer.GracefulStop()
+	go func() {
+		lis, err := net.Listen("tcp", ":9000")
+		if err != nil {
+			log.Fatalf("Error listening for gRPC: %v\n", err)
+		}
+		if err := grpcServer.Serve(lis); err != nil {
+			log.Fatalf("Error starting gRPC server: %v\n", err)
+		}
+	}()
 
 	if err := http.ListenAndServe(":8000", r); err != nil {
 		log.Fatalf("Error starting server: %v\n", err)
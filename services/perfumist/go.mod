@@ -5,10 +5,23 @@ go 1.25.1
 require (
 	github.com/redis/go-redis/v9 v9.17.2
 	github.com/zemld/Scently/models v0.0.0-20260106164549-b2e28db98b99
+	github.com/zemld/Scently/shared/proto/perfume-hub v0.0.0-00010101000000-000000000000
+	github.com/zemld/Scently/shared/proto/perfumist v0.0.0-00010101000000-000000000000
 	github.com/zemld/config-manager v0.0.0-20260105103713-9dd35608f1cd
+	google.golang.org/grpc v1.79.3
+	google.golang.org/protobuf v1.36.11
 )
 
 require (
 	github.com/cespare/xxhash/v2 v2.3.0 // indirect
 	github.com/dgryski/go-rendezvous v0.0.0-20200823014737-9f7001d12a5f // indirect
+	golang.org/x/net v0.48.0 // indirect
+	golang.org/x/sys v0.39.0 // indirect
+	golang.org/x/text v0.32.0 // indirect
+	google.golang.org/genproto/googleapis/rpc v0.0.0-20251202230838-ff82c1b0f217 // indirect
+)
+
+replace (
+	github.com/zemld/Scently/shared/proto/perfume-hub => ../../shared/go/proto/perfume-hub/v1
+	github.com/zemld/Scently/shared/proto/perfumist => ../../shared/go/proto/perfumist/v1
 )
@@ -1,6 +1,10 @@
 package errors
 
-import "fmt"
+import (
+	"fmt"
+
+	"github.com/zemld/Scently/models"
+)
 
 type ValidationError struct {
 	Field   string
@@ -19,7 +23,17 @@ func NewValidationError(field, message string) *ValidationError {
 }
 
 type NotFoundError struct {
-	Message string
+	Message    string
+	Candidates []Candidate
+}
+
+// Candidate is a catalog perfume the user could have meant by a perfume that
+// was not found.
+type Candidate struct {
+	Brand string     `json:"brand"`
+	Name  string     `json:"name"`
+	Sex   models.Sex `json:"sex"`
+	Score float64    `json:"score"`
 }
 
 func (e *NotFoundError) Error() string {
@@ -30,6 +44,10 @@ func NewNotFoundError(message string) *NotFoundError {
 	return &NotFoundError{Message: message}
 }
 
+func NewPerfumeNotFoundError(message string, candidates []Candidate) *NotFoundError {
+	return &NotFoundError{Message: message, Candidates: candidates}
+}
+
 type ServiceError struct {
 	Message string
 	Err     error
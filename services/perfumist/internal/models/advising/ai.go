@@ -2,6 +2,7 @@ package advising
 
 import (
 	"context"
+	"log"
 
 	"github.com/zemld/Scently/models"
 	"github.com/zemld/Scently/perfumist/internal/errors"
@@ -14,6 +15,7 @@ import (
 type AI struct {
 	adviseFetcher fetching.Fetcher
 	enrichFetcher fetching.Fetcher
+	fallback      Advisor
 	cm            cm.ConfigManager
 }
 
@@ -21,14 +23,25 @@ func NewAI(adviseFetcher fetching.Fetcher, enrichFetcher fetching.Fetcher, confi
 	return &AI{adviseFetcher: adviseFetcher, enrichFetcher: enrichFetcher, cm: configManager}
 }
 
+// WithFallback sets the advisor used when the LLM returns nothing, e.g. when
+// all providers are down.
+func (a *AI) WithFallback(fallback Advisor) *AI {
+	a.fallback = fallback
+	return a
+}
+
 func (a *AI) Advise(ctx context.Context, params parameters.RequestPerfume) ([]models.Ranked, error) {
 	adviseResults, err := a.tryFetchRawAdvise(ctx, params)
 	if err != nil {
+		if a.fallback != nil {
+			log.Printf("Falling back from AI advisor: %v\n", err)
+			return a.fallback.Advise(ctx, params)
+		}
 		return nil, err
 	}
 	rankedMap := a.tryFetchEnrichments(ctx, adviseResults, params)
 
-	return a.prepareSuggestionsWithEnrichments(adviseResults, rankedMap), nil
+	return filterRankedOffers(a.prepareSuggestionsWithEnrichments(adviseResults, rankedMap), params.Offers), nil
 }
 
 func (a *AI) tryFetchRawAdvise(ctx context.Context, params parameters.RequestPerfume) ([]models.Perfume, error) {
@@ -50,9 +63,19 @@ func (a *AI) tryFetchEnrichments(ctx context.Context, adviseResults []models.Per
 	}
 	enrichmentChan := a.enrichFetcher.FetchMany(ctx, enrichmentParams)
 
+	ranks := make(map[string]int, len(adviseResults))
+	for i, advise := range adviseResults {
+		ranks[getKey(advise)] = i + 1
+	}
+
 	rankedMap := make(map[string]models.Perfume)
 	for enrichment, ok := <-enrichmentChan; ok; enrichment, ok = <-enrichmentChan {
 		rankedMap[getKey(enrichment)] = enrichment
+		if rank, ok := ranks[getKey(enrichment)]; ok {
+			if ready := filterRankedOffers([]models.Ranked{a.enrich(enrichment, rank)}, params.Offers); len(ready) > 0 {
+				reportProgress(ctx, Update{Event: SuggestionEvent, Suggested: ready})
+			}
+		}
 	}
 	return rankedMap
 }
@@ -61,19 +84,7 @@ func (a *AI) prepareSuggestionsWithEnrichments(adviseResults []models.Perfume, r
 	enrichedResults := make([]models.Ranked, 0, len(adviseResults))
 	for i, advise := range adviseResults {
 		if enriched, ok := rankedMap[getKey(advise)]; ok {
-			matching.PreparePerfumeCharacteristics(&enriched)
-			enriched.Properties.Tags = matching.CalculatePerfumeTags(
-				&enriched.Properties,
-				*matching.NewBaseWeights(
-					a.cm.GetFloatWithDefault("upper_notes_weight", 0.2),
-					a.cm.GetFloatWithDefault("core_notes_weight", 0.35),
-					a.cm.GetFloatWithDefault("base_notes_weight", 0.45),
-				),
-			)
-			enrichedResults = append(enrichedResults, models.Ranked{
-				Perfume: enriched,
-				Rank:    i + 1,
-			})
+			enrichedResults = append(enrichedResults, a.enrich(enriched, i+1))
 		} else {
 			enrichedResults = append(enrichedResults, models.Ranked{
 				Perfume: advise,
@@ -84,6 +95,19 @@ func (a *AI) prepareSuggestionsWithEnrichments(adviseResults []models.Perfume, r
 	return enrichedResults
 }
 
+func (a *AI) enrich(enriched models.Perfume, rank int) models.Ranked {
+	matching.PreparePerfumeCharacteristics(&enriched)
+	enriched.Properties.Tags = matching.CalculatePerfumeTags(
+		&enriched.Properties,
+		*matching.NewBaseWeights(
+			a.cm.GetFloatWithDefault("upper_notes_weight", 0.2),
+			a.cm.GetFloatWithDefault("core_notes_weight", 0.35),
+			a.cm.GetFloatWithDefault("base_notes_weight", 0.45),
+		),
+	)
+	return models.Ranked{Perfume: enriched, Rank: rank}
+}
+
 func getKey(p models.Perfume) string {
 	return p.Brand + p.Name
 }
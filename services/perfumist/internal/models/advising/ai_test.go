@@ -468,3 +468,26 @@ func TestAiAdvisor_Advise_EnrichmentParamsConstruction(t *testing.T) {
 		t.Fatalf("expected %d results, got %d", len(enrichedPerfumes), len(result))
 	}
 }
+
+type stubAdvisor struct {
+	suggested []models.Ranked
+}
+
+func (a *stubAdvisor) Advise(ctx context.Context, params parameters.RequestPerfume) ([]models.Ranked, error) {
+	return a.suggested, nil
+}
+
+func TestAiAdvisor_Advise_FallsBackWhenLLMFails(t *testing.T) {
+	t.Parallel()
+
+	fallback := &stubAdvisor{suggested: []models.Ranked{{Perfume: models.Perfume{Brand: "Acqua di Parma", Name: "Colonia"}, Rank: 1}}}
+	advisor := NewAI(&MockFetcher{}, &MockFetcher{}, &config.MockConfigManager{}).WithFallback(fallback)
+
+	suggested, err := advisor.Advise(context.Background(), *parameters.NewGet().WithBrand("Dior").WithName("Sauvage"))
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if len(suggested) != 1 || suggested[0].Perfume.Name != "Colonia" {
+		t.Fatalf("expected fallback suggestions, got %+v", suggested)
+	}
+}
@@ -13,33 +13,90 @@ import (
 )
 
 type Base struct {
-	fetcher fetching.Fetcher
-	matcher matching.Matcher
-	cm      cm.ConfigManager
+	fetcher   fetching.Fetcher
+	matcher   matching.Matcher
+	cm        cm.ConfigManager
+	describer Describer
+	resolver  *FavouriteResolver
 }
 
 func NewBase(fetcher fetching.Fetcher, matcher matching.Matcher, cm cm.ConfigManager) *Base {
 	return &Base{fetcher: fetcher, matcher: matcher, cm: cm}
 }
 
+// WithDescriber makes AdviseWithExplanations describe every suggestion in
+// words.
+func (a *Base) WithDescriber(describer Describer) *Base {
+	a.describer = describer
+	return a
+}
+
+// WithResolver makes a missing favourite perfume either resolved to a close
+// catalog perfume or reported with the candidates.
+func (a *Base) WithResolver(resolver *FavouriteResolver) *Base {
+	a.resolver = resolver
+	return a
+}
+
 func (a *Base) Advise(ctx context.Context, params parameters.RequestPerfume) ([]models.Ranked, error) {
-	favouritePerfume, err := a.fetchFavouritePerfume(ctx, params)
+	suggested, _, err := a.advise(ctx, params)
+	return suggested, err
+}
+
+func (a *Base) AdviseWithExplanations(ctx context.Context, params parameters.RequestPerfume) ([]Explained, error) {
+	suggested, favouritePerfume, err := a.advise(ctx, params)
 	if err != nil {
 		return nil, err
 	}
+	explained := explain(a.matcher, favouritePerfume.Properties, suggested)
+	if a.describer != nil {
+		describe(ctx, a.describer, a.cm, favouritePerfume, explained)
+	}
+	return explained, nil
+}
+
+func (a *Base) advise(ctx context.Context, params parameters.RequestPerfume) ([]models.Ranked, models.Perfume, error) {
+	favouritePerfume, err := a.fetchFavouritePerfume(ctx, params)
+	if err != nil {
+		return nil, models.Perfume{}, err
+	}
+	if favouritePerfume.Properties.UpperCharacteristics == nil {
+		matching.PreparePerfumeCharacteristics(&favouritePerfume)
+	}
 	log.Printf("favouritePerfume: %+v\n", favouritePerfume)
 	common := NewCommon(a.fetcher, a.matcher, a.cm).WithFavouritePerfume(favouritePerfume)
-	return common.Advise(ctx, params)
+	suggested, err := common.Advise(ctx, params)
+	return suggested, favouritePerfume, err
 }
 
 func (a *Base) fetchFavouritePerfume(ctx context.Context, params parameters.RequestPerfume) (models.Perfume, error) {
+	return fetchPerfume(ctx, a.fetcher, a.resolver, params)
+}
+
+// fetchPerfume fetches a concrete perfume. A missing one is passed to resolver,
+// if there is one.
+func fetchPerfume(ctx context.Context, fetcher fetching.Fetcher, resolver *FavouriteResolver, params parameters.RequestPerfume) (models.Perfume, error) {
+	perfume, err := fetchFirst(ctx, fetcher, params)
+	if err == nil || resolver == nil || ctx.Err() != nil {
+		return perfume, err
+	}
+
+	resolved, err := resolver.Resolve(ctx, params)
+	if err != nil {
+		return models.Perfume{}, err
+	}
+	log.Printf("Perfume %s %s resolved to %s %s\n", params.Brand, params.Name, resolved.Brand, resolved.Name)
+	return fetchFirst(ctx, fetcher, resolved)
+}
+
+func fetchFirst(ctx context.Context, fetcher fetching.Fetcher, params parameters.RequestPerfume) (models.Perfume, error) {
 	select {
 	case <-ctx.Done():
 		return models.Perfume{}, errors.NewServiceError("context cancelled", nil)
-	case favouritePerfume, ok := <-a.fetcher.Fetch(ctx, params):
+	case perfume, ok := <-fetcher.Fetch(ctx, params):
 		if !ok {
 			return models.Perfume{}, errors.NewServiceError("failed to interact with perfume service", nil)
 		}
-		return favouritePerfume, nil
+		return perfume, nil
 	}
 }
@@ -7,6 +7,7 @@ import (
 	"github.com/zemld/Scently/models"
 	"github.com/zemld/Scently/perfumist/internal/config"
 	"github.com/zemld/Scently/perfumist/internal/errors"
+	"github.com/zemld/Scently/perfumist/internal/models/matching"
 	"github.com/zemld/Scently/perfumist/internal/models/parameters"
 )
 
@@ -503,3 +504,156 @@ func TestBase_Advise_RespectsAdviseCount(t *testing.T) {
 		t.Fatalf("expected at most %d results, got %d", expectedCount, len(result))
 	}
 }
+
+type MockCandidatesFetcher struct {
+	MockFetcher
+	FetchCandidatesFunc func(ctx context.Context, param parameters.RequestPerfume, favourite models.Properties, count int) <-chan models.Perfume
+}
+
+func (m *MockCandidatesFetcher) FetchCandidates(ctx context.Context, param parameters.RequestPerfume, favourite models.Properties, count int) <-chan models.Perfume {
+	return m.FetchCandidatesFunc(ctx, param, favourite, count)
+}
+
+func TestBase_Advise_UsesCandidatesShortlist(t *testing.T) {
+	t.Parallel()
+
+	favouritePerfume := models.Perfume{Brand: "Chanel", Name: "No5", Sex: "female"}
+	shortlisted := models.Perfume{Brand: "Dior", Name: "J'adore", Sex: "female"}
+
+	fetcher := &MockCandidatesFetcher{
+		MockFetcher: MockFetcher{
+			FetchFunc: func(ctx context.Context, param parameters.RequestPerfume) <-chan models.Perfume {
+				ch := make(chan models.Perfume, 1)
+				if param.Brand == "Chanel" {
+					ch <- favouritePerfume
+				}
+				close(ch)
+				return ch
+			},
+		},
+	}
+	requestedCount := 0
+	fetcher.FetchCandidatesFunc = func(ctx context.Context, param parameters.RequestPerfume, favourite models.Properties, count int) <-chan models.Perfume {
+		requestedCount = count
+		ch := make(chan models.Perfume, 1)
+		ch <- shortlisted
+		close(ch)
+		return ch
+	}
+
+	mockConfig := &config.MockConfigManager{}
+	result, err := NewBase(fetcher, &MockMatcher{}, mockConfig).Advise(context.Background(), parameters.RequestPerfume{
+		Brand: "Chanel",
+		Name:  "No5",
+		Sex:   "female",
+	})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if requestedCount != 100 {
+		t.Fatalf("expected default candidates count 100, got %d", requestedCount)
+	}
+	if len(result) != 1 || !result[0].Perfume.Equal(shortlisted) {
+		t.Fatalf("expected only shortlisted perfume, got %+v", result)
+	}
+}
+
+func TestBase_Advise_CandidatesShortlistDisabled(t *testing.T) {
+	t.Parallel()
+
+	favouritePerfume := models.Perfume{Brand: "Chanel", Name: "No5", Sex: "female"}
+	fullScan := false
+	fetcher := &MockCandidatesFetcher{
+		MockFetcher: MockFetcher{
+			FetchFunc: func(ctx context.Context, param parameters.RequestPerfume) <-chan models.Perfume {
+				ch := make(chan models.Perfume, 1)
+				if param.Brand == "Chanel" {
+					ch <- favouritePerfume
+				} else {
+					fullScan = true
+				}
+				close(ch)
+				return ch
+			},
+		},
+		FetchCandidatesFunc: func(ctx context.Context, param parameters.RequestPerfume, favourite models.Properties, count int) <-chan models.Perfume {
+			t.Error("shortlist should not be requested when disabled")
+			ch := make(chan models.Perfume)
+			close(ch)
+			return ch
+		},
+	}
+
+	mockConfig := &config.MockConfigManager{
+		GetBoolWithDefaultFunc: func(key string, defaultValue bool) bool {
+			if key == "ann_enabled" {
+				return false
+			}
+			return defaultValue
+		},
+	}
+	if _, err := NewBase(fetcher, &MockMatcher{}, mockConfig).Advise(context.Background(), parameters.RequestPerfume{
+		Brand: "Chanel",
+		Name:  "No5",
+		Sex:   "female",
+	}); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if !fullScan {
+		t.Fatal("expected full catalog scan")
+	}
+}
+
+type filteringMatcher struct {
+	MockMatcher
+}
+
+func (m *filteringMatcher) Accepts(properties models.Properties) bool {
+	return true
+}
+
+func TestBase_Advise_CandidatesShortlistSkippedWhenCandidatesAreDropped(t *testing.T) {
+	t.Parallel()
+
+	for name, tc := range map[string]struct {
+		matcher    matching.Matcher
+		exclusions parameters.Exclusions
+	}{
+		"excluded notes": {&MockMatcher{}, parameters.Exclusions{Notes: []string{"oud"}}},
+		"disliked":       {&MockMatcher{}, parameters.Exclusions{Disliked: []parameters.RequestPerfume{*parameters.NewGet().WithBrand("Dior").WithName("Sauvage")}}},
+		"filter matcher": {&filteringMatcher{}, parameters.Exclusions{}},
+	} {
+		t.Run(name, func(t *testing.T) {
+			favouritePerfume := models.Perfume{Brand: "Chanel", Name: "No5", Sex: "female"}
+			fullScan := false
+			fetcher := &MockCandidatesFetcher{
+				MockFetcher: MockFetcher{
+					FetchFunc: func(ctx context.Context, param parameters.RequestPerfume) <-chan models.Perfume {
+						ch := make(chan models.Perfume, 1)
+						if param.Brand == "Chanel" {
+							ch <- favouritePerfume
+						} else {
+							fullScan = true
+						}
+						close(ch)
+						return ch
+					},
+				},
+				FetchCandidatesFunc: func(ctx context.Context, param parameters.RequestPerfume, favourite models.Properties, count int) <-chan models.Perfume {
+					t.Error("shortlist should not be requested when candidates are dropped")
+					ch := make(chan models.Perfume)
+					close(ch)
+					return ch
+				},
+			}
+
+			params := parameters.NewGet().WithBrand("Chanel").WithName("No5").WithSex(models.Female).WithExclusions(tc.exclusions)
+			if _, err := NewBase(fetcher, tc.matcher, &config.MockConfigManager{}).Advise(context.Background(), *params); err != nil {
+				t.Fatalf("expected no error, got %v", err)
+			}
+			if !fullScan {
+				t.Fatal("expected full catalog scan")
+			}
+		})
+	}
+}
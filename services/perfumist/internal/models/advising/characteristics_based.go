@@ -0,0 +1,34 @@
+package advising
+
+import (
+	"context"
+
+	"github.com/zemld/Scently/models"
+	"github.com/zemld/Scently/perfumist/internal/models/fetching"
+	"github.com/zemld/Scently/perfumist/internal/models/matching"
+	"github.com/zemld/Scently/perfumist/internal/models/parameters"
+	"github.com/zemld/config-manager/pkg/cm"
+)
+
+type CharacteristicsBased struct {
+	matcher *matching.CharacteristicsTarget
+	fetcher fetching.Fetcher
+	cm      cm.ConfigManager
+}
+
+func NewCharacteristicsBased(matcher *matching.CharacteristicsTarget, fetcher fetching.Fetcher, cm cm.ConfigManager) *CharacteristicsBased {
+	return &CharacteristicsBased{matcher: matcher, fetcher: fetcher, cm: cm}
+}
+
+func (a *CharacteristicsBased) Advise(ctx context.Context, params parameters.RequestPerfume) ([]models.Ranked, error) {
+	common := NewCommon(a.fetcher, a.matcher, a.cm)
+	return common.Advise(ctx, params)
+}
+
+func (a *CharacteristicsBased) AdviseWithExplanations(ctx context.Context, params parameters.RequestPerfume) ([]Explained, error) {
+	suggested, err := a.Advise(ctx, params)
+	if err != nil {
+		return nil, err
+	}
+	return explain(a.matcher, models.Properties{}, suggested), nil
+}
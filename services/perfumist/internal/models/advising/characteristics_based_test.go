@@ -0,0 +1,59 @@
+package advising
+
+import (
+	"context"
+	"testing"
+
+	"github.com/zemld/Scently/perfumist/internal/models/matching"
+	"github.com/zemld/Scently/perfumist/internal/models/parameters"
+)
+
+func TestCharacteristicsBased_Advise(t *testing.T) {
+	t.Parallel()
+
+	advisor := NewCharacteristicsBased(
+		matching.NewCharacteristicsTarget(
+			*matching.NewBaseWeights(1, 1, 1),
+			map[matching.NoteLevel]map[string]float64{matching.UpperLevel: {"woodiness": 1}},
+			nil,
+		),
+		multiFetcher(nil),
+		multiConfig(len(multiCatalog)),
+	)
+
+	suggested, err := advisor.Advise(context.Background(), *parameters.NewGet())
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	best := suggested[0]
+	for _, s := range suggested {
+		if s.Score > best.Score {
+			best = s
+		}
+	}
+	if best.Perfume.Name != "Santal 33" {
+		t.Fatalf("expected Santal 33 to score highest, got %+v", best)
+	}
+}
+
+func TestCharacteristicsBased_AdviseWithExplanations(t *testing.T) {
+	t.Parallel()
+
+	advisor := NewCharacteristicsBased(
+		matching.NewCharacteristicsTarget(
+			*matching.NewBaseWeights(1, 1, 1),
+			map[matching.NoteLevel]map[string]float64{matching.UpperLevel: {"freshness": 1}},
+			nil,
+		),
+		multiFetcher(nil),
+		multiConfig(1),
+	)
+
+	explained, err := advisor.AdviseWithExplanations(context.Background(), *parameters.NewGet())
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if len(explained) != 1 || explained[0].Explanation == nil {
+		t.Fatalf("expected one explained suggestion, got %+v", explained)
+	}
+}
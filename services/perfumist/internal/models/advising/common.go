@@ -5,6 +5,7 @@ import (
 	"context"
 	"log"
 	"sync"
+	"sync/atomic"
 
 	"github.com/zemld/Scently/models"
 	"github.com/zemld/Scently/perfumist/internal/models/fetching"
@@ -15,6 +16,10 @@ import (
 
 type Common struct {
 	favouritePerfume models.Perfume
+	excludedPerfumes []models.Perfume
+	preferences      *negativePreferences
+	offers           parameters.OfferFilter
+	diversity        parameters.Diversity
 
 	matcher matching.Matcher
 	fetcher fetching.Fetcher
@@ -23,6 +28,7 @@ type Common struct {
 
 	matchesCount int
 	workersCount int
+	processed    atomic.Int64
 }
 
 func NewCommon(fetcher fetching.Fetcher, matcher matching.Matcher, cm cm.ConfigManager) *Common {
@@ -40,9 +46,18 @@ func (a *Common) WithFavouritePerfume(favouritePerfume models.Perfume) *Common {
 	return a
 }
 
+func (a *Common) WithExcludedPerfumes(excludedPerfumes []models.Perfume) *Common {
+	a.excludedPerfumes = excludedPerfumes
+	return a
+}
+
 func (a *Common) Advise(ctx context.Context, parameter parameters.RequestPerfume) ([]models.Ranked, error) {
 	log.Printf("parameter: %+v\n", parameter)
-	allPerfumesChan := a.fetcher.Fetch(ctx, *parameters.NewGet().WithSex(parameter.Sex))
+	a.preferences = a.resolveNegativePreferences(ctx, parameter.Exclusions)
+	a.offers = parameter.Offers
+	a.diversity = parameter.Diversity
+	a.processed.Store(0)
+	allPerfumesChan := a.fetchPerfumes(ctx, *parameters.NewGet().WithSex(parameter.Sex))
 
 	resultsChan := make(chan *matching.PerfumeHeap)
 
@@ -60,7 +75,10 @@ func (a *Common) Advise(ctx context.Context, parameter parameters.RequestPerfume
 		close(resultsChan)
 	}()
 
-	results := getMatchingResults(mergeHeaps(resultsChan, a.matchesCount), a.matchesCount)
+	results := getMatchingResults(a.mergeHeaps(ctx, resultsChan), a.poolSize())
+	if a.diversity.Enabled {
+		results = rerankByDiversity(results, pairwiseMatcher(a.matcher, a.cm), a.diversity, a.matchesCount)
+	}
 	for i := range results {
 		results[i].Perfume.Properties.Tags = matching.CalculatePerfumeTags(
 			&results[i].Perfume.Properties,
@@ -74,6 +92,32 @@ func (a *Common) Advise(ctx context.Context, parameter parameters.RequestPerfume
 	return results, nil
 }
 
+func (a *Common) fetchPerfumes(ctx context.Context, parameter parameters.RequestPerfume) <-chan models.Perfume {
+	candidatesFetcher, ok := a.fetcher.(fetching.CandidatesFetcher)
+	if !ok || !a.canShortlist() {
+		return a.fetcher.Fetch(ctx, parameter)
+	}
+	return candidatesFetcher.FetchCandidates(
+		ctx,
+		parameter,
+		a.favouritePerfume.Properties,
+		a.cm.GetIntWithDefault("ann_candidates_count", 100),
+	)
+}
+
+// canShortlist tells whether the index shortlist can be ranked instead of the
+// whole catalog. Offers, exclusions, dislikes and filtering matchers drop
+// candidates after the shortlist, which could leave fewer than suggest_count.
+func (a *Common) canShortlist() bool {
+	if _, filters := a.matcher.(matching.Filter); filters {
+		return false
+	}
+	return a.favouritePerfume.Brand != "" &&
+		a.offers.IsEmpty() &&
+		a.preferences == nil &&
+		a.cm.GetBoolWithDefault("ann_enabled", true)
+}
+
 func (a *Common) initWaitGroup() *sync.WaitGroup {
 	wg := sync.WaitGroup{}
 	wg.Add(a.workersCount)
@@ -98,13 +142,22 @@ func (a *Common) runAllAdvisingWorkers(
 	}
 }
 
+// runAdvisingWorker sends its heap when the jobs are over. When progress is
+// reported, it also flushes the heap every stream_batch_size perfumes, so that
+// the merged top can be shown before the catalog is drained.
 func (a *Common) runAdvisingWorker(
 	ctx context.Context,
 	jobs <-chan models.Perfume,
 	results chan<- *matching.PerfumeHeap,
 ) {
-	h := matching.NewPerfumeHeap(a.matchesCount)
+	batchSize := 0
+	if _, ok := progressFromContext(ctx); ok {
+		batchSize = a.cm.GetIntWithDefault("stream_batch_size", 200)
+	}
+
+	h := matching.NewPerfumeHeap(a.poolSize())
 	heap.Init(h)
+	processed := 0
 
 	for {
 		select {
@@ -117,17 +170,32 @@ func (a *Common) runAdvisingWorker(
 				return
 			}
 			a.processPerfume(ctx, perfume, h)
+			a.processed.Add(1)
+			if processed++; batchSize > 0 && processed%batchSize == 0 {
+				results <- h
+				h = matching.NewPerfumeHeap(a.poolSize())
+				heap.Init(h)
+			}
 		}
 	}
 }
 
 func (a *Common) processPerfume(ctx context.Context, perfume models.Perfume, h *matching.PerfumeHeap) {
-	if a.favouritePerfume.Equal(perfume) {
+	if a.isExcluded(perfume) || a.preferences.rejects(perfume) {
 		return
 	}
-	matching.PreparePerfumeCharacteristics(&perfume)
-	similarityScore := a.matcher.GetSimilarityScore(
-		a.favouritePerfume.Properties,
+	if filter, ok := a.matcher.(matching.Filter); ok && !filter.Accepts(perfume.Properties) {
+		return
+	}
+	perfume, ok := filterOffers(perfume, a.offers)
+	if !ok {
+		return
+	}
+	if perfume.Properties.UpperCharacteristics == nil {
+		matching.PreparePerfumeCharacteristics(&perfume)
+	}
+	similarityScore := a.preferences.penalize(
+		a.matcher.GetSimilarityScore(a.favouritePerfume.Properties, perfume.Properties),
 		perfume.Properties,
 	)
 
@@ -137,13 +205,42 @@ func (a *Common) processPerfume(ctx context.Context, perfume models.Perfume, h *
 	})
 }
 
-func mergeHeaps(heaps <-chan *matching.PerfumeHeap, matchesCount int) *matching.PerfumeHeap {
-	suggestionsHeap := matching.NewPerfumeHeap(matchesCount)
+func (a *Common) poolSize() int {
+	if !a.diversity.Enabled {
+		return a.matchesCount
+	}
+	return a.matchesCount * a.cm.GetIntWithDefault("diversity_pool_factor", 10)
+}
+
+func (a *Common) isExcluded(perfume models.Perfume) bool {
+	if a.favouritePerfume.Equal(perfume) {
+		return true
+	}
+	for _, excluded := range a.excludedPerfumes {
+		if excluded.Equal(perfume) {
+			return true
+		}
+	}
+	return false
+}
+
+func (a *Common) mergeHeaps(ctx context.Context, heaps <-chan *matching.PerfumeHeap) *matching.PerfumeHeap {
+	suggestionsHeap := matching.NewPerfumeHeap(a.poolSize())
 	heap.Init(suggestionsHeap)
+	var reported []models.Ranked
 	for h := range heaps {
 		for _, p := range h.GetPerfumes() {
 			suggestionsHeap.Push(p)
 		}
+
+		if _, ok := progressFromContext(ctx); !ok {
+			continue
+		}
+		reportProgress(ctx, Update{Event: ProgressEvent, Processed: int(a.processed.Load())})
+		if top := provisionalTop(suggestionsHeap, a.matchesCount); !sameRanking(top, reported) {
+			reported = top
+			reportProgress(ctx, Update{Event: PartialEvent, Suggested: top})
+		}
 	}
 	return suggestionsHeap
 }
@@ -0,0 +1,51 @@
+package advising
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/zemld/Scently/models"
+	"github.com/zemld/Scently/perfumist/internal/models/matching"
+	"github.com/zemld/config-manager/pkg/cm"
+)
+
+type Describer interface {
+	Describe(ctx context.Context, favourite models.Perfume, suggestion models.Perfume, overlap matching.Overlap) (string, error)
+}
+
+type description struct {
+	index int
+	text  string
+}
+
+// describe asks for descriptions of all suggestions at once and waits for them
+// no longer than describe_timeout. Suggestions whose description failed or
+// did not arrive in time are left without one.
+func describe(ctx context.Context, describer Describer, cm cm.ConfigManager, favourite models.Perfume, explained []Explained) {
+	ctx, cancel := context.WithTimeout(ctx, cm.GetDurationWithDefault("describe_timeout", 3*time.Second))
+	defer cancel()
+
+	threshold := cm.GetFloatWithDefault("describe_characteristic_threshold", 0.5)
+	descriptions := make(chan description, len(explained))
+	for i, suggestion := range explained {
+		go func() {
+			overlap := matching.FindOverlap(favourite.Properties, suggestion.Perfume.Properties, threshold)
+			text, err := describer.Describe(ctx, favourite, suggestion.Perfume, overlap)
+			if err != nil {
+				log.Printf("Cannot describe %s %s: %v\n", suggestion.Perfume.Brand, suggestion.Perfume.Name, err)
+			}
+			descriptions <- description{index: i, text: text}
+		}()
+	}
+
+	for range explained {
+		select {
+		case <-ctx.Done():
+			log.Printf("Descriptions are not ready in time: %v\n", ctx.Err())
+			return
+		case description := <-descriptions:
+			explained[description.index].Description = description.text
+		}
+	}
+}
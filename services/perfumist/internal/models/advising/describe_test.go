@@ -0,0 +1,87 @@
+package advising
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/zemld/Scently/models"
+	"github.com/zemld/Scently/perfumist/internal/config"
+	"github.com/zemld/Scently/perfumist/internal/models/matching"
+)
+
+type stubDescriber struct {
+	describe func(ctx context.Context, suggestion models.Perfume, overlap matching.Overlap) (string, error)
+}
+
+func (d *stubDescriber) Describe(ctx context.Context, favourite models.Perfume, suggestion models.Perfume, overlap matching.Overlap) (string, error) {
+	return d.describe(ctx, suggestion, overlap)
+}
+
+func describeConfig(timeout time.Duration) *config.MockConfigManager {
+	return &config.MockConfigManager{
+		GetDurationWithDefaultFunc: func(key string, defaultValue time.Duration) time.Duration {
+			if key == "describe_timeout" {
+				return timeout
+			}
+			return defaultValue
+		},
+	}
+}
+
+func TestDescribe_SkipsFailedAndLateDescriptions(t *testing.T) {
+	t.Parallel()
+
+	describer := &stubDescriber{describe: func(ctx context.Context, suggestion models.Perfume, overlap matching.Overlap) (string, error) {
+		switch suggestion.Brand {
+		case "Dior":
+			return "Both are fresh.", nil
+		case "Chanel":
+			return "", errors.New("provider is down")
+		default:
+			<-ctx.Done()
+			return "", ctx.Err()
+		}
+	}}
+	explained := []Explained{
+		{Ranked: models.Ranked{Perfume: models.Perfume{Brand: "Dior"}}},
+		{Ranked: models.Ranked{Perfume: models.Perfume{Brand: "Chanel"}}},
+		{Ranked: models.Ranked{Perfume: models.Perfume{Brand: "Kilian"}}},
+	}
+
+	started := time.Now()
+	describe(context.Background(), describer, describeConfig(50*time.Millisecond), models.Perfume{}, explained)
+	if elapsed := time.Since(started); elapsed > time.Second {
+		t.Fatalf("expected descriptions to be bounded by the timeout, took %v", elapsed)
+	}
+	if explained[0].Description != "Both are fresh." {
+		t.Fatalf("expected description for Dior, got %q", explained[0].Description)
+	}
+	if explained[1].Description != "" || explained[2].Description != "" {
+		t.Fatalf("expected no descriptions for failed and late suggestions, got %+v", explained)
+	}
+}
+
+func TestBase_AdviseWithExplanations_Describes(t *testing.T) {
+	t.Parallel()
+
+	var overlaps []matching.Overlap
+	describer := &stubDescriber{describe: func(ctx context.Context, suggestion models.Perfume, overlap matching.Overlap) (string, error) {
+		overlaps = append(overlaps, overlap)
+		return "Similar freshness.", nil
+	}}
+	advisor := NewBase(multiFetcher(nil), matching.NewCharacteristicsMatcher(*matching.NewBaseWeights(1, 0, 0)), multiConfig(1)).
+		WithDescriber(describer)
+
+	explained, err := advisor.AdviseWithExplanations(context.Background(), multiFavourites()[0])
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if len(explained) != 1 || explained[0].Description != "Similar freshness." {
+		t.Fatalf("expected described suggestion, got %+v", explained)
+	}
+	if len(overlaps) != 1 {
+		t.Fatalf("expected one description request, got %d", len(overlaps))
+	}
+}
@@ -0,0 +1,71 @@
+package advising
+
+import (
+	"context"
+	"math"
+	"sort"
+
+	"github.com/zemld/Scently/models"
+	"github.com/zemld/Scently/perfumist/internal/models/fetching"
+	"github.com/zemld/Scently/perfumist/internal/models/matching"
+	"github.com/zemld/Scently/perfumist/internal/models/parameters"
+	"github.com/zemld/config-manager/pkg/cm"
+)
+
+// Details is the full card of a perfume: the perfume with computed tags and
+// characteristics and all its shop variants from the cheapest per ml.
+type Details struct {
+	Perfume models.Perfume `json:"perfume"`
+	Offers  []Offer        `json:"offers"`
+}
+
+type Offer struct {
+	ShopName   string  `json:"shop_name"`
+	Domain     string  `json:"domain"`
+	Volume     int     `json:"volume"`
+	Price      int     `json:"price"`
+	PricePerMl float64 `json:"price_per_ml,omitempty"`
+	Link       string  `json:"link"`
+}
+
+// FetchDetails fetches the perfume like a favourite one, so a missing perfume
+// is resolved or reported with candidates by resolver.
+func FetchDetails(ctx context.Context, fetcher fetching.Fetcher, resolver *FavouriteResolver, cm cm.ConfigManager, params parameters.RequestPerfume) (Details, error) {
+	perfume, err := fetchPerfume(ctx, fetcher, resolver, params)
+	if err != nil {
+		return Details{}, err
+	}
+
+	matching.PreparePerfumeCharacteristics(&perfume)
+	perfume.Properties.Tags = matching.CalculatePerfumeTags(
+		&perfume.Properties,
+		*matching.NewBaseWeights(
+			cm.GetFloatWithDefault("upper_notes_weight", 0.2),
+			cm.GetFloatWithDefault("core_notes_weight", 0.35),
+			cm.GetFloatWithDefault("base_notes_weight", 0.45),
+		),
+	)
+	return Details{Perfume: perfume, Offers: sortedOffers(perfume.Shops)}, nil
+}
+
+// sortedOffers puts variants without a volume last, as their price per ml is
+// unknown.
+func sortedOffers(shops []models.ShopInfo) []Offer {
+	offers := make([]Offer, 0)
+	for _, shop := range shops {
+		for _, variant := range shop.Variants {
+			offer := Offer{ShopName: shop.ShopName, Domain: shop.Domain, Volume: variant.Volume, Price: variant.Price, Link: variant.Link}
+			if variant.Volume > 0 {
+				offer.PricePerMl = math.Round(float64(variant.Price)/float64(variant.Volume)*100) / 100
+			}
+			offers = append(offers, offer)
+		}
+	}
+	sort.SliceStable(offers, func(i, j int) bool {
+		if (offers[i].Volume > 0) != (offers[j].Volume > 0) {
+			return offers[i].Volume > 0
+		}
+		return offers[i].PricePerMl < offers[j].PricePerMl
+	})
+	return offers
+}
@@ -0,0 +1,73 @@
+package advising
+
+import (
+	"context"
+	"testing"
+
+	"github.com/zemld/Scently/models"
+	"github.com/zemld/Scently/perfumist/internal/config"
+	"github.com/zemld/Scently/perfumist/internal/errors"
+	"github.com/zemld/Scently/perfumist/internal/models/parameters"
+)
+
+func TestFetchDetails(t *testing.T) {
+	t.Parallel()
+
+	perfume := models.Perfume{
+		Brand: "Dior",
+		Name:  "Sauvage",
+		Sex:   models.Male,
+		Properties: models.Properties{
+			EnrichedUpperNotes: []models.EnrichedNote{{Name: "bergamot", Tags: []string{"fresh"}, Characteristics: []models.NoteCharacteristic{{Name: "freshness", Value: 0.8}}}},
+			EnrichedBaseNotes: []models.EnrichedNote{
+				{Name: "ambroxan", Tags: []string{"woody"}, Characteristics: []models.NoteCharacteristic{{Name: "warmth", Value: 0.6}}},
+				{Name: "cedar", Tags: []string{"woody"}, Characteristics: []models.NoteCharacteristic{{Name: "warmth", Value: 0.6}}},
+				{Name: "vetiver", Tags: []string{"woody"}, Characteristics: []models.NoteCharacteristic{{Name: "warmth", Value: 0.6}}},
+			},
+		},
+		Shops: []models.ShopInfo{
+			{ShopName: "Gold Apple", Domain: "goldapple.ru", Variants: []models.Variant{{Volume: 100, Price: 12000}, {Volume: 0, Price: 500}}},
+			{ShopName: "Letu", Domain: "letu.ru", Variants: []models.Variant{{Volume: 60, Price: 6000}}},
+		},
+	}
+	fetcher := &MockFetcher{
+		FetchFunc: func(ctx context.Context, param parameters.RequestPerfume) <-chan models.Perfume {
+			ch := make(chan models.Perfume, 1)
+			ch <- perfume
+			close(ch)
+			return ch
+		},
+	}
+
+	details, err := FetchDetails(context.Background(), fetcher, nil, &config.MockConfigManager{}, parameters.RequestPerfume{Brand: "Dior", Name: "Sauvage", Sex: models.Male})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if details.Perfume.Properties.UpperCharacteristics["freshness"] != 0.8 || details.Perfume.Properties.BaseCharacteristics["warmth"] != 0.6 {
+		t.Fatalf("expected characteristic profiles, got %+v", details.Perfume.Properties)
+	}
+	if details.Perfume.Properties.Tags["woody"] == 0 {
+		t.Fatalf("expected computed tags, got %v", details.Perfume.Properties.Tags)
+	}
+
+	expected := []float64{100, 120, 0}
+	if len(details.Offers) != len(expected) {
+		t.Fatalf("expected %d offers, got %+v", len(expected), details.Offers)
+	}
+	for i, pricePerMl := range expected {
+		if details.Offers[i].PricePerMl != pricePerMl {
+			t.Fatalf("expected offers sorted by price per ml with unknown volume last, got %+v", details.Offers)
+		}
+	}
+}
+
+func TestFetchDetails_NotFound(t *testing.T) {
+	t.Parallel()
+
+	resolver := NewFavouriteResolver(&MockSearcher{Results: sauvageResults}, &config.MockConfigManager{})
+	_, err := FetchDetails(context.Background(), &MockFetcher{}, resolver, &config.MockConfigManager{}, parameters.RequestPerfume{Brand: "Dior", Name: "Sauvge", Sex: models.Male})
+
+	if notFound, ok := err.(*errors.NotFoundError); !ok || len(notFound.Candidates) == 0 {
+		t.Fatalf("expected NotFoundError with candidates, got %v", err)
+	}
+}
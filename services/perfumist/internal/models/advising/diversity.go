@@ -0,0 +1,65 @@
+package advising
+
+import (
+	"math"
+	"strings"
+
+	"github.com/zemld/Scently/models"
+	"github.com/zemld/Scently/perfumist/internal/models/matching"
+	"github.com/zemld/Scently/perfumist/internal/models/parameters"
+	"github.com/zemld/config-manager/pkg/cm"
+)
+
+// rerankByDiversity picks count perfumes from the candidates with Maximal
+// Marginal Relevance: lambda*score - (1-lambda)*max similarity to the already
+// picked ones. Perfumes of a brand that reached the cap are skipped.
+func rerankByDiversity(candidates []models.Ranked, matcher matching.Matcher, diversity parameters.Diversity, count int) []models.Ranked {
+	selected := make([]models.Ranked, 0, count)
+	brandCounts := make(map[string]int)
+	used := make([]bool, len(candidates))
+	for len(selected) < count {
+		best, bestScore := -1, math.Inf(-1)
+		for i, candidate := range candidates {
+			if used[i] || (diversity.BrandCap > 0 && brandCounts[brandKey(candidate.Perfume)] >= diversity.BrandCap) {
+				continue
+			}
+			redundancy := 0.0
+			for _, picked := range selected {
+				redundancy = max(redundancy, matcher.GetSimilarityScore(picked.Perfume.Properties, candidate.Perfume.Properties))
+			}
+			score := diversity.Lambda*candidate.Score - (1-diversity.Lambda)*redundancy
+			if score > bestScore {
+				best, bestScore = i, score
+			}
+		}
+		if best == -1 {
+			break
+		}
+		used[best] = true
+		brandCounts[brandKey(candidates[best].Perfume)]++
+		selected = append(selected, candidates[best])
+	}
+
+	for i := range selected {
+		selected[i].Rank = i + 1
+	}
+	return selected
+}
+
+// pairwiseMatcher returns a matcher that compares two perfumes with each other.
+// Target-based matchers ignore the first perfume, so they fall back to the
+// combined matcher.
+func pairwiseMatcher(matcher matching.Matcher, cm cm.ConfigManager) matching.Matcher {
+	switch m := matcher.(type) {
+	case *matching.MaxSimilarity:
+		return m.Matcher
+	case *matching.TagsBasedAdapter, *matching.CharacteristicsTarget:
+		return matching.NewCombinedMatcher(configWeights(cm))
+	default:
+		return matcher
+	}
+}
+
+func brandKey(perfume models.Perfume) string {
+	return strings.ToLower(strings.TrimSpace(perfume.Brand))
+}
@@ -0,0 +1,136 @@
+package advising
+
+import (
+	"context"
+	"testing"
+
+	"github.com/zemld/Scently/models"
+	"github.com/zemld/Scently/perfumist/internal/models/matching"
+	"github.com/zemld/Scently/perfumist/internal/models/parameters"
+)
+
+func flankers() []models.Ranked {
+	sweet := map[string]float64{"sweetness": 1}
+	fresh := map[string]float64{"freshness": 1}
+	return []models.Ranked{
+		{Perfume: models.Perfume{Brand: "Dior", Name: "Sauvage", Properties: models.Properties{UpperCharacteristics: sweet}}, Score: 0.95},
+		{Perfume: models.Perfume{Brand: "Dior", Name: "Sauvage Elixir", Properties: models.Properties{UpperCharacteristics: sweet}}, Score: 0.94},
+		{Perfume: models.Perfume{Brand: "DIOR", Name: "Sauvage Parfum", Properties: models.Properties{UpperCharacteristics: sweet}}, Score: 0.93},
+		{Perfume: models.Perfume{Brand: "Chanel", Name: "Bleu", Properties: models.Properties{UpperCharacteristics: fresh}}, Score: 0.8},
+	}
+}
+
+func names(ranked []models.Ranked) []string {
+	result := make([]string, len(ranked))
+	for i, r := range ranked {
+		result[i] = r.Perfume.Name
+	}
+	return result
+}
+
+func TestRerankByDiversity_PureRelevanceKeepsOrder(t *testing.T) {
+	t.Parallel()
+
+	reranked := rerankByDiversity(
+		flankers(),
+		matching.NewCharacteristicsMatcher(*matching.NewBaseWeights(1, 0, 0)),
+		parameters.Diversity{Enabled: true, Lambda: 1},
+		3,
+	)
+	got := names(reranked)
+	if len(got) != 3 || got[0] != "Sauvage" || got[1] != "Sauvage Elixir" || got[2] != "Sauvage Parfum" {
+		t.Fatalf("expected relevance order, got %v", got)
+	}
+	for i, r := range reranked {
+		if r.Rank != i+1 {
+			t.Fatalf("expected rank %d, got %d", i+1, r.Rank)
+		}
+	}
+}
+
+func TestRerankByDiversity_PromotesDifferentPerfumes(t *testing.T) {
+	t.Parallel()
+
+	reranked := rerankByDiversity(
+		flankers(),
+		matching.NewCharacteristicsMatcher(*matching.NewBaseWeights(1, 0, 0)),
+		parameters.Diversity{Enabled: true, Lambda: 0.5},
+		2,
+	)
+	got := names(reranked)
+	if len(got) != 2 || got[0] != "Sauvage" || got[1] != "Bleu" {
+		t.Fatalf("expected the fresh perfume to be promoted, got %v", got)
+	}
+}
+
+func TestRerankByDiversity_BrandCap(t *testing.T) {
+	t.Parallel()
+
+	reranked := rerankByDiversity(
+		flankers(),
+		matching.NewCharacteristicsMatcher(*matching.NewBaseWeights(1, 0, 0)),
+		parameters.Diversity{Enabled: true, Lambda: 1, BrandCap: 1},
+		3,
+	)
+	got := names(reranked)
+	if len(got) != 2 || got[0] != "Sauvage" || got[1] != "Bleu" {
+		t.Fatalf("expected one perfume per brand, got %v", got)
+	}
+}
+
+func TestPairwiseMatcher(t *testing.T) {
+	t.Parallel()
+
+	combined := matching.NewCombinedMatcher(*matching.NewWeights(0.4, 0.55, 0.05, 0.2, 0.35, 0.45, 0.3, 0.5, 0.2))
+	if pairwiseMatcher(combined, multiConfig(4)) != matching.Matcher(combined) {
+		t.Fatal("expected pairwise matcher to be used as is")
+	}
+	if pairwiseMatcher(matching.NewMaxSimilarity(combined, nil), multiConfig(4)) != matching.Matcher(combined) {
+		t.Fatal("expected max similarity to unwrap its matcher")
+	}
+	if _, ok := pairwiseMatcher(matching.NewTagsBasedAdapter(matching.Weights{}, []string{"fresh"}), multiConfig(4)).(*matching.CombinedMatcher); !ok {
+		t.Fatal("expected tags adapter to fall back to combined matcher")
+	}
+}
+
+func TestCommon_Advise_Diversifies(t *testing.T) {
+	t.Parallel()
+
+	catalog := make([]models.Perfume, 0)
+	for _, ranked := range flankers() {
+		catalog = append(catalog, ranked.Perfume)
+	}
+	fetcher := &MockFetcher{
+		FetchFunc: func(ctx context.Context, param parameters.RequestPerfume) <-chan models.Perfume {
+			ch := make(chan models.Perfume, len(catalog))
+			for _, p := range catalog {
+				ch <- p
+			}
+			close(ch)
+			return ch
+		},
+	}
+	favourite := models.Perfume{Properties: models.Properties{UpperCharacteristics: map[string]float64{"sweetness": 1, "freshness": 0.2}}}
+	matcher := matching.NewCharacteristicsMatcher(*matching.NewBaseWeights(1, 0, 0))
+
+	plain, err := NewCommon(fetcher, matcher, multiConfig(2)).WithFavouritePerfume(favourite).
+		Advise(context.Background(), *parameters.NewGet())
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	for _, r := range plain {
+		if r.Perfume.Brand == "Chanel" {
+			t.Fatalf("expected only Dior flankers without diversity, got %v", names(plain))
+		}
+	}
+
+	diverse, err := NewCommon(fetcher, matcher, multiConfig(2)).WithFavouritePerfume(favourite).
+		Advise(context.Background(), *parameters.NewGet().WithDiversity(parameters.Diversity{Enabled: true, Lambda: 1, BrandCap: 1}))
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	got := names(diverse)
+	if len(got) != 2 || got[0] != "Sauvage" || got[1] != "Bleu" {
+		t.Fatalf("expected one perfume per brand, got %v", got)
+	}
+}
@@ -0,0 +1,37 @@
+package advising
+
+import (
+	"context"
+	"math"
+
+	"github.com/zemld/Scently/models"
+	"github.com/zemld/Scently/perfumist/internal/models/matching"
+	"github.com/zemld/Scently/perfumist/internal/models/parameters"
+)
+
+type Explained struct {
+	models.Ranked
+	Explanation *matching.Explanation `json:"explanation,omitempty"`
+	Description string                `json:"description,omitempty"`
+}
+
+type ExplainingAdvisor interface {
+	AdviseWithExplanations(ctx context.Context, params parameters.RequestPerfume) ([]Explained, error)
+}
+
+func explain(matcher matching.Matcher, favourite models.Properties, suggested []models.Ranked) []Explained {
+	explainingMatcher, ok := matcher.(matching.ExplainingMatcher)
+	explained := make([]Explained, len(suggested))
+	for i, ranked := range suggested {
+		explained[i] = Explained{Ranked: ranked}
+		if ok {
+			explanation := explainingMatcher.Explain(favourite, ranked.Perfume.Properties)
+			if penalty := ranked.Score - explanation.Score; math.Abs(penalty) > 1e-9 {
+				explanation.Components = append(explanation.Components, matching.NewComponent(matching.DislikeComponent, penalty, 1))
+				explanation.Score = ranked.Score
+			}
+			explained[i].Explanation = &explanation
+		}
+	}
+	return explained
+}
@@ -0,0 +1,124 @@
+package advising
+
+import (
+	"context"
+	"testing"
+
+	"github.com/zemld/Scently/models"
+	"github.com/zemld/Scently/perfumist/internal/config"
+	"github.com/zemld/Scently/perfumist/internal/models/matching"
+	"github.com/zemld/Scently/perfumist/internal/models/parameters"
+)
+
+func TestExplain_NonExplainingMatcher(t *testing.T) {
+	t.Parallel()
+
+	suggested := []models.Ranked{{Perfume: models.Perfume{Brand: "Dior"}, Score: 0.5, Rank: 1}}
+	explained := explain(&MockMatcher{}, models.Properties{}, suggested)
+
+	if len(explained) != 1 {
+		t.Fatalf("expected 1 explained suggestion, got %d", len(explained))
+	}
+	if explained[0].Explanation != nil {
+		t.Fatal("expected no explanation for non-explaining matcher")
+	}
+	if explained[0].Score != 0.5 || explained[0].Rank != 1 {
+		t.Fatalf("expected ranked data to be kept, got %+v", explained[0].Ranked)
+	}
+}
+
+func TestBase_AdviseWithExplanations(t *testing.T) {
+	t.Parallel()
+
+	favourite := models.Perfume{
+		Brand: "Chanel",
+		Name:  "No5",
+		Sex:   "female",
+		Properties: models.Properties{
+			Family:     []string{"Floral"},
+			UpperNotes: []string{"Rose"},
+			EnrichedUpperNotes: []models.EnrichedNote{
+				{Name: "Rose", Tags: []string{"floral"}, Characteristics: []models.NoteCharacteristic{{Name: "floralcy", Value: 1}}},
+			},
+		},
+	}
+	candidate := models.Perfume{
+		Brand:      "Dior",
+		Name:       "J'adore",
+		Sex:        "female",
+		Properties: favourite.Properties,
+	}
+	fetcher := &MockFetcher{
+		FetchFunc: func(ctx context.Context, param parameters.RequestPerfume) <-chan models.Perfume {
+			ch := make(chan models.Perfume, 1)
+			if param.Brand == "Chanel" {
+				ch <- favourite
+			} else {
+				ch <- candidate
+			}
+			close(ch)
+			return ch
+		},
+	}
+	matcher := matching.NewCombinedMatcher(*matching.NewWeights(0.4, 0.55, 0.05, 0.2, 0.35, 0.45, 0.3, 0.5, 0.2))
+
+	explained, err := NewBase(fetcher, matcher, &config.MockConfigManager{}).AdviseWithExplanations(
+		context.Background(),
+		parameters.RequestPerfume{Brand: "Chanel", Name: "No5", Sex: "female"},
+	)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if len(explained) != 1 {
+		t.Fatalf("expected 1 suggestion, got %d", len(explained))
+	}
+	explanation := explained[0].Explanation
+	if explanation == nil {
+		t.Fatal("expected explanation")
+	}
+	if explanation.Score != explained[0].Score {
+		t.Fatalf("expected explanation score %f to match similarity %f", explanation.Score, explained[0].Score)
+	}
+	if explanation.Components[0].Levels[0].Score != 1 {
+		t.Fatalf("expected identical upper characteristics, got %+v", explanation.Components[0].Levels[0])
+	}
+	if len(explanation.SharedFamilies) != 1 || explanation.SharedFamilies[0] != "Floral" {
+		t.Fatalf("expected shared family Floral, got %v", explanation.SharedFamilies)
+	}
+}
+
+func TestTagsBased_AdviseWithExplanations(t *testing.T) {
+	t.Parallel()
+
+	candidate := models.Perfume{
+		Brand: "Dior",
+		Name:  "J'adore",
+		Sex:   "female",
+		Properties: models.Properties{
+			EnrichedUpperNotes: []models.EnrichedNote{{Name: "Rose", Tags: []string{"floral"}}},
+		},
+	}
+	fetcher := &MockFetcher{
+		FetchFunc: func(ctx context.Context, param parameters.RequestPerfume) <-chan models.Perfume {
+			ch := make(chan models.Perfume, 1)
+			ch <- candidate
+			close(ch)
+			return ch
+		},
+	}
+	matcher := matching.NewTagsBasedAdapter(*matching.NewBaseWeights(1, 1, 1), []string{"floral"})
+
+	explained, err := NewTagsBased(matcher, fetcher, &config.MockConfigManager{}).AdviseWithExplanations(
+		context.Background(),
+		*parameters.NewGet().WithSex("female"),
+	)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if len(explained) != 1 || explained[0].Explanation == nil {
+		t.Fatalf("expected 1 explained suggestion, got %+v", explained)
+	}
+	if explained[0].Explanation.OverlappingTags["floral"] != 1 {
+		t.Fatalf("expected floral overlap, got %v", explained[0].Explanation.OverlappingTags)
+	}
+}
@@ -0,0 +1,115 @@
+package advising
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/zemld/Scently/perfumist/internal/errors"
+	"github.com/zemld/Scently/perfumist/internal/models/fetching"
+	"github.com/zemld/Scently/perfumist/internal/models/parameters"
+	"github.com/zemld/config-manager/pkg/cm"
+)
+
+// FavouriteResolver looks for the perfumes the user could have meant when the
+// favourite perfume is not found, so that a typo is told apart from an outage.
+type FavouriteResolver struct {
+	searcher    fetching.PerfumeSearcher
+	cm          cm.ConfigManager
+	autoResolve bool
+}
+
+func NewFavouriteResolver(searcher fetching.PerfumeSearcher, cm cm.ConfigManager) *FavouriteResolver {
+	return &FavouriteResolver{searcher: searcher, cm: cm}
+}
+
+// WithAutoResolve makes Resolve pick the best candidate instead of returning
+// the candidates, if the best one is unambiguous.
+func (r *FavouriteResolver) WithAutoResolve(autoResolve bool) *FavouriteResolver {
+	r.autoResolve = autoResolve
+	return r
+}
+
+// Resolve returns params of the perfume to use instead of the missing one or a
+// NotFoundError listing the closest candidates.
+func (r *FavouriteResolver) Resolve(ctx context.Context, params parameters.RequestPerfume) (parameters.RequestPerfume, error) {
+	count := r.cm.GetIntWithDefault("did_you_mean_count", 5)
+	// The hub ranks by trigrams only, so a wider pool is reranked here.
+	found, err := r.searcher.Search(ctx, params.Brand+" "+params.Name, params.Sex, 3*count)
+	if err != nil {
+		return parameters.RequestPerfume{}, err
+	}
+
+	candidates := rankCandidates(params, found)
+	candidates = candidates[:min(count, len(candidates))]
+	if best, ok := r.unambiguous(candidates); ok && r.autoResolve {
+		resolved := params
+		resolved.Brand, resolved.Name = best.Brand, best.Name
+		return resolved, nil
+	}
+	return parameters.RequestPerfume{}, errors.NewPerfumeNotFoundError(fmt.Sprintf("perfume %s %s not found", params.Brand, params.Name), candidates)
+}
+
+func (r *FavouriteResolver) unambiguous(candidates []errors.Candidate) (errors.Candidate, bool) {
+	if len(candidates) == 0 || candidates[0].Score < r.cm.GetFloatWithDefault("auto_resolve_min_score", 0.8) {
+		return errors.Candidate{}, false
+	}
+	if len(candidates) > 1 && candidates[0].Score-candidates[1].Score < r.cm.GetFloatWithDefault("auto_resolve_margin", 0.1) {
+		return errors.Candidate{}, false
+	}
+	return candidates[0], true
+}
+
+func rankCandidates(params parameters.RequestPerfume, found []fetching.SearchResult) []errors.Candidate {
+	candidates := make([]errors.Candidate, 0, len(found))
+	for _, result := range found {
+		candidates = append(candidates, errors.Candidate{
+			Brand: result.Brand,
+			Name:  result.Name,
+			Sex:   result.Sex,
+			Score: candidateScore(params.Brand, params.Name, result.Brand, result.Name),
+		})
+	}
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return candidates[i].Score > candidates[j].Score
+	})
+	return candidates
+}
+
+// candidateScore averages the edit similarity of canonized brand and name with
+// the share of common words: the first tolerates typos, the second missing or
+// reordered words.
+func candidateScore(brand string, name string, candidateBrand string, candidateName string) float64 {
+	requested, candidate := canonizedName(brand, name), canonizedName(candidateBrand, candidateName)
+	first, second := requested.Brand+requested.Name, candidate.Brand+candidate.Name
+	length := max(len([]rune(first)), len([]rune(second)))
+	if length == 0 {
+		return 0
+	}
+	editSimilarity := 1 - float64(editDistance(first, second))/float64(length)
+	return (editSimilarity + wordsOverlap(words(brand+" "+name), words(candidateBrand+" "+candidateName))) / 2
+}
+
+// wordsOverlap is the Jaccard index of two sets of words, where words with a
+// typo still count as common.
+func wordsOverlap(first []string, second []string) float64 {
+	common := 0
+	for _, word := range first {
+		if _, ok := closest(word, second); ok {
+			common++
+		}
+	}
+	union := len(first) + len(second) - common
+	if union <= 0 {
+		return 0
+	}
+	return float64(common) / float64(union)
+}
+
+func words(s string) []string {
+	return strings.FieldsFunc(strings.ToLower(s), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsNumber(r)
+	})
+}
@@ -0,0 +1,128 @@
+package advising
+
+import (
+	"context"
+	"testing"
+
+	"github.com/zemld/Scently/models"
+	"github.com/zemld/Scently/perfumist/internal/config"
+	"github.com/zemld/Scently/perfumist/internal/errors"
+	"github.com/zemld/Scently/perfumist/internal/models/fetching"
+	"github.com/zemld/Scently/perfumist/internal/models/parameters"
+)
+
+type MockSearcher struct {
+	Results []fetching.SearchResult
+	Err     error
+}
+
+func (m *MockSearcher) Search(ctx context.Context, query string, sex models.Sex, limit int) ([]fetching.SearchResult, error) {
+	return m.Results, m.Err
+}
+
+var sauvageResults = []fetching.SearchResult{
+	{Brand: "Dior", Name: "Sauvage Elixir", Sex: models.Male},
+	{Brand: "Dior", Name: "Sauvage", Sex: models.Male},
+	{Brand: "Dior", Name: "Fahrenheit", Sex: models.Male},
+}
+
+func TestFavouriteResolver_Resolve_ReturnsCandidates(t *testing.T) {
+	t.Parallel()
+
+	resolver := NewFavouriteResolver(&MockSearcher{Results: sauvageResults}, &config.MockConfigManager{})
+	_, err := resolver.Resolve(context.Background(), parameters.RequestPerfume{Brand: "Dior", Name: "Sauvge", Sex: models.Male})
+
+	notFound, ok := err.(*errors.NotFoundError)
+	if !ok {
+		t.Fatalf("expected NotFoundError, got %v", err)
+	}
+	if len(notFound.Candidates) != 3 || notFound.Candidates[0].Name != "Sauvage" {
+		t.Fatalf("expected Sauvage to be the best candidate, got %+v", notFound.Candidates)
+	}
+	for i := 1; i < len(notFound.Candidates); i++ {
+		if notFound.Candidates[i].Score > notFound.Candidates[i-1].Score {
+			t.Fatalf("expected candidates sorted by score, got %+v", notFound.Candidates)
+		}
+	}
+}
+
+func TestFavouriteResolver_Resolve_AutoResolves(t *testing.T) {
+	t.Parallel()
+
+	resolver := NewFavouriteResolver(&MockSearcher{Results: sauvageResults}, &config.MockConfigManager{}).WithAutoResolve(true)
+	resolved, err := resolver.Resolve(context.Background(), parameters.RequestPerfume{Brand: "Dior", Name: "Sauvge", Sex: models.Male})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if resolved.Brand != "Dior" || resolved.Name != "Sauvage" || resolved.Sex != models.Male {
+		t.Fatalf("expected Dior Sauvage, got %+v", resolved)
+	}
+}
+
+func TestFavouriteResolver_Resolve_KeepsAmbiguous(t *testing.T) {
+	t.Parallel()
+
+	results := []fetching.SearchResult{
+		{Brand: "Tom Ford", Name: "Oud Wood", Sex: models.Unisex},
+		{Brand: "Tom Ford", Name: "Oud Mood", Sex: models.Unisex},
+	}
+	resolver := NewFavouriteResolver(&MockSearcher{Results: results}, &config.MockConfigManager{}).WithAutoResolve(true)
+	_, err := resolver.Resolve(context.Background(), parameters.RequestPerfume{Brand: "Tom Ford", Name: "Oud Hood", Sex: models.Unisex})
+
+	if notFound, ok := err.(*errors.NotFoundError); !ok || len(notFound.Candidates) != 2 {
+		t.Fatalf("expected NotFoundError with both candidates, got %v", err)
+	}
+}
+
+func TestFavouriteResolver_Resolve_SearchFails(t *testing.T) {
+	t.Parallel()
+
+	resolver := NewFavouriteResolver(&MockSearcher{Err: errors.NewServiceError("can't search perfumes", nil)}, &config.MockConfigManager{})
+	_, err := resolver.Resolve(context.Background(), parameters.RequestPerfume{Brand: "Dior", Name: "Sauvge"})
+
+	if _, ok := err.(*errors.ServiceError); !ok {
+		t.Fatalf("expected ServiceError, got %v", err)
+	}
+}
+
+func TestCandidateScore(t *testing.T) {
+	t.Parallel()
+
+	exact := candidateScore("Dior", "Sauvage", "Dior", "Sauvage")
+	typo := candidateScore("Dior", "Sauvge", "Dior", "Sauvage")
+	reordered := candidateScore("Sauvage", "Dior", "Dior", "Sauvage")
+	other := candidateScore("Dior", "Sauvge", "Dior", "Fahrenheit")
+
+	if exact != 1 {
+		t.Errorf("expected exact match to score 1, got %f", exact)
+	}
+	if typo <= other || reordered <= other {
+		t.Errorf("expected typo (%f) and reordered words (%f) to beat another perfume (%f)", typo, reordered, other)
+	}
+}
+
+func TestBase_Advise_ResolvesMissingFavourite(t *testing.T) {
+	t.Parallel()
+
+	favourite := models.Perfume{Brand: "Dior", Name: "Sauvage", Sex: models.Male, Properties: models.Properties{Type: "Eau de Toilette"}}
+	fetcher := &MockFetcher{
+		FetchFunc: func(ctx context.Context, param parameters.RequestPerfume) <-chan models.Perfume {
+			ch := make(chan models.Perfume, 1)
+			if param.Name == "Sauvage" {
+				ch <- favourite
+			}
+			close(ch)
+			return ch
+		},
+	}
+	resolver := NewFavouriteResolver(&MockSearcher{Results: sauvageResults}, &config.MockConfigManager{}).WithAutoResolve(true)
+	base := NewBase(fetcher, &MockMatcher{}, &config.MockConfigManager{}).WithResolver(resolver)
+
+	perfume, err := base.fetchFavouritePerfume(context.Background(), parameters.RequestPerfume{Brand: "Dior", Name: "Sauvge", Sex: models.Male})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if !perfume.Equal(favourite) {
+		t.Fatalf("expected %+v, got %+v", favourite, perfume)
+	}
+}
@@ -0,0 +1,167 @@
+package advising
+
+import (
+	"context"
+	"sort"
+
+	"github.com/zemld/Scently/models"
+	"github.com/zemld/Scently/perfumist/internal/models/fetching"
+	"github.com/zemld/Scently/perfumist/internal/models/matching"
+	"github.com/zemld/Scently/perfumist/internal/models/parameters"
+	"github.com/zemld/config-manager/pkg/cm"
+)
+
+// Hybrid asks the LLM for candidates, keeps only the ones found in the catalog,
+// reranks them by similarity to the favourite and fills the gap with the base
+// advisor, so every suggestion is backed by the catalog.
+type Hybrid struct {
+	adviseFetcher fetching.Fetcher
+	fetcher       fetching.Fetcher
+	matcher       matching.Matcher
+	cm            cm.ConfigManager
+	describer     Describer
+	resolver      *FavouriteResolver
+}
+
+func NewHybrid(adviseFetcher fetching.Fetcher, fetcher fetching.Fetcher, matcher matching.Matcher, cm cm.ConfigManager) *Hybrid {
+	return &Hybrid{adviseFetcher: adviseFetcher, fetcher: fetcher, matcher: matcher, cm: cm}
+}
+
+func (a *Hybrid) WithDescriber(describer Describer) *Hybrid {
+	a.describer = describer
+	return a
+}
+
+func (a *Hybrid) WithResolver(resolver *FavouriteResolver) *Hybrid {
+	a.resolver = resolver
+	return a
+}
+
+func (a *Hybrid) Advise(ctx context.Context, params parameters.RequestPerfume) ([]models.Ranked, error) {
+	suggested, _, err := a.advise(ctx, params)
+	return suggested, err
+}
+
+func (a *Hybrid) AdviseWithExplanations(ctx context.Context, params parameters.RequestPerfume) ([]Explained, error) {
+	suggested, favouritePerfume, err := a.advise(ctx, params)
+	if err != nil {
+		return nil, err
+	}
+	explained := explain(a.matcher, favouritePerfume.Properties, suggested)
+	if a.describer != nil {
+		describe(ctx, a.describer, a.cm, favouritePerfume, explained)
+	}
+	return explained, nil
+}
+
+func (a *Hybrid) advise(ctx context.Context, params parameters.RequestPerfume) ([]models.Ranked, models.Perfume, error) {
+	base := NewBase(a.fetcher, a.matcher, a.cm).WithResolver(a.resolver)
+	favouritePerfume, err := base.fetchFavouritePerfume(ctx, params)
+	if err != nil {
+		return nil, models.Perfume{}, err
+	}
+	if favouritePerfume.Properties.UpperCharacteristics == nil {
+		matching.PreparePerfumeCharacteristics(&favouritePerfume)
+	}
+
+	count := a.cm.GetIntWithDefault("suggest_count", 4)
+	preferences := NewCommon(a.fetcher, a.matcher, a.cm).resolveNegativePreferences(ctx, params.Exclusions)
+	suggested := a.rankVerified(favouritePerfume, a.verify(ctx, params, favouritePerfume), params.Offers, preferences)
+	suggested = suggested[:min(count, len(suggested))]
+	for i := range suggested {
+		suggested[i].Rank = i + 1
+	}
+	if len(suggested) > 0 {
+		reportProgress(ctx, Update{Event: PartialEvent, Suggested: suggested})
+	}
+
+	if len(suggested) < count {
+		excluded := make([]models.Perfume, len(suggested))
+		for i, ranked := range suggested {
+			excluded[i] = ranked.Perfume
+		}
+		common := NewCommon(a.fetcher, a.matcher, a.cm).
+			WithFavouritePerfume(favouritePerfume).
+			WithExcludedPerfumes(excluded)
+		// The filling top alone would look like a regression of the
+		// verified one, so it is not reported.
+		filled, err := common.Advise(WithProgress(ctx, nil), params)
+		if err != nil {
+			return nil, models.Perfume{}, err
+		}
+		suggested = append(suggested, filled[:min(count-len(suggested), len(filled))]...)
+	}
+
+	for i := range suggested {
+		suggested[i].Rank = i + 1
+	}
+	return suggested, favouritePerfume, nil
+}
+
+// verify resolves the LLM suggestions against the catalog, dropping the unknown
+// ones, duplicates and the favourite itself.
+func (a *Hybrid) verify(ctx context.Context, params parameters.RequestPerfume, favouritePerfume models.Perfume) []models.Perfume {
+	var suggestions []models.Perfume
+	for suggestion := range a.adviseFetcher.Fetch(ctx, params) {
+		suggestions = append(suggestions, suggestion)
+	}
+	if len(suggestions) == 0 {
+		return nil
+	}
+
+	var catalogPerfumes []models.Perfume
+	for perfume := range a.fetcher.Fetch(ctx, *parameters.NewGet().WithSex(params.Sex)) {
+		catalogPerfumes = append(catalogPerfumes, perfume)
+	}
+	resolver := newCatalogResolver(catalogPerfumes)
+
+	seen := map[models.CanonizedPerfume]bool{favouritePerfume.Canonize(): true}
+	verified := make([]models.Perfume, 0, len(suggestions))
+	for _, suggestion := range suggestions {
+		perfume, ok := resolver.resolve(suggestion)
+		if !ok || seen[perfume.Canonize()] {
+			continue
+		}
+		seen[perfume.Canonize()] = true
+		verified = append(verified, perfume)
+	}
+	return verified
+}
+
+func (a *Hybrid) rankVerified(
+	favouritePerfume models.Perfume,
+	verified []models.Perfume,
+	offers parameters.OfferFilter,
+	preferences *negativePreferences,
+) []models.Ranked {
+	ranked := make([]models.Ranked, 0, len(verified))
+	for _, perfume := range verified {
+		if preferences.rejects(perfume) {
+			continue
+		}
+		perfume, ok := filterOffers(perfume, offers)
+		if !ok {
+			continue
+		}
+		if perfume.Properties.UpperCharacteristics == nil {
+			matching.PreparePerfumeCharacteristics(&perfume)
+		}
+		score := preferences.penalize(
+			a.matcher.GetSimilarityScore(favouritePerfume.Properties, perfume.Properties),
+			perfume.Properties,
+		)
+		perfume.Properties.Tags = matching.CalculatePerfumeTags(
+			&perfume.Properties,
+			*matching.NewBaseWeights(
+				a.cm.GetFloatWithDefault("upper_notes_weight", 0.2),
+				a.cm.GetFloatWithDefault("core_notes_weight", 0.35),
+				a.cm.GetFloatWithDefault("base_notes_weight", 0.45),
+			),
+		)
+		ranked = append(ranked, models.Ranked{Perfume: perfume, Score: score})
+	}
+	sort.SliceStable(ranked, func(i, j int) bool {
+		return ranked[i].Score > ranked[j].Score
+	})
+	return ranked
+}
@@ -0,0 +1,141 @@
+package advising
+
+import (
+	"context"
+	"testing"
+
+	"github.com/zemld/Scently/models"
+	"github.com/zemld/Scently/perfumist/internal/models/matching"
+	"github.com/zemld/Scently/perfumist/internal/models/parameters"
+)
+
+func llmFetcher(suggestions ...models.Perfume) *MockFetcher {
+	return &MockFetcher{
+		FetchFunc: func(ctx context.Context, param parameters.RequestPerfume) <-chan models.Perfume {
+			ch := make(chan models.Perfume, len(suggestions))
+			for _, p := range suggestions {
+				ch <- p
+			}
+			close(ch)
+			return ch
+		},
+	}
+}
+
+func TestHybrid_Advise_VerifiesAndFillsFromCatalog(t *testing.T) {
+	t.Parallel()
+
+	advisor := NewHybrid(
+		llmFetcher(
+			models.Perfume{Brand: "Tom Frod", Name: "Tobaco Vanile"},
+			models.Perfume{Brand: "Creed", Name: "Aventus"},
+			models.Perfume{Brand: "KILIAN", Name: "Angels Share"},
+			models.Perfume{Brand: "Dior", Name: "Sauvage"},
+			models.Perfume{Brand: "Kilian", Name: "Angel's Share"},
+		),
+		multiFetcher(nil),
+		matching.NewCharacteristicsMatcher(*matching.NewBaseWeights(1, 0, 0)),
+		multiConfig(3),
+	)
+
+	suggested, err := advisor.Advise(context.Background(), *parameters.NewGet().WithBrand("Dior").WithName("Sauvage").WithSex("male"))
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if len(suggested) != 3 {
+		t.Fatalf("expected 3 suggestions, got %d", len(suggested))
+	}
+
+	verified := map[string]bool{suggested[0].Perfume.Name: true, suggested[1].Perfume.Name: true}
+	if !verified["Tobacco Vanille"] || !verified["Angels' Share"] {
+		t.Fatalf("expected verified LLM suggestions first, got %s and %s", suggested[0].Perfume.Name, suggested[1].Perfume.Name)
+	}
+	if suggested[0].Score < suggested[1].Score {
+		t.Fatalf("expected verified suggestions to be reranked by score, got %f then %f", suggested[0].Score, suggested[1].Score)
+	}
+	if suggested[2].Perfume.Name != "Colonia" {
+		t.Fatalf("expected gap to be filled by Colonia, got %s", suggested[2].Perfume.Name)
+	}
+	for i, ranked := range suggested {
+		if ranked.Rank != i+1 {
+			t.Fatalf("expected rank %d, got %d", i+1, ranked.Rank)
+		}
+	}
+}
+
+func TestHybrid_Advise_FallsBackWithoutLLM(t *testing.T) {
+	t.Parallel()
+
+	advisor := NewHybrid(
+		llmFetcher(),
+		multiFetcher(nil),
+		matching.NewCharacteristicsMatcher(*matching.NewBaseWeights(1, 0, 0)),
+		multiConfig(2),
+	)
+
+	suggested, err := advisor.Advise(context.Background(), *parameters.NewGet().WithBrand("Dior").WithName("Sauvage").WithSex("male"))
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if len(suggested) != 2 || suggested[0].Perfume.Name != "Colonia" {
+		t.Fatalf("expected base suggestions led by Colonia, got %+v", suggested)
+	}
+}
+
+func TestHybrid_AdviseWithExplanations(t *testing.T) {
+	t.Parallel()
+
+	advisor := NewHybrid(
+		llmFetcher(models.Perfume{Brand: "Acqua di Parma", Name: "Colonia"}),
+		multiFetcher(nil),
+		matching.NewCombinedMatcher(*matching.NewWeights(0.4, 0.55, 0.05, 0.2, 0.35, 0.45, 0.3, 0.5, 0.2)),
+		multiConfig(1),
+	)
+
+	explained, err := advisor.AdviseWithExplanations(context.Background(), *parameters.NewGet().WithBrand("Dior").WithName("Sauvage").WithSex("male"))
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if len(explained) != 1 || explained[0].Perfume.Name != "Colonia" || explained[0].Explanation == nil {
+		t.Fatalf("expected explained Colonia, got %+v", explained)
+	}
+}
+
+func TestHybrid_Advise_RespectsExclusions(t *testing.T) {
+	t.Parallel()
+
+	catalog := []models.Perfume{
+		{Brand: "Dior", Name: "Sauvage", Sex: "male", Properties: models.Properties{UpperCharacteristics: map[string]float64{"freshness": 1}}},
+		{Brand: "Acqua di Parma", Name: "Colonia", Sex: "unisex", Properties: models.Properties{
+			UpperNotes:           []string{"Bergamot"},
+			UpperCharacteristics: map[string]float64{"freshness": 0.9},
+		}},
+		{Brand: "Le Labo", Name: "Santal 33", Sex: "unisex", Properties: models.Properties{UpperCharacteristics: map[string]float64{"woodiness": 1}}},
+	}
+	fetcher := &MockFetcher{
+		FetchFunc: func(ctx context.Context, param parameters.RequestPerfume) <-chan models.Perfume {
+			ch := make(chan models.Perfume, len(catalog))
+			for _, p := range catalog {
+				ch <- p
+			}
+			close(ch)
+			return ch
+		},
+	}
+	advisor := NewHybrid(
+		llmFetcher(models.Perfume{Brand: "Acqua di Parma", Name: "Colonia"}),
+		fetcher,
+		matching.NewCharacteristicsMatcher(*matching.NewBaseWeights(1, 0, 0)),
+		multiConfig(1),
+	)
+
+	params := parameters.NewGet().WithBrand("Dior").WithName("Sauvage").WithSex("male").
+		WithExclusions(parameters.Exclusions{Notes: []string{"bergamot"}})
+	suggested, err := advisor.Advise(context.Background(), *params)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if len(suggested) != 1 || suggested[0].Perfume.Name != "Santal 33" {
+		t.Fatalf("expected excluded Colonia to be replaced by Santal 33, got %+v", suggested)
+	}
+}
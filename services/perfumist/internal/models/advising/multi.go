@@ -0,0 +1,102 @@
+package advising
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/zemld/Scently/models"
+	"github.com/zemld/Scently/perfumist/internal/errors"
+	"github.com/zemld/Scently/perfumist/internal/models/fetching"
+	"github.com/zemld/Scently/perfumist/internal/models/matching"
+	"github.com/zemld/Scently/perfumist/internal/models/parameters"
+	"github.com/zemld/config-manager/pkg/cm"
+)
+
+type Multi struct {
+	fetcher    fetching.Fetcher
+	matcher    matching.Matcher
+	cm         cm.ConfigManager
+	favourites []parameters.RequestPerfume
+	strategy   matching.ProfileStrategy
+}
+
+func NewMulti(
+	fetcher fetching.Fetcher,
+	matcher matching.Matcher,
+	cm cm.ConfigManager,
+	favourites []parameters.RequestPerfume,
+	strategy matching.ProfileStrategy,
+) *Multi {
+	return &Multi{fetcher: fetcher, matcher: matcher, cm: cm, favourites: favourites, strategy: strategy}
+}
+
+func (a *Multi) Advise(ctx context.Context, params parameters.RequestPerfume) ([]models.Ranked, error) {
+	suggested, _, _, err := a.advise(ctx, params)
+	return suggested, err
+}
+
+func (a *Multi) AdviseWithExplanations(ctx context.Context, params parameters.RequestPerfume) ([]Explained, error) {
+	suggested, matcher, profile, err := a.advise(ctx, params)
+	if err != nil {
+		return nil, err
+	}
+	return explain(matcher, profile, suggested), nil
+}
+
+func (a *Multi) advise(ctx context.Context, params parameters.RequestPerfume) ([]models.Ranked, matching.Matcher, models.Properties, error) {
+	favouritePerfumes, err := a.fetchFavouritePerfumes(ctx)
+	if err != nil {
+		return nil, nil, models.Properties{}, err
+	}
+
+	favourites := make([]models.Properties, len(favouritePerfumes))
+	for i := range favouritePerfumes {
+		if favouritePerfumes[i].Properties.UpperCharacteristics == nil {
+			matching.PreparePerfumeCharacteristics(&favouritePerfumes[i])
+		}
+		favourites[i] = favouritePerfumes[i].Properties
+	}
+
+	var matcher matching.Matcher
+	var profile models.Properties
+	switch a.strategy {
+	case matching.MaxStrategy:
+		matcher = matching.NewMaxSimilarity(a.matcher, favourites)
+	default:
+		matcher = a.matcher
+		profile = matching.BuildProfile(favourites)
+	}
+
+	common := NewCommon(a.fetcher, matcher, a.cm).
+		WithFavouritePerfume(models.Perfume{Properties: profile}).
+		WithExcludedPerfumes(favouritePerfumes)
+	suggested, err := common.Advise(ctx, params)
+	return suggested, matcher, profile, err
+}
+
+func (a *Multi) fetchFavouritePerfumes(ctx context.Context) ([]models.Perfume, error) {
+	fetched := make(map[models.CanonizedPerfume]models.Perfume)
+	for perfume := range a.fetcher.FetchMany(ctx, a.favourites) {
+		key := canonizedName(perfume.Brand, perfume.Name)
+		if _, ok := fetched[key]; !ok {
+			fetched[key] = perfume
+		}
+	}
+	if ctx.Err() != nil {
+		return nil, errors.NewServiceError("context cancelled", ctx.Err())
+	}
+
+	favouritePerfumes := make([]models.Perfume, 0, len(a.favourites))
+	for _, favourite := range a.favourites {
+		perfume, ok := fetched[canonizedName(favourite.Brand, favourite.Name)]
+		if !ok {
+			return nil, errors.NewNotFoundError(fmt.Sprintf("perfume %s %s not found", favourite.Brand, favourite.Name))
+		}
+		favouritePerfumes = append(favouritePerfumes, perfume)
+	}
+	return favouritePerfumes, nil
+}
+
+func canonizedName(brand string, name string) models.CanonizedPerfume {
+	return models.Perfume{Brand: brand, Name: name}.Canonize()
+}
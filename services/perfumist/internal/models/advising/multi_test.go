@@ -0,0 +1,173 @@
+package advising
+
+import (
+	"context"
+	"math"
+	"testing"
+
+	"github.com/zemld/Scently/models"
+	"github.com/zemld/Scently/perfumist/internal/config"
+	"github.com/zemld/Scently/perfumist/internal/errors"
+	"github.com/zemld/Scently/perfumist/internal/models/matching"
+	"github.com/zemld/Scently/perfumist/internal/models/parameters"
+)
+
+var multiCatalog = []models.Perfume{
+	{Brand: "Dior", Name: "Sauvage", Sex: "male", Properties: models.Properties{UpperCharacteristics: map[string]float64{"freshness": 1}}},
+	{Brand: "Tom Ford", Name: "Tobacco Vanille", Sex: "unisex", Properties: models.Properties{UpperCharacteristics: map[string]float64{"sweetness": 1}}},
+	{Brand: "Acqua di Parma", Name: "Colonia", Sex: "unisex", Properties: models.Properties{UpperCharacteristics: map[string]float64{"freshness": 0.9, "spiciness": 0.1}}},
+	{Brand: "Kilian", Name: "Angels' Share", Sex: "unisex", Properties: models.Properties{UpperCharacteristics: map[string]float64{"sweetness": 0.9, "warmth": 0.1}}},
+	{Brand: "Le Labo", Name: "Santal 33", Sex: "unisex", Properties: models.Properties{UpperCharacteristics: map[string]float64{"woodiness": 1}}},
+}
+
+func multiFetcher(requested *[]parameters.RequestPerfume) *MockFetcher {
+	return &MockFetcher{
+		FetchFunc: func(ctx context.Context, param parameters.RequestPerfume) <-chan models.Perfume {
+			ch := make(chan models.Perfume, len(multiCatalog))
+			for _, p := range multiCatalog {
+				ch <- p
+			}
+			close(ch)
+			return ch
+		},
+		FetchManyFunc: func(ctx context.Context, params []parameters.RequestPerfume) <-chan models.Perfume {
+			if requested != nil {
+				*requested = params
+			}
+			ch := make(chan models.Perfume, len(multiCatalog))
+			for _, p := range multiCatalog {
+				for _, param := range params {
+					if canonizedName(p.Brand, p.Name) == canonizedName(param.Brand, param.Name) {
+						ch <- p
+					}
+				}
+			}
+			close(ch)
+			return ch
+		},
+	}
+}
+
+func multiConfig(count int) *config.MockConfigManager {
+	return &config.MockConfigManager{
+		GetIntWithDefaultFunc: func(key string, defaultValue int) int {
+			if key == "suggest_count" {
+				return count
+			}
+			return defaultValue
+		},
+	}
+}
+
+func multiFavourites() []parameters.RequestPerfume {
+	return []parameters.RequestPerfume{
+		*parameters.NewGet().WithBrand("Dior").WithName("Sauvage").WithSex("male"),
+		*parameters.NewGet().WithBrand("tom ford").WithName("TOBACCO VANILLE"),
+	}
+}
+
+func TestMulti_Advise_UsesFetchManyAndExcludesInputs(t *testing.T) {
+	t.Parallel()
+
+	var requested []parameters.RequestPerfume
+	advisor := NewMulti(
+		multiFetcher(&requested),
+		matching.NewCharacteristicsMatcher(*matching.NewBaseWeights(1, 0, 0)),
+		multiConfig(5),
+		multiFavourites(),
+		matching.CentroidStrategy,
+	)
+
+	suggested, err := advisor.Advise(context.Background(), *parameters.NewGet().WithSex("male"))
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if len(requested) != 2 {
+		t.Fatalf("expected both favourites to be fetched at once, got %v", requested)
+	}
+	if len(suggested) != 3 {
+		t.Fatalf("expected 3 suggestions, got %d", len(suggested))
+	}
+	for _, s := range suggested {
+		if s.Perfume.Name == "Sauvage" || s.Perfume.Name == "Tobacco Vanille" {
+			t.Fatalf("expected input perfume %s to be excluded", s.Perfume.Name)
+		}
+	}
+	for _, s := range suggested {
+		if s.Perfume.Name == "Santal 33" && s.Score != 0 {
+			t.Fatalf("expected unrelated perfume to score 0, got %f", s.Score)
+		}
+		if s.Perfume.Name == "Colonia" && s.Score < 0.6 {
+			t.Fatalf("expected fresh perfume to match the centroid, got %f", s.Score)
+		}
+	}
+}
+
+func TestMulti_Advise_MaxStrategy(t *testing.T) {
+	t.Parallel()
+
+	advisor := NewMulti(
+		multiFetcher(nil),
+		matching.NewCharacteristicsMatcher(*matching.NewBaseWeights(1, 0, 0)),
+		multiConfig(2),
+		multiFavourites(),
+		matching.MaxStrategy,
+	)
+
+	suggested, err := advisor.Advise(context.Background(), *parameters.NewGet().WithSex("male"))
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if len(suggested) != 2 {
+		t.Fatalf("expected 2 suggestions, got %d", len(suggested))
+	}
+	names := map[string]bool{suggested[0].Perfume.Name: true, suggested[1].Perfume.Name: true}
+	if !names["Colonia"] || !names["Angels' Share"] {
+		t.Fatalf("expected the closest perfume to each favourite, got %v", names)
+	}
+	for _, s := range suggested {
+		if s.Score < 0.99 {
+			t.Fatalf("expected max similarity close to 1 for %s, got %f", s.Perfume.Name, s.Score)
+		}
+	}
+}
+
+func TestMulti_Advise_FavouriteNotFound(t *testing.T) {
+	t.Parallel()
+
+	favourites := append(multiFavourites(), *parameters.NewGet().WithBrand("Unknown").WithName("Perfume"))
+	advisor := NewMulti(
+		multiFetcher(nil),
+		&MockMatcher{},
+		multiConfig(4),
+		favourites,
+		matching.CentroidStrategy,
+	)
+
+	_, err := advisor.Advise(context.Background(), *parameters.NewGet())
+	if _, ok := err.(*errors.NotFoundError); !ok {
+		t.Fatalf("expected NotFoundError, got %v", err)
+	}
+}
+
+func TestMulti_AdviseWithExplanations(t *testing.T) {
+	t.Parallel()
+
+	advisor := NewMulti(
+		multiFetcher(nil),
+		matching.NewCombinedMatcher(*matching.NewWeights(0.4, 0.55, 0.05, 0.2, 0.35, 0.45, 0.3, 0.5, 0.2)),
+		multiConfig(2),
+		multiFavourites(),
+		matching.MaxStrategy,
+	)
+
+	explained, err := advisor.AdviseWithExplanations(context.Background(), *parameters.NewGet().WithSex("male"))
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	for _, e := range explained {
+		if e.Explanation == nil || math.Abs(e.Explanation.Score-e.Score) > 1e-9 {
+			t.Fatalf("expected explanation matching the score, got %+v", e)
+		}
+	}
+}
@@ -0,0 +1,93 @@
+package advising
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/zemld/Scently/models"
+	"github.com/zemld/Scently/perfumist/internal/errors"
+	"github.com/zemld/Scently/perfumist/internal/models/fetching"
+	"github.com/zemld/Scently/perfumist/internal/models/matching"
+	"github.com/zemld/Scently/perfumist/internal/models/parameters"
+	"github.com/zemld/config-manager/pkg/cm"
+)
+
+type NotesBased struct {
+	fetcher      fetching.Fetcher
+	notesFetcher fetching.NotesFetcher
+	matcher      matching.Matcher
+	cm           cm.ConfigManager
+	notes        []string
+	levels       []matching.NoteLevel
+}
+
+func NewNotesBased(
+	fetcher fetching.Fetcher,
+	notesFetcher fetching.NotesFetcher,
+	matcher matching.Matcher,
+	cm cm.ConfigManager,
+	notes []string,
+	levels []matching.NoteLevel,
+) *NotesBased {
+	return &NotesBased{
+		fetcher:      fetcher,
+		notesFetcher: notesFetcher,
+		matcher:      matcher,
+		cm:           cm,
+		notes:        notes,
+		levels:       levels,
+	}
+}
+
+func (a *NotesBased) Advise(ctx context.Context, params parameters.RequestPerfume) ([]models.Ranked, error) {
+	suggested, _, err := a.advise(ctx, params)
+	return suggested, err
+}
+
+func (a *NotesBased) AdviseWithExplanations(ctx context.Context, params parameters.RequestPerfume) ([]Explained, error) {
+	suggested, profile, err := a.advise(ctx, params)
+	if err != nil {
+		return nil, err
+	}
+	return explain(a.matcher, profile, suggested), nil
+}
+
+func (a *NotesBased) advise(ctx context.Context, params parameters.RequestPerfume) ([]models.Ranked, models.Properties, error) {
+	notes, err := a.fetchNotes(ctx)
+	if err != nil {
+		return nil, models.Properties{}, err
+	}
+
+	profile := matching.BuildNotesProfile(notes, a.levels)
+	common := NewCommon(a.fetcher, a.matcher, a.cm).WithFavouritePerfume(models.Perfume{Properties: profile})
+	suggested, err := common.Advise(ctx, params)
+	return suggested, profile, err
+}
+
+func (a *NotesBased) fetchNotes(ctx context.Context) ([]models.EnrichedNote, error) {
+	fetched, err := a.notesFetcher.FetchNotes(ctx, a.notes)
+	if err != nil {
+		return nil, err
+	}
+
+	byName := make(map[string]models.EnrichedNote, len(fetched))
+	for _, note := range fetched {
+		byName[strings.ToLower(note.Name)] = note
+	}
+
+	notes := make([]models.EnrichedNote, 0, len(a.notes))
+	unknown := []string{}
+	for _, name := range a.notes {
+		note, ok := byName[strings.ToLower(name)]
+		if !ok {
+			unknown = append(unknown, name)
+			continue
+		}
+		notes = append(notes, note)
+	}
+	if len(unknown) > 0 {
+		return nil, errors.NewValidationError(parameters.NotesParamKey, fmt.Sprintf("unknown notes: %s", strings.Join(unknown, ", ")))
+	}
+	return notes, nil
+}
@@ -0,0 +1,118 @@
+package advising
+
+import (
+	"context"
+	"testing"
+
+	"github.com/zemld/Scently/models"
+	"github.com/zemld/Scently/perfumist/internal/errors"
+	"github.com/zemld/Scently/perfumist/internal/models/matching"
+	"github.com/zemld/Scently/perfumist/internal/models/parameters"
+)
+
+type mockNotesFetcher struct {
+	notes     []models.EnrichedNote
+	err       error
+	requested []string
+}
+
+func (f *mockNotesFetcher) FetchNotes(ctx context.Context, names []string) ([]models.EnrichedNote, error) {
+	f.requested = names
+	return f.notes, f.err
+}
+
+func notesVocabulary() []models.EnrichedNote {
+	return []models.EnrichedNote{
+		{Name: "vanilla", Characteristics: []models.NoteCharacteristic{{Name: "sweetness", Value: 1}}},
+		{Name: "bergamot", Characteristics: []models.NoteCharacteristic{{Name: "freshness", Value: 1}}},
+	}
+}
+
+func TestNotesBased_Advise_RanksBySyntheticProfile(t *testing.T) {
+	t.Parallel()
+
+	notesFetcher := &mockNotesFetcher{notes: notesVocabulary()[:1]}
+	advisor := NewNotesBased(
+		multiFetcher(nil),
+		notesFetcher,
+		matching.NewCharacteristicsMatcher(*matching.NewBaseWeights(1, 0, 0)),
+		multiConfig(len(multiCatalog)),
+		[]string{"Vanilla"},
+		[]matching.NoteLevel{matching.UpperLevel},
+	)
+
+	suggested, err := advisor.Advise(context.Background(), *parameters.NewGet().WithSex("unisex"))
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if len(notesFetcher.requested) != 1 || notesFetcher.requested[0] != "Vanilla" {
+		t.Fatalf("expected notes to be requested, got %v", notesFetcher.requested)
+	}
+	scores := map[string]float64{}
+	for _, s := range suggested {
+		scores[s.Perfume.Name] = s.Score
+	}
+	if scores["Tobacco Vanille"] <= scores["Sauvage"] || scores["Angels' Share"] <= scores["Santal 33"] {
+		t.Fatalf("expected sweet perfumes to score higher, got %v", scores)
+	}
+}
+
+func TestNotesBased_Advise_RejectsUnknownNotes(t *testing.T) {
+	t.Parallel()
+
+	advisor := NewNotesBased(
+		multiFetcher(nil),
+		&mockNotesFetcher{notes: notesVocabulary()},
+		matching.NewCharacteristicsMatcher(*matching.NewBaseWeights(1, 0, 0)),
+		multiConfig(2),
+		[]string{"vanilla", "unicorn tears"},
+		nil,
+	)
+
+	_, err := advisor.Advise(context.Background(), *parameters.NewGet())
+	validationErr, ok := err.(*errors.ValidationError)
+	if !ok {
+		t.Fatalf("expected validation error, got %v", err)
+	}
+	if validationErr.Field != parameters.NotesParamKey {
+		t.Fatalf("expected field %q, got %q", parameters.NotesParamKey, validationErr.Field)
+	}
+}
+
+func TestNotesBased_Advise_PropagatesFetchError(t *testing.T) {
+	t.Parallel()
+
+	advisor := NewNotesBased(
+		multiFetcher(nil),
+		&mockNotesFetcher{err: errors.NewServiceError("hub is down", nil)},
+		matching.NewCharacteristicsMatcher(*matching.NewBaseWeights(1, 0, 0)),
+		multiConfig(2),
+		[]string{"vanilla"},
+		nil,
+	)
+
+	if _, err := advisor.Advise(context.Background(), *parameters.NewGet()); err == nil {
+		t.Fatal("expected error when notes can't be fetched")
+	}
+}
+
+func TestNotesBased_AdviseWithExplanations(t *testing.T) {
+	t.Parallel()
+
+	advisor := NewNotesBased(
+		multiFetcher(nil),
+		&mockNotesFetcher{notes: notesVocabulary()},
+		matching.NewCombinedMatcher(*matching.NewWeights(0.4, 0.55, 0.05, 0.2, 0.35, 0.45, 0.3, 0.5, 0.2)),
+		multiConfig(1),
+		[]string{"bergamot"},
+		[]matching.NoteLevel{matching.UpperLevel},
+	)
+
+	explained, err := advisor.AdviseWithExplanations(context.Background(), *parameters.NewGet())
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if len(explained) != 1 || explained[0].Explanation == nil {
+		t.Fatalf("expected one explained suggestion, got %+v", explained)
+	}
+}
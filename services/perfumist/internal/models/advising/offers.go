@@ -0,0 +1,83 @@
+package advising
+
+import (
+	"slices"
+	"strings"
+
+	"github.com/zemld/Scently/models"
+	"github.com/zemld/Scently/perfumist/internal/models/parameters"
+)
+
+// filterOffers keeps only the shop variants that satisfy the filter. The
+// perfume is rejected when none of them do. Shops are copied, not trimmed in
+// place, because perfumes may come from the shared catalog snapshot.
+func filterOffers(perfume models.Perfume, filter parameters.OfferFilter) (models.Perfume, bool) {
+	if filter.IsEmpty() {
+		return perfume, true
+	}
+
+	shops := make([]models.ShopInfo, 0, len(perfume.Shops))
+	for _, shop := range perfume.Shops {
+		if !matchesShop(shop, filter.Shops) {
+			continue
+		}
+		variants := make([]models.Variant, 0, len(shop.Variants))
+		for _, variant := range shop.Variants {
+			if matchesVariant(variant, filter) {
+				variants = append(variants, variant)
+			}
+		}
+		if len(variants) == 0 {
+			continue
+		}
+		shop.Variants = variants
+		shops = append(shops, shop)
+	}
+	perfume.Shops = shops
+	return perfume, len(shops) > 0
+}
+
+func filterRankedOffers(suggested []models.Ranked, filter parameters.OfferFilter) []models.Ranked {
+	if filter.IsEmpty() {
+		return suggested
+	}
+	filtered := make([]models.Ranked, 0, len(suggested))
+	for _, ranked := range suggested {
+		perfume, ok := filterOffers(ranked.Perfume, filter)
+		if !ok {
+			continue
+		}
+		ranked.Perfume = perfume
+		ranked.Rank = len(filtered) + 1
+		filtered = append(filtered, ranked)
+	}
+	return filtered
+}
+
+func matchesShop(shop models.ShopInfo, shops []string) bool {
+	if len(shops) == 0 {
+		return true
+	}
+	for _, name := range shops {
+		if strings.EqualFold(name, shop.ShopName) || strings.EqualFold(name, shop.Domain) {
+			return true
+		}
+	}
+	return false
+}
+
+func matchesVariant(variant models.Variant, filter parameters.OfferFilter) bool {
+	if filter.MinPrice > 0 && variant.Price < filter.MinPrice {
+		return false
+	}
+	if filter.MaxPrice > 0 && variant.Price > filter.MaxPrice {
+		return false
+	}
+	if filter.MaxPricePerMl > 0 && (variant.Volume <= 0 || float64(variant.Price)/float64(variant.Volume) > filter.MaxPricePerMl) {
+		return false
+	}
+	if len(filter.Volumes) > 0 && !slices.Contains(filter.Volumes, variant.Volume) {
+		return false
+	}
+	return true
+}
@@ -0,0 +1,178 @@
+package advising
+
+import (
+	"context"
+	"testing"
+
+	"github.com/zemld/Scently/models"
+	"github.com/zemld/Scently/perfumist/internal/models/matching"
+	"github.com/zemld/Scently/perfumist/internal/models/parameters"
+)
+
+func offeredPerfume() models.Perfume {
+	return models.Perfume{
+		Brand: "Dior",
+		Name:  "Sauvage",
+		Shops: []models.ShopInfo{
+			{ShopName: "Gold Apple", Domain: "goldapple.ru", Variants: []models.Variant{
+				{Volume: 30, Price: 6000},
+				{Volume: 100, Price: 12000},
+			}},
+			{ShopName: "Randewoo", Domain: "randewoo.ru", Variants: []models.Variant{
+				{Volume: 100, Price: 9000},
+			}},
+		},
+	}
+}
+
+func TestFilterOffers_EmptyFilterKeepsPerfume(t *testing.T) {
+	t.Parallel()
+
+	perfume, ok := filterOffers(offeredPerfume(), parameters.OfferFilter{})
+	if !ok || len(perfume.Shops) != 2 || len(perfume.Shops[0].Variants) != 2 {
+		t.Fatalf("expected perfume to be untouched, got %+v (ok=%v)", perfume, ok)
+	}
+}
+
+func TestFilterOffers_TrimsVariants(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name     string
+		filter   parameters.OfferFilter
+		expected map[string][]int
+	}{
+		{
+			name:     "max price",
+			filter:   parameters.OfferFilter{MaxPrice: 9000},
+			expected: map[string][]int{"Gold Apple": {30}, "Randewoo": {100}},
+		},
+		{
+			name:     "min price",
+			filter:   parameters.OfferFilter{MinPrice: 10000},
+			expected: map[string][]int{"Gold Apple": {100}},
+		},
+		{
+			name:     "price per ml",
+			filter:   parameters.OfferFilter{MaxPricePerMl: 120},
+			expected: map[string][]int{"Gold Apple": {100}, "Randewoo": {100}},
+		},
+		{
+			name:     "volumes",
+			filter:   parameters.OfferFilter{Volumes: []int{30}},
+			expected: map[string][]int{"Gold Apple": {30}},
+		},
+		{
+			name:     "shops by domain",
+			filter:   parameters.OfferFilter{Shops: []string{"RANDEWOO.RU"}},
+			expected: map[string][]int{"Randewoo": {100}},
+		},
+	}
+
+	for _, tc := range cases {
+		perfume, ok := filterOffers(offeredPerfume(), tc.filter)
+		if !ok {
+			t.Fatalf("%s: expected perfume to pass", tc.name)
+		}
+		if len(perfume.Shops) != len(tc.expected) {
+			t.Fatalf("%s: expected shops %v, got %+v", tc.name, tc.expected, perfume.Shops)
+		}
+		for _, shop := range perfume.Shops {
+			volumes := tc.expected[shop.ShopName]
+			if len(shop.Variants) != len(volumes) {
+				t.Fatalf("%s: expected volumes %v in %s, got %+v", tc.name, volumes, shop.ShopName, shop.Variants)
+			}
+			for i, variant := range shop.Variants {
+				if variant.Volume != volumes[i] {
+					t.Fatalf("%s: expected volumes %v in %s, got %+v", tc.name, volumes, shop.ShopName, shop.Variants)
+				}
+			}
+		}
+	}
+}
+
+func TestFilterOffers_RejectsPerfumeWithoutMatchingVariants(t *testing.T) {
+	t.Parallel()
+
+	if _, ok := filterOffers(offeredPerfume(), parameters.OfferFilter{MaxPrice: 1000}); ok {
+		t.Fatal("expected perfume without affordable variants to be rejected")
+	}
+	if _, ok := filterOffers(models.Perfume{Brand: "Dior", Name: "Sauvage"}, parameters.OfferFilter{MaxPrice: 1000}); ok {
+		t.Fatal("expected perfume without shops to be rejected")
+	}
+}
+
+func TestFilterOffers_DoesNotMutateOriginal(t *testing.T) {
+	t.Parallel()
+
+	original := offeredPerfume()
+	filterOffers(original, parameters.OfferFilter{MaxPrice: 6000})
+	if len(original.Shops) != 2 || len(original.Shops[0].Variants) != 2 {
+		t.Fatalf("expected original perfume to stay untouched, got %+v", original.Shops)
+	}
+}
+
+func TestFilterRankedOffers_ReranksSurvivors(t *testing.T) {
+	t.Parallel()
+
+	cheap := offeredPerfume()
+	expensive := models.Perfume{Brand: "Creed", Name: "Aventus", Shops: []models.ShopInfo{
+		{ShopName: "Gold Apple", Variants: []models.Variant{{Volume: 100, Price: 30000}}},
+	}}
+	filtered := filterRankedOffers([]models.Ranked{
+		{Perfume: expensive, Rank: 1},
+		{Perfume: cheap, Rank: 2},
+	}, parameters.OfferFilter{MaxPrice: 10000})
+
+	if len(filtered) != 1 || filtered[0].Perfume.Name != "Sauvage" || filtered[0].Rank != 1 {
+		t.Fatalf("expected only Sauvage ranked first, got %+v", filtered)
+	}
+}
+
+func TestCommon_Advise_FiltersOffersBeforeRanking(t *testing.T) {
+	t.Parallel()
+
+	affordable := map[string]bool{"Colonia": true, "Santal 33": true, "Angels' Share": true}
+	catalog := make([]models.Perfume, len(multiCatalog))
+	for i, perfume := range multiCatalog {
+		price := 30000
+		if affordable[perfume.Name] {
+			price = 5000
+		}
+		perfume.Shops = []models.ShopInfo{{ShopName: "Gold Apple", Variants: []models.Variant{
+			{Volume: 50, Price: price},
+			{Volume: 100, Price: price * 2},
+		}}}
+		catalog[i] = perfume
+	}
+	fetcher := &MockFetcher{
+		FetchFunc: func(ctx context.Context, param parameters.RequestPerfume) <-chan models.Perfume {
+			ch := make(chan models.Perfume, len(catalog))
+			for _, p := range catalog {
+				ch <- p
+			}
+			close(ch)
+			return ch
+		},
+	}
+
+	advisor := NewTagsBased(matching.NewTagsBasedAdapter(*matching.NewBaseWeights(1, 1, 1), []string{"fresh"}), fetcher, multiConfig(2))
+	suggested, err := advisor.Advise(context.Background(), *parameters.NewGet().WithOffers(parameters.OfferFilter{MaxPrice: 6000}))
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if len(suggested) != 2 {
+		t.Fatalf("expected a full top-2 of affordable perfumes, got %d", len(suggested))
+	}
+	for _, s := range suggested {
+		if !affordable[s.Perfume.Name] {
+			t.Fatalf("expected only affordable perfumes, got %s", s.Perfume.Name)
+		}
+		if len(s.Perfume.Shops) != 1 || len(s.Perfume.Shops[0].Variants) != 1 || s.Perfume.Shops[0].Variants[0].Volume != 50 {
+			t.Fatalf("expected variants outside the budget to be trimmed, got %+v", s.Perfume.Shops)
+		}
+	}
+	if len(catalog[2].Shops[0].Variants) != 2 {
+		t.Fatal("expected catalog perfumes to stay untouched")
+	}
+}
@@ -0,0 +1,116 @@
+package advising
+
+import (
+	"context"
+	"log"
+	"strings"
+
+	"github.com/zemld/Scently/models"
+	"github.com/zemld/Scently/perfumist/internal/models/matching"
+	"github.com/zemld/Scently/perfumist/internal/models/parameters"
+	"github.com/zemld/Scently/perfumist/pkg/set"
+	"github.com/zemld/config-manager/pkg/cm"
+)
+
+type negativePreferences struct {
+	notes    map[string]struct{}
+	tags     map[string]struct{}
+	disliked []models.Perfume
+
+	matcher matching.Matcher
+	weights matching.Weights
+	penalty float64
+}
+
+// newNegativePreferences penalizes perfumes similar to the disliked ones with the
+// matcher that ranks them, compared pairwise.
+func newNegativePreferences(exclusions parameters.Exclusions, disliked []models.Perfume, matcher matching.Matcher, cm cm.ConfigManager) *negativePreferences {
+	return &negativePreferences{
+		notes:    set.MakeSet(lowerAll(exclusions.Notes)),
+		tags:     set.MakeSet(lowerAll(exclusions.Tags)),
+		disliked: disliked,
+		matcher:  pairwiseMatcher(matcher, cm),
+		weights:  configWeights(cm),
+		penalty:  cm.GetFloatWithDefault("dislike_penalty", 0.5),
+	}
+}
+
+func (p *negativePreferences) rejects(perfume models.Perfume) bool {
+	if p == nil {
+		return false
+	}
+	for _, disliked := range p.disliked {
+		if disliked.Equal(perfume) {
+			return true
+		}
+	}
+	if len(p.notes) > 0 {
+		for _, notes := range [][]string{perfume.Properties.UpperNotes, perfume.Properties.CoreNotes, perfume.Properties.BaseNotes} {
+			for _, note := range notes {
+				if _, ok := p.notes[strings.ToLower(note)]; ok {
+					return true
+				}
+			}
+		}
+	}
+	if len(p.tags) > 0 {
+		for tag := range matching.CalculatePerfumeTags(&perfume.Properties, p.weights) {
+			if _, ok := p.tags[strings.ToLower(tag)]; ok {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func (p *negativePreferences) penalize(score float64, perfume models.Properties) float64 {
+	if p == nil || len(p.disliked) == 0 {
+		return score
+	}
+	maxSimilarity := 0.0
+	for _, disliked := range p.disliked {
+		maxSimilarity = max(maxSimilarity, p.matcher.GetSimilarityScore(disliked.Properties, perfume))
+	}
+	return score - p.penalty*maxSimilarity
+}
+
+func (a *Common) resolveNegativePreferences(ctx context.Context, exclusions parameters.Exclusions) *negativePreferences {
+	if exclusions.IsEmpty() {
+		return nil
+	}
+	disliked := make([]models.Perfume, 0, len(exclusions.Disliked))
+	if len(exclusions.Disliked) > 0 {
+		for perfume := range a.fetcher.FetchMany(ctx, exclusions.Disliked) {
+			if perfume.Properties.UpperCharacteristics == nil {
+				matching.PreparePerfumeCharacteristics(&perfume)
+			}
+			disliked = append(disliked, perfume)
+		}
+		if len(disliked) < len(exclusions.Disliked) {
+			log.Printf("Found %d of %d disliked perfumes\n", len(disliked), len(exclusions.Disliked))
+		}
+	}
+	return newNegativePreferences(exclusions, disliked, a.matcher, a.cm)
+}
+
+func configWeights(cm cm.ConfigManager) matching.Weights {
+	return *matching.NewWeights(
+		cm.GetFloatWithDefault("family_weight", 0.4),
+		cm.GetFloatWithDefault("notes_weight", 0.55),
+		cm.GetFloatWithDefault("type_weight", 0.05),
+		cm.GetFloatWithDefault("upper_notes_weight", 0.2),
+		cm.GetFloatWithDefault("core_notes_weight", 0.35),
+		cm.GetFloatWithDefault("base_notes_weight", 0.45),
+		cm.GetFloatWithDefault("characteristics_weight", 0.3),
+		cm.GetFloatWithDefault("tags_weight", 0.5),
+		cm.GetFloatWithDefault("overlay_weight", 0.2),
+	)
+}
+
+func lowerAll(items []string) []string {
+	lowered := make([]string, len(items))
+	for i, item := range items {
+		lowered[i] = strings.ToLower(strings.TrimSpace(item))
+	}
+	return lowered
+}
@@ -0,0 +1,253 @@
+package advising
+
+import (
+	"context"
+	"slices"
+	"testing"
+
+	"github.com/zemld/Scently/models"
+	"github.com/zemld/Scently/perfumist/internal/config"
+	"github.com/zemld/Scently/perfumist/internal/models/matching"
+	"github.com/zemld/Scently/perfumist/internal/models/parameters"
+)
+
+var preferencesCatalog = []models.Perfume{
+	{
+		Brand: "Tom Ford",
+		Name:  "Oud Wood",
+		Sex:   "unisex",
+		Properties: models.Properties{
+			CoreNotes:         []string{"Oud"},
+			EnrichedCoreNotes: []models.EnrichedNote{{Name: "Oud", Tags: []string{"woody", "smoky"}, Characteristics: []models.NoteCharacteristic{{Name: "woodiness", Value: 1}}}},
+		},
+	},
+	{
+		Brand: "Kilian",
+		Name:  "Angels' Share",
+		Sex:   "unisex",
+		Properties: models.Properties{
+			CoreNotes:         []string{"Cognac"},
+			EnrichedCoreNotes: []models.EnrichedNote{{Name: "Cognac", Tags: []string{"sweet", "warm"}, Characteristics: []models.NoteCharacteristic{{Name: "sweetness", Value: 1}}}},
+		},
+	},
+	{
+		Brand: "Le Labo",
+		Name:  "Santal 33",
+		Sex:   "unisex",
+		Properties: models.Properties{
+			CoreNotes:         []string{"Sandalwood"},
+			EnrichedCoreNotes: []models.EnrichedNote{{Name: "Sandalwood", Tags: []string{"woody"}, Characteristics: []models.NoteCharacteristic{{Name: "woodiness", Value: 0.9}, {Name: "warmth", Value: 0.1}}}},
+		},
+	},
+	{
+		Brand: "Acqua di Parma",
+		Name:  "Colonia",
+		Sex:   "unisex",
+		Properties: models.Properties{
+			CoreNotes:         []string{"Lavender"},
+			EnrichedCoreNotes: []models.EnrichedNote{{Name: "Lavender", Tags: []string{"fresh"}, Characteristics: []models.NoteCharacteristic{{Name: "freshness", Value: 1}}}},
+		},
+	},
+}
+
+func preferencesFetcher(requested *[]parameters.RequestPerfume) *MockFetcher {
+	return &MockFetcher{
+		FetchFunc: func(ctx context.Context, param parameters.RequestPerfume) <-chan models.Perfume {
+			ch := make(chan models.Perfume, len(preferencesCatalog))
+			for _, p := range preferencesCatalog {
+				ch <- p
+			}
+			close(ch)
+			return ch
+		},
+		FetchManyFunc: func(ctx context.Context, params []parameters.RequestPerfume) <-chan models.Perfume {
+			if requested != nil {
+				*requested = params
+			}
+			ch := make(chan models.Perfume, len(preferencesCatalog))
+			for _, p := range preferencesCatalog {
+				for _, param := range params {
+					if canonizedName(p.Brand, p.Name) == canonizedName(param.Brand, param.Name) {
+						ch <- p
+					}
+				}
+			}
+			close(ch)
+			return ch
+		},
+	}
+}
+
+func constantMatcher(score float64) *MockMatcher {
+	return &MockMatcher{
+		GetSimilarityScoreFunc: func(first models.Properties, second models.Properties) float64 {
+			return score
+		},
+	}
+}
+
+func suggestedNames(suggested []models.Ranked) map[string]float64 {
+	names := make(map[string]float64)
+	for _, s := range suggested {
+		names[s.Perfume.Name] = s.Score
+	}
+	return names
+}
+
+func TestCommon_Advise_ExcludedNotes(t *testing.T) {
+	t.Parallel()
+
+	common := NewCommon(preferencesFetcher(nil), constantMatcher(1), &config.MockConfigManager{})
+	params := parameters.NewGet().WithExclusions(parameters.Exclusions{Notes: []string{"oud", " Lavender "}})
+
+	suggested, err := common.Advise(context.Background(), *params)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	names := suggestedNames(suggested)
+	if len(names) != 2 {
+		t.Fatalf("expected 2 suggestions, got %v", names)
+	}
+	if _, ok := names["Oud Wood"]; ok {
+		t.Fatal("expected perfume with excluded note to be filtered out")
+	}
+	if _, ok := names["Colonia"]; ok {
+		t.Fatal("expected excluded notes to be matched case-insensitively")
+	}
+}
+
+func TestCommon_Advise_ExcludedTags(t *testing.T) {
+	t.Parallel()
+
+	cm := &config.MockConfigManager{
+		GetFloatWithDefaultFunc: func(key string, defaultValue float64) float64 {
+			if key == "core_notes_weight" {
+				return 1
+			}
+			return defaultValue
+		},
+	}
+	common := NewCommon(preferencesFetcher(nil), constantMatcher(1), cm)
+	params := parameters.NewGet().WithExclusions(parameters.Exclusions{Tags: []string{"woody"}})
+
+	suggested, err := common.Advise(context.Background(), *params)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	names := suggestedNames(suggested)
+	if len(names) != 2 {
+		t.Fatalf("expected 2 suggestions, got %v", names)
+	}
+	if _, ok := names["Oud Wood"]; ok {
+		t.Fatal("expected woody perfume to be filtered out")
+	}
+	if _, ok := names["Santal 33"]; ok {
+		t.Fatal("expected woody perfume to be filtered out")
+	}
+}
+
+func TestCommon_Advise_DislikedPenalty(t *testing.T) {
+	t.Parallel()
+
+	var requested []parameters.RequestPerfume
+	cm := &config.MockConfigManager{
+		GetFloatWithDefaultFunc: func(key string, defaultValue float64) float64 {
+			if key == "dislike_penalty" {
+				return 1
+			}
+			return defaultValue
+		},
+	}
+	// Scores 1 against the empty favourite and compares disliked perfumes by
+	// woody tags, so the penalty has to come from this matcher.
+	matcher := &MockMatcher{
+		GetSimilarityScoreFunc: func(first models.Properties, second models.Properties) float64 {
+			if len(first.EnrichedCoreNotes) == 0 {
+				return 1
+			}
+			if slices.Contains(first.EnrichedCoreNotes[0].Tags, "woody") && slices.Contains(second.EnrichedCoreNotes[0].Tags, "woody") {
+				return 1
+			}
+			return 0
+		},
+	}
+	common := NewCommon(preferencesFetcher(&requested), matcher, cm)
+	params := parameters.NewGet().WithExclusions(parameters.Exclusions{
+		Disliked: []parameters.RequestPerfume{*parameters.NewGet().WithBrand("Tom Ford").WithName("Oud Wood")},
+	})
+
+	suggested, err := common.Advise(context.Background(), *params)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if len(requested) != 1 || requested[0].Name != "Oud Wood" {
+		t.Fatalf("expected disliked perfume to be fetched, got %v", requested)
+	}
+	names := suggestedNames(suggested)
+	if _, ok := names["Oud Wood"]; ok {
+		t.Fatal("expected disliked perfume itself to be excluded")
+	}
+	if names["Colonia"] != 1 || names["Angels' Share"] != 1 {
+		t.Fatalf("expected unrelated perfumes to keep their score, got %v", names)
+	}
+	if score := names["Santal 33"]; score > 0.05 {
+		t.Fatalf("expected perfume similar to the disliked one to be penalized, got %f", score)
+	}
+}
+
+func TestCommon_Advise_UnknownDislikedIgnored(t *testing.T) {
+	t.Parallel()
+
+	common := NewCommon(preferencesFetcher(nil), constantMatcher(1), &config.MockConfigManager{})
+	params := parameters.NewGet().WithExclusions(parameters.Exclusions{
+		Disliked: []parameters.RequestPerfume{*parameters.NewGet().WithBrand("Unknown").WithName("Perfume")},
+	})
+
+	suggested, err := common.Advise(context.Background(), *params)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	for _, s := range suggested {
+		if s.Score != 1 {
+			t.Fatalf("expected no penalty, got %f for %s", s.Score, s.Perfume.Name)
+		}
+	}
+}
+
+func TestExplain_DislikePenaltyComponent(t *testing.T) {
+	t.Parallel()
+
+	cm := &config.MockConfigManager{
+		GetFloatWithDefaultFunc: func(key string, defaultValue float64) float64 {
+			if key == "dislike_penalty" {
+				return 1
+			}
+			return defaultValue
+		},
+	}
+	advisor := NewBase(preferencesFetcher(nil), matching.NewCombinedMatcher(configWeights(cm)), cm)
+	params := parameters.NewGet().WithBrand("Kilian").WithName("Angels' Share").WithExclusions(parameters.Exclusions{
+		Disliked: []parameters.RequestPerfume{*parameters.NewGet().WithBrand("Tom Ford").WithName("Oud Wood")},
+	})
+
+	explained, err := advisor.AdviseWithExplanations(context.Background(), *params)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	found := false
+	for _, e := range explained {
+		if e.Explanation.Score != e.Score {
+			t.Fatalf("expected explanation score %f to match %f", e.Explanation.Score, e.Score)
+		}
+		last := e.Explanation.Components[len(e.Explanation.Components)-1]
+		if e.Perfume.Name == "Santal 33" {
+			found = true
+			if last.Name != "dislike" || last.Contribution >= 0 {
+				t.Fatalf("expected negative dislike component, got %+v", last)
+			}
+		}
+	}
+	if !found {
+		t.Fatal("expected Santal 33 among suggestions")
+	}
+}
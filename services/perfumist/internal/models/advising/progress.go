@@ -0,0 +1,70 @@
+package advising
+
+import (
+	"context"
+	"sort"
+
+	"github.com/zemld/Scently/models"
+	"github.com/zemld/Scently/perfumist/internal/models/matching"
+)
+
+const (
+	ProgressEvent   = "progress"
+	PartialEvent    = "partial"
+	SuggestionEvent = "suggestion"
+)
+
+// Update is a provisional state of a running request. Progress updates carry
+// the number of processed perfumes, partial ones the current top and
+// suggestion ones a single suggestion that is ready.
+type Update struct {
+	Event     string          `json:"-"`
+	Processed int             `json:"processed,omitempty"`
+	Suggested []models.Ranked `json:"suggested,omitempty"`
+}
+
+type ProgressFunc func(Update)
+
+type progressKey struct{}
+
+// WithProgress makes advisors report updates to progress while they work. It
+// may be called from different goroutines.
+func WithProgress(ctx context.Context, progress ProgressFunc) context.Context {
+	return context.WithValue(ctx, progressKey{}, progress)
+}
+
+func progressFromContext(ctx context.Context) (ProgressFunc, bool) {
+	progress, ok := ctx.Value(progressKey{}).(ProgressFunc)
+	return progress, ok && progress != nil
+}
+
+func reportProgress(ctx context.Context, update Update) {
+	if progress, ok := progressFromContext(ctx); ok {
+		progress(update)
+	}
+}
+
+// provisionalTop returns the best count perfumes of h without popping them.
+func provisionalTop(h *matching.PerfumeHeap, count int) []models.Ranked {
+	top := append([]models.Ranked(nil), h.GetPerfumes()...)
+	sort.SliceStable(top, func(i, j int) bool {
+		return top[i].Score > top[j].Score
+	})
+	top = top[:min(count, len(top))]
+	for i := range top {
+		top[i].Rank = i + 1
+	}
+	return top
+}
+
+func sameRanking(first []models.Ranked, second []models.Ranked) bool {
+	if len(first) != len(second) {
+		return false
+	}
+	for i := range first {
+		if !first[i].Perfume.Equal(second[i].Perfume) {
+			return false
+		}
+	}
+	return true
+}
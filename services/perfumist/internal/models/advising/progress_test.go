@@ -0,0 +1,150 @@
+package advising
+
+import (
+	"context"
+	"strconv"
+	"sync"
+	"testing"
+
+	"github.com/zemld/Scently/models"
+	"github.com/zemld/Scently/perfumist/internal/config"
+	"github.com/zemld/Scently/perfumist/internal/models/parameters"
+)
+
+type updatesRecorder struct {
+	mu      sync.Mutex
+	updates []Update
+}
+
+func (r *updatesRecorder) record(update Update) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.updates = append(r.updates, update)
+}
+
+func (r *updatesRecorder) byEvent(event string) []Update {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	updates := make([]Update, 0)
+	for _, update := range r.updates {
+		if update.Event == event {
+			updates = append(updates, update)
+		}
+	}
+	return updates
+}
+
+func streamingConfig() *config.MockConfigManager {
+	return &config.MockConfigManager{
+		GetIntWithDefaultFunc: func(key string, defaultValue int) int {
+			switch key {
+			case "suggest_count":
+				return 2
+			case "threads_count":
+				return 1
+			case "stream_batch_size":
+				return 2
+			}
+			return defaultValue
+		},
+	}
+}
+
+func scoredCatalog(count int) *MockFetcher {
+	return &MockFetcher{
+		FetchFunc: func(ctx context.Context, param parameters.RequestPerfume) <-chan models.Perfume {
+			ch := make(chan models.Perfume)
+			go func() {
+				defer close(ch)
+				for i := 1; i <= count; i++ {
+					ch <- models.Perfume{Brand: "Brand", Name: strconv.Itoa(i), Properties: models.Properties{Type: strconv.Itoa(i)}}
+				}
+			}()
+			return ch
+		},
+	}
+}
+
+func scoreByType() *MockMatcher {
+	return &MockMatcher{GetSimilarityScoreFunc: func(first models.Properties, second models.Properties) float64 {
+		score, _ := strconv.Atoi(second.Type)
+		return float64(score) / 10
+	}}
+}
+
+func TestCommon_Advise_ReportsProgress(t *testing.T) {
+	t.Parallel()
+
+	recorder := &updatesRecorder{}
+	advisor := NewCommon(scoredCatalog(6), scoreByType(), streamingConfig())
+
+	results, err := advisor.Advise(WithProgress(context.Background(), recorder.record), *parameters.NewGet())
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	progress := recorder.byEvent(ProgressEvent)
+	if len(progress) == 0 || progress[len(progress)-1].Processed != 6 {
+		t.Fatalf("expected progress to end with 6 processed perfumes, got %+v", progress)
+	}
+	partial := recorder.byEvent(PartialEvent)
+	if len(partial) < 2 {
+		t.Fatalf("expected several partial updates, got %+v", partial)
+	}
+	if last := partial[len(partial)-1].Suggested; !sameRanking(last, results) {
+		t.Fatalf("expected last partial update to match results %+v, got %+v", results, last)
+	}
+	for i := 1; i < len(partial); i++ {
+		if sameRanking(partial[i-1].Suggested, partial[i].Suggested) {
+			t.Fatalf("expected partial updates only on changes, got %+v", partial)
+		}
+	}
+}
+
+func TestCommon_Advise_NoProgressWithoutListener(t *testing.T) {
+	t.Parallel()
+
+	results, err := NewCommon(scoredCatalog(6), scoreByType(), streamingConfig()).
+		Advise(WithProgress(context.Background(), nil), *parameters.NewGet())
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if len(results) != 2 || results[0].Perfume.Name != "6" || results[1].Perfume.Name != "5" {
+		t.Fatalf("expected perfumes 6 and 5, got %+v", results)
+	}
+}
+
+func TestAI_Advise_ReportsSuggestions(t *testing.T) {
+	t.Parallel()
+
+	suggestions := []models.Perfume{{Brand: "Chanel", Name: "No5"}, {Brand: "Dior", Name: "Sauvage"}}
+	adviseFetcher := &MockFetcher{FetchFunc: func(ctx context.Context, param parameters.RequestPerfume) <-chan models.Perfume {
+		ch := make(chan models.Perfume, len(suggestions))
+		for _, suggestion := range suggestions {
+			ch <- suggestion
+		}
+		close(ch)
+		return ch
+	}}
+	enrichFetcher := &MockFetcher{FetchManyFunc: func(ctx context.Context, params []parameters.RequestPerfume) <-chan models.Perfume {
+		ch := make(chan models.Perfume, 1)
+		ch <- models.Perfume{Brand: "Dior", Name: "Sauvage", ImageUrl: "http://example.com/sauvage.jpg"}
+		close(ch)
+		return ch
+	}}
+
+	recorder := &updatesRecorder{}
+	_, err := NewAI(adviseFetcher, enrichFetcher, &config.MockConfigManager{}).
+		Advise(WithProgress(context.Background(), recorder.record), *parameters.NewGet().WithBrand("Chanel").WithName("Coco"))
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	reported := recorder.byEvent(SuggestionEvent)
+	if len(reported) != 1 || len(reported[0].Suggested) != 1 {
+		t.Fatalf("expected a single suggestion update, got %+v", reported)
+	}
+	if suggestion := reported[0].Suggested[0]; suggestion.Perfume.Name != "Sauvage" || suggestion.Rank != 2 {
+		t.Fatalf("expected Sauvage ranked 2, got %+v", suggestion)
+	}
+}
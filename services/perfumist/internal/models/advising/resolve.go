@@ -0,0 +1,104 @@
+package advising
+
+import (
+	"github.com/zemld/Scently/models"
+)
+
+// catalogResolver maps perfumes named by the LLM to catalog perfumes. Names are
+// compared canonized and a few typos are tolerated, so "Tobaco Vanile" still
+// resolves to "Tobacco Vanille".
+type catalogResolver struct {
+	byBrand map[string][]resolvable
+}
+
+type resolvable struct {
+	name    string
+	perfume models.Perfume
+}
+
+func newCatalogResolver(perfumes []models.Perfume) *catalogResolver {
+	r := &catalogResolver{byBrand: make(map[string][]resolvable)}
+	for _, perfume := range perfumes {
+		canonized := perfume.Canonize()
+		r.byBrand[canonized.Brand] = append(r.byBrand[canonized.Brand], resolvable{name: canonized.Name, perfume: perfume})
+	}
+	return r
+}
+
+func (r *catalogResolver) resolve(suggestion models.Perfume) (models.Perfume, bool) {
+	canonized := suggestion.Canonize()
+	candidates, ok := r.byBrand[canonized.Brand]
+	if !ok {
+		brand, found := closest(canonized.Brand, r.brands())
+		if !found {
+			return models.Perfume{}, false
+		}
+		candidates = r.byBrand[brand]
+	}
+
+	names := make([]string, len(candidates))
+	for i, candidate := range candidates {
+		if candidate.name == canonized.Name {
+			return candidate.perfume, true
+		}
+		names[i] = candidate.name
+	}
+	name, found := closest(canonized.Name, names)
+	if !found {
+		return models.Perfume{}, false
+	}
+	for _, candidate := range candidates {
+		if candidate.name == name {
+			return candidate.perfume, true
+		}
+	}
+	return models.Perfume{}, false
+}
+
+func (r *catalogResolver) brands() []string {
+	brands := make([]string, 0, len(r.byBrand))
+	for brand := range r.byBrand {
+		brands = append(brands, brand)
+	}
+	return brands
+}
+
+// closest returns the option with the smallest edit distance to the target, if
+// the distance doesn't exceed a fifth of the target length.
+func closest(target string, options []string) (string, bool) {
+	maxDistance := max(1, len([]rune(target))/5)
+	best, bestDistance := "", maxDistance+1
+	for _, option := range options {
+		if distance := editDistance(target, option); distance < bestDistance || (distance == bestDistance && option < best) {
+			best, bestDistance = option, distance
+		}
+	}
+	return best, bestDistance <= maxDistance
+}
+
+// editDistance is the optimal string alignment distance: a swap of two adjacent
+// letters, the most common typo, costs one edit.
+func editDistance(first string, second string) int {
+	a, b := []rune(first), []rune(second)
+	beforePrevious := make([]int, len(b)+1)
+	previous := make([]int, len(b)+1)
+	current := make([]int, len(b)+1)
+	for j := range previous {
+		previous[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		current[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			current[j] = min(previous[j]+1, current[j-1]+1, previous[j-1]+cost)
+			if i > 1 && j > 1 && a[i-1] == b[j-2] && a[i-2] == b[j-1] {
+				current[j] = min(current[j], beforePrevious[j-2]+1)
+			}
+		}
+		beforePrevious, previous, current = previous, current, beforePrevious
+	}
+	return previous[len(b)]
+}
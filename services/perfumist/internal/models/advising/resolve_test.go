@@ -0,0 +1,50 @@
+package advising
+
+import (
+	"testing"
+
+	"github.com/zemld/Scently/models"
+)
+
+func TestCatalogResolver_Resolve(t *testing.T) {
+	t.Parallel()
+
+	resolver := newCatalogResolver(multiCatalog)
+	for _, tc := range []struct {
+		suggestion models.Perfume
+		expected   string
+		found      bool
+	}{
+		{models.Perfume{Brand: "Le Labo", Name: "Santal 33"}, "Santal 33", true},
+		{models.Perfume{Brand: "le-labo", Name: "SANTAL33"}, "Santal 33", true},
+		{models.Perfume{Brand: "Tom Frod", Name: "Tobaco Vanile"}, "Tobacco Vanille", true},
+		{models.Perfume{Brand: "Le Labo", Name: "Another 13"}, "", false},
+		{models.Perfume{Brand: "Creed", Name: "Aventus"}, "", false},
+	} {
+		perfume, ok := resolver.resolve(tc.suggestion)
+		if ok != tc.found || perfume.Name != tc.expected {
+			t.Fatalf("%+v: expected %q (%v), got %q (%v)", tc.suggestion, tc.expected, tc.found, perfume.Name, ok)
+		}
+	}
+}
+
+func TestEditDistance(t *testing.T) {
+	t.Parallel()
+
+	for _, tc := range []struct {
+		first    string
+		second   string
+		expected int
+	}{
+		{"", "", 0},
+		{"sauvage", "sauvage", 0},
+		{"tobacovanile", "tobaccovanille", 2},
+		{"kitten", "sitting", 3},
+		{"tomfrod", "tomford", 1},
+		{"", "abc", 3},
+	} {
+		if distance := editDistance(tc.first, tc.second); distance != tc.expected {
+			t.Fatalf("%q, %q: expected %d, got %d", tc.first, tc.second, tc.expected, distance)
+		}
+	}
+}
@@ -24,3 +24,11 @@ func (a *TagsBased) Advise(ctx context.Context, params parameters.RequestPerfume
 	common := NewCommon(a.fetcher, a.matcher, a.cm)
 	return common.Advise(ctx, params)
 }
+
+func (a *TagsBased) AdviseWithExplanations(ctx context.Context, params parameters.RequestPerfume) ([]Explained, error) {
+	suggested, err := a.Advise(ctx, params)
+	if err != nil {
+		return nil, err
+	}
+	return explain(a.matcher, models.Properties{}, suggested), nil
+}
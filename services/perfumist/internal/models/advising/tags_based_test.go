@@ -404,3 +404,44 @@ func TestTagsBased_Advise_VerifyFetchParams(t *testing.T) {
 		t.Fatalf("expected sex 'female' to be fetched, got %q", fetchedSex)
 	}
 }
+
+func TestTagsBased_Advise_SkipsRejectedPerfumes(t *testing.T) {
+	t.Parallel()
+
+	perfumes := []models.Perfume{
+		{
+			Brand: "Dior",
+			Name:  "J'adore",
+			Properties: models.Properties{
+				EnrichedCoreNotes: []models.EnrichedNote{{Name: "Rose", Tags: []string{"floral"}}},
+			},
+		},
+		{
+			Brand: "Tom Ford",
+			Name:  "Oud Wood",
+			Properties: models.Properties{
+				EnrichedBaseNotes: []models.EnrichedNote{{Name: "Oud", Tags: []string{"woody"}}},
+			},
+		},
+	}
+	fetcher := &MockFetcher{
+		FetchFunc: func(ctx context.Context, param parameters.RequestPerfume) <-chan models.Perfume {
+			ch := make(chan models.Perfume, len(perfumes))
+			for _, p := range perfumes {
+				ch <- p
+			}
+			close(ch)
+			return ch
+		},
+	}
+	matcher := matching.NewWeightedTagsBasedAdapter(*matching.NewBaseWeights(1, 1, 1), map[string]int{"floral": 3, "woody": 1}).
+		WithRequiredTags([]string{"woody"})
+
+	result, err := NewTagsBased(matcher, fetcher, &config.MockConfigManager{}).Advise(context.Background(), parameters.RequestPerfume{})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if len(result) != 1 || result[0].Perfume.Name != "Oud Wood" {
+		t.Fatalf("expected only the perfume with the required tag, got %+v", result)
+	}
+}
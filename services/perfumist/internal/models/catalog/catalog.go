@@ -0,0 +1,245 @@
+package catalog
+
+import (
+	"context"
+	"log"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/zemld/Scently/models"
+	"github.com/zemld/Scently/perfumist/internal/errors"
+	"github.com/zemld/Scently/perfumist/internal/models/fetching"
+	"github.com/zemld/Scently/perfumist/internal/models/matching"
+	"github.com/zemld/Scently/perfumist/internal/models/parameters"
+	"github.com/zemld/config-manager/pkg/cm"
+)
+
+type SourceFunc func() (fetching.Fetcher, error)
+
+type Snapshot struct {
+	Version  uint64
+	LoadedAt time.Time
+	size     int
+	perfumes map[models.Sex][]models.Perfume
+	indexes  map[models.Sex]*matching.CharacteristicsIndex
+}
+
+func NewSnapshot(version uint64, perfumes []models.Perfume, options matching.IndexOptions) *Snapshot {
+	bySex := make(map[models.Sex][]models.Perfume)
+	for _, perfume := range perfumes {
+		bySex[perfume.Sex] = append(bySex[perfume.Sex], perfume)
+	}
+
+	s := &Snapshot{
+		Version:  version,
+		LoadedAt: time.Now(),
+		size:     len(perfumes),
+		perfumes: make(map[models.Sex][]models.Perfume),
+		indexes:  make(map[models.Sex]*matching.CharacteristicsIndex),
+	}
+	for _, sex := range []models.Sex{models.Unisex, models.Male, models.Female} {
+		view := make([]models.Perfume, 0, len(bySex[models.Unisex])+len(bySex[sex]))
+		view = append(view, bySex[models.Unisex]...)
+		if sex != models.Unisex {
+			view = append(view, bySex[sex]...)
+		}
+		s.perfumes[sex] = view
+		s.indexes[sex] = matching.NewCharacteristicsIndex(view, options)
+	}
+	return s
+}
+
+func (s *Snapshot) Perfumes(sex models.Sex) []models.Perfume {
+	return s.perfumes[viewSex(sex)]
+}
+
+func (s *Snapshot) Candidates(sex models.Sex, favourite models.Properties, count int) []models.Perfume {
+	view := s.perfumes[viewSex(sex)]
+	ids := s.indexes[viewSex(sex)].Search(favourite, count)
+	candidates := make([]models.Perfume, len(ids))
+	for i, id := range ids {
+		candidates[i] = view[id]
+	}
+	return candidates
+}
+
+func (s *Snapshot) Len() int {
+	return s.size
+}
+
+func viewSex(sex models.Sex) models.Sex {
+	if sex != models.Male && sex != models.Female {
+		return models.Unisex
+	}
+	return sex
+}
+
+type Catalog struct {
+	source   SourceFunc
+	cm       cm.ConfigManager
+	snapshot atomic.Pointer[Snapshot]
+	version  atomic.Uint64
+
+	loadMu sync.Mutex
+	stop   chan struct{}
+	wg     sync.WaitGroup
+}
+
+func NewCatalog(source SourceFunc, cm cm.ConfigManager) *Catalog {
+	return &Catalog{source: source, cm: cm}
+}
+
+func (c *Catalog) Snapshot() *Snapshot {
+	return c.snapshot.Load()
+}
+
+func (c *Catalog) Load(ctx context.Context) error {
+	c.loadMu.Lock()
+	defer c.loadMu.Unlock()
+
+	source, err := c.source()
+	if err != nil {
+		return err
+	}
+
+	perfumes := make([]models.Perfume, 0)
+	for _, sex := range []models.Sex{models.Male, models.Female} {
+		fetched, err := fetchAll(ctx, source, *parameters.NewGet().WithSex(sex))
+		if err != nil {
+			return errors.NewServiceError("catalog loading failed", err)
+		}
+		for _, perfume := range fetched {
+			if sex == models.Female && perfume.Sex != models.Female {
+				continue
+			}
+			matching.PreparePerfumeCharacteristics(&perfume)
+			perfumes = append(perfumes, perfume)
+		}
+	}
+	if ctx.Err() != nil {
+		return errors.NewServiceError("catalog loading interrupted", ctx.Err())
+	}
+	if len(perfumes) == 0 {
+		return errors.NewServiceError("catalog is empty", nil)
+	}
+
+	snapshot := NewSnapshot(c.version.Add(1), perfumes, c.indexOptions())
+	c.snapshot.Store(snapshot)
+	log.Printf("Catalog snapshot %d loaded: %d perfumes\n", snapshot.Version, snapshot.Len())
+	return nil
+}
+
+// fetchAll prefers sources that report an incomplete transfer, so that a broken
+// one doesn't replace the snapshot with part of the catalog.
+func fetchAll(ctx context.Context, source fetching.Fetcher, parameter parameters.RequestPerfume) ([]models.Perfume, error) {
+	if catalogFetcher, ok := source.(fetching.CatalogFetcher); ok {
+		return catalogFetcher.FetchCatalog(ctx, parameter)
+	}
+	perfumes := make([]models.Perfume, 0)
+	for perfume := range source.Fetch(ctx, parameter) {
+		perfumes = append(perfumes, perfume)
+	}
+	return perfumes, nil
+}
+
+func (c *Catalog) indexOptions() matching.IndexOptions {
+	return matching.NewIndexOptions(
+		c.cm.GetIntWithDefault("ann_trees_count", 8),
+		c.cm.GetIntWithDefault("ann_leaf_size", 16),
+	).WithSearchFactor(c.cm.GetIntWithDefault("ann_search_factor", 4))
+}
+
+func (c *Catalog) StartRefreshing() {
+	c.stop = make(chan struct{})
+	if err := c.loadWithTimeout(); err != nil {
+		log.Printf("Cannot warm catalog: %v\n", err)
+	}
+
+	c.wg.Add(1)
+	go func() {
+		defer c.wg.Done()
+		for {
+			timer := time.NewTimer(c.cm.GetDurationWithDefault("catalog_refresh_interval", 5*time.Minute))
+			select {
+			case <-c.stop:
+				timer.Stop()
+				return
+			case <-timer.C:
+				if err := c.loadWithTimeout(); err != nil {
+					log.Printf("Cannot refresh catalog: %v\n", err)
+				}
+			}
+		}
+	}()
+}
+
+func (c *Catalog) StopRefreshing() {
+	if c.stop == nil {
+		return
+	}
+	close(c.stop)
+	c.wg.Wait()
+	c.stop = nil
+}
+
+func (c *Catalog) loadWithTimeout() error {
+	ctx, cancel := context.WithTimeout(context.Background(), c.cm.GetDurationWithDefault("catalog_load_timeout", time.Minute))
+	defer cancel()
+	return c.Load(ctx)
+}
+
+func (c *Catalog) Fetch(ctx context.Context, parameter parameters.RequestPerfume) <-chan models.Perfume {
+	snapshot := c.Snapshot()
+	if parameter.Brand != "" || parameter.Name != "" || snapshot == nil {
+		return c.fetchFromSource(ctx, parameter)
+	}
+	return sendPerfumes(ctx, snapshot.Perfumes(parameter.Sex))
+}
+
+func (c *Catalog) FetchCandidates(ctx context.Context, parameter parameters.RequestPerfume, favourite models.Properties, count int) <-chan models.Perfume {
+	snapshot := c.Snapshot()
+	if snapshot == nil {
+		return c.Fetch(ctx, parameter)
+	}
+	return sendPerfumes(ctx, snapshot.Candidates(parameter.Sex, favourite, count))
+}
+
+func (c *Catalog) FetchMany(ctx context.Context, params []parameters.RequestPerfume) <-chan models.Perfume {
+	source, err := c.source()
+	if err != nil {
+		log.Printf("Cannot create catalog source: %v\n", err)
+		return closedChan()
+	}
+	return source.FetchMany(ctx, params)
+}
+
+func (c *Catalog) fetchFromSource(ctx context.Context, parameter parameters.RequestPerfume) <-chan models.Perfume {
+	source, err := c.source()
+	if err != nil {
+		log.Printf("Cannot create catalog source: %v\n", err)
+		return closedChan()
+	}
+	return source.Fetch(ctx, parameter)
+}
+
+func sendPerfumes(ctx context.Context, perfumes []models.Perfume) <-chan models.Perfume {
+	perfumesChan := make(chan models.Perfume)
+	go func() {
+		defer close(perfumesChan)
+		for _, perfume := range perfumes {
+			select {
+			case <-ctx.Done():
+				return
+			case perfumesChan <- perfume:
+			}
+		}
+	}()
+	return perfumesChan
+}
+
+func closedChan() <-chan models.Perfume {
+	perfumesChan := make(chan models.Perfume)
+	close(perfumesChan)
+	return perfumesChan
+}
@@ -0,0 +1,324 @@
+package catalog
+
+import (
+	"context"
+	"testing"
+
+	"github.com/zemld/Scently/models"
+	"github.com/zemld/Scently/perfumist/internal/config"
+	"github.com/zemld/Scently/perfumist/internal/errors"
+	"github.com/zemld/Scently/perfumist/internal/models/fetching"
+	"github.com/zemld/Scently/perfumist/internal/models/parameters"
+)
+
+type MockFetcher struct {
+	FetchFunc     func(ctx context.Context, param parameters.RequestPerfume) <-chan models.Perfume
+	FetchManyFunc func(ctx context.Context, params []parameters.RequestPerfume) <-chan models.Perfume
+}
+
+func (m *MockFetcher) Fetch(ctx context.Context, param parameters.RequestPerfume) <-chan models.Perfume {
+	if m.FetchFunc != nil {
+		return m.FetchFunc(ctx, param)
+	}
+	return closedChan()
+}
+
+func (m *MockFetcher) FetchMany(ctx context.Context, params []parameters.RequestPerfume) <-chan models.Perfume {
+	if m.FetchManyFunc != nil {
+		return m.FetchManyFunc(ctx, params)
+	}
+	return closedChan()
+}
+
+// MockCatalogFetcher is a source that reports broken transfers.
+type MockCatalogFetcher struct {
+	MockFetcher
+	FetchCatalogFunc func(ctx context.Context, param parameters.RequestPerfume) ([]models.Perfume, error)
+}
+
+func (m *MockCatalogFetcher) FetchCatalog(ctx context.Context, param parameters.RequestPerfume) ([]models.Perfume, error) {
+	return m.FetchCatalogFunc(ctx, param)
+}
+
+var testPerfumes = []models.Perfume{
+	{
+		Brand: "Dior",
+		Name:  "Sauvage",
+		Sex:   models.Male,
+		Properties: models.Properties{
+			EnrichedUpperNotes: []models.EnrichedNote{
+				{Name: "Bergamot", Characteristics: []models.NoteCharacteristic{{Name: "freshness", Value: 0.9}}},
+			},
+		},
+	},
+	{Brand: "Chanel", Name: "No5", Sex: models.Female},
+	{Brand: "Le Labo", Name: "Santal 33", Sex: models.Unisex},
+}
+
+// hubFetcher mimics perfume-hub: a sex filter returns the matching sex plus unisex perfumes.
+func hubFetcher() *MockFetcher {
+	return &MockFetcher{
+		FetchFunc: func(ctx context.Context, param parameters.RequestPerfume) <-chan models.Perfume {
+			var perfumes []models.Perfume
+			for _, p := range testPerfumes {
+				if p.Sex == models.Unisex || p.Sex == param.Sex {
+					perfumes = append(perfumes, p)
+				}
+			}
+			return sendPerfumes(ctx, perfumes)
+		},
+	}
+}
+
+func collect(ch <-chan models.Perfume) []models.Perfume {
+	var perfumes []models.Perfume
+	for p := range ch {
+		perfumes = append(perfumes, p)
+	}
+	return perfumes
+}
+
+func TestCatalog_Load_SplitsBySex(t *testing.T) {
+	t.Parallel()
+
+	c := NewCatalog(func() (fetching.Fetcher, error) { return hubFetcher(), nil }, &config.MockConfigManager{})
+	if err := c.Load(context.Background()); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	snapshot := c.Snapshot()
+	if snapshot == nil {
+		t.Fatal("expected snapshot to be loaded")
+	}
+	if snapshot.Version != 1 {
+		t.Fatalf("expected version 1, got %d", snapshot.Version)
+	}
+	if snapshot.Len() != len(testPerfumes) {
+		t.Fatalf("expected %d perfumes, got %d", len(testPerfumes), snapshot.Len())
+	}
+	if got := len(snapshot.Perfumes(models.Male)); got != 2 {
+		t.Fatalf("expected 2 perfumes for male, got %d", got)
+	}
+	if got := len(snapshot.Perfumes(models.Female)); got != 2 {
+		t.Fatalf("expected 2 perfumes for female, got %d", got)
+	}
+	if got := len(snapshot.Perfumes(models.Unisex)); got != 1 {
+		t.Fatalf("expected 1 perfume for unisex, got %d", got)
+	}
+}
+
+func TestCatalog_Load_PreparesCharacteristics(t *testing.T) {
+	t.Parallel()
+
+	c := NewCatalog(func() (fetching.Fetcher, error) { return hubFetcher(), nil }, &config.MockConfigManager{})
+	if err := c.Load(context.Background()); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	for _, p := range c.Snapshot().Perfumes(models.Male) {
+		if p.Properties.UpperCharacteristics == nil {
+			t.Fatalf("expected prepared characteristics for %s %s", p.Brand, p.Name)
+		}
+		if p.Name == "Sauvage" && p.Properties.UpperCharacteristics["freshness"] != 0.9 {
+			t.Fatalf("expected freshness 0.9, got %v", p.Properties.UpperCharacteristics["freshness"])
+		}
+	}
+}
+
+func TestCatalog_Load_IncrementsVersion(t *testing.T) {
+	t.Parallel()
+
+	c := NewCatalog(func() (fetching.Fetcher, error) { return hubFetcher(), nil }, &config.MockConfigManager{})
+	for range 3 {
+		if err := c.Load(context.Background()); err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+	}
+	if c.Snapshot().Version != 3 {
+		t.Fatalf("expected version 3, got %d", c.Snapshot().Version)
+	}
+}
+
+func TestCatalog_Load_EmptyKeepsPreviousSnapshot(t *testing.T) {
+	t.Parallel()
+
+	empty := false
+	c := NewCatalog(func() (fetching.Fetcher, error) {
+		if empty {
+			return &MockFetcher{}, nil
+		}
+		return hubFetcher(), nil
+	}, &config.MockConfigManager{})
+	if err := c.Load(context.Background()); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	empty = true
+	err := c.Load(context.Background())
+	if err == nil {
+		t.Fatal("expected error for empty catalog")
+	}
+	if _, ok := err.(*errors.ServiceError); !ok {
+		t.Fatalf("expected ServiceError, got %T", err)
+	}
+	if c.Snapshot().Version != 1 || c.Snapshot().Len() != len(testPerfumes) {
+		t.Fatal("expected previous snapshot to be kept")
+	}
+}
+
+func TestCatalog_Load_BrokenTransferKeepsPreviousSnapshot(t *testing.T) {
+	t.Parallel()
+
+	broken := false
+	c := NewCatalog(func() (fetching.Fetcher, error) {
+		if !broken {
+			return hubFetcher(), nil
+		}
+		return &MockCatalogFetcher{
+			MockFetcher: *hubFetcher(),
+			FetchCatalogFunc: func(ctx context.Context, param parameters.RequestPerfume) ([]models.Perfume, error) {
+				return nil, errors.NewServiceError("perfumes stream broken after 1 perfumes", nil)
+			},
+		}, nil
+	}, &config.MockConfigManager{})
+	if err := c.Load(context.Background()); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	broken = true
+	if err := c.Load(context.Background()); err == nil {
+		t.Fatal("expected error for broken transfer")
+	}
+	if c.Snapshot().Version != 1 || c.Snapshot().Len() != len(testPerfumes) {
+		t.Fatal("expected previous snapshot to be kept")
+	}
+}
+
+func TestCatalog_Load_SourceError(t *testing.T) {
+	t.Parallel()
+
+	c := NewCatalog(func() (fetching.Fetcher, error) {
+		return nil, errors.NewServiceError("failed to get get_perfumes_url", nil)
+	}, &config.MockConfigManager{})
+	if err := c.Load(context.Background()); err == nil {
+		t.Fatal("expected error from source")
+	}
+	if c.Snapshot() != nil {
+		t.Fatal("expected no snapshot")
+	}
+}
+
+func TestCatalog_Fetch_AllFromSnapshot(t *testing.T) {
+	t.Parallel()
+
+	calls := 0
+	c := NewCatalog(func() (fetching.Fetcher, error) {
+		calls++
+		return hubFetcher(), nil
+	}, &config.MockConfigManager{})
+	if err := c.Load(context.Background()); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	perfumes := collect(c.Fetch(context.Background(), *parameters.NewGet().WithSex(models.Female)))
+	if len(perfumes) != 2 {
+		t.Fatalf("expected 2 perfumes, got %d", len(perfumes))
+	}
+	if calls != 1 {
+		t.Fatalf("expected source to be used only for loading, got %d calls", calls)
+	}
+}
+
+func TestCatalog_Fetch_ConcretePerfumeFromSource(t *testing.T) {
+	t.Parallel()
+
+	var requested parameters.RequestPerfume
+	source := &MockFetcher{
+		FetchFunc: func(ctx context.Context, param parameters.RequestPerfume) <-chan models.Perfume {
+			requested = param
+			return sendPerfumes(ctx, testPerfumes[:1])
+		},
+	}
+	c := NewCatalog(func() (fetching.Fetcher, error) { return source, nil }, &config.MockConfigManager{})
+
+	perfumes := collect(c.Fetch(context.Background(), *parameters.NewGet().WithBrand("Dior").WithName("Sauvage")))
+	if len(perfumes) != 1 {
+		t.Fatalf("expected 1 perfume, got %d", len(perfumes))
+	}
+	if requested.Brand != "Dior" || requested.Name != "Sauvage" {
+		t.Fatalf("expected request to be passed to source, got %+v", requested)
+	}
+}
+
+func TestCatalog_Fetch_WithoutSnapshotFallsBackToSource(t *testing.T) {
+	t.Parallel()
+
+	c := NewCatalog(func() (fetching.Fetcher, error) { return hubFetcher(), nil }, &config.MockConfigManager{})
+
+	perfumes := collect(c.Fetch(context.Background(), *parameters.NewGet().WithSex(models.Male)))
+	if len(perfumes) != 2 {
+		t.Fatalf("expected 2 perfumes, got %d", len(perfumes))
+	}
+}
+
+func TestCatalog_Fetch_ContextCancelled(t *testing.T) {
+	t.Parallel()
+
+	c := NewCatalog(func() (fetching.Fetcher, error) { return hubFetcher(), nil }, &config.MockConfigManager{})
+	if err := c.Load(context.Background()); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	perfumes := collect(c.Fetch(ctx, *parameters.NewGet().WithSex(models.Male)))
+	if len(perfumes) > 2 {
+		t.Fatalf("expected at most 2 perfumes, got %d", len(perfumes))
+	}
+}
+
+func TestCatalog_StartStopRefreshing(t *testing.T) {
+	t.Parallel()
+
+	c := NewCatalog(func() (fetching.Fetcher, error) { return hubFetcher(), nil }, &config.MockConfigManager{})
+	c.StartRefreshing()
+	defer c.StopRefreshing()
+
+	if c.Snapshot() == nil {
+		t.Fatal("expected catalog to be warmed on start")
+	}
+}
+
+func TestCatalog_FetchCandidates_FromIndex(t *testing.T) {
+	t.Parallel()
+
+	c := NewCatalog(func() (fetching.Fetcher, error) { return hubFetcher(), nil }, &config.MockConfigManager{})
+	if err := c.Load(context.Background()); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	favourite := models.Properties{UpperCharacteristics: map[string]float64{"freshness": 1}}
+	perfumes := collect(c.FetchCandidates(context.Background(), *parameters.NewGet().WithSex(models.Male), favourite, 1))
+	if len(perfumes) == 0 {
+		t.Fatal("expected candidates")
+	}
+	if perfumes[0].Name != "Sauvage" {
+		t.Fatalf("expected Sauvage to be the nearest candidate, got %s", perfumes[0].Name)
+	}
+	for _, p := range perfumes {
+		if p.Sex == models.Female {
+			t.Fatalf("unexpected female perfume %s in male candidates", p.Name)
+		}
+	}
+}
+
+func TestCatalog_FetchCandidates_WithoutSnapshotFallsBackToSource(t *testing.T) {
+	t.Parallel()
+
+	c := NewCatalog(func() (fetching.Fetcher, error) { return hubFetcher(), nil }, &config.MockConfigManager{})
+
+	perfumes := collect(c.FetchCandidates(context.Background(), *parameters.NewGet().WithSex(models.Female), models.Properties{}, 1))
+	if len(perfumes) != 2 {
+		t.Fatalf("expected full female catalog, got %d perfumes", len(perfumes))
+	}
+}
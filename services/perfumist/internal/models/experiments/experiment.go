@@ -0,0 +1,104 @@
+package experiments
+
+import (
+	"context"
+	"fmt"
+	"hash/fnv"
+	"strconv"
+	"strings"
+)
+
+type contextKey string
+
+const assignmentKey contextKey = "assignment"
+
+type Variant struct {
+	Name  string
+	Share int
+}
+
+type Experiment struct {
+	Name       string
+	Variants   []Variant
+	totalShare int
+}
+
+// NewExperiment parses variants in the "control:50,treatment:50" form. Shares are
+// relative, so they don't have to sum up to 100.
+func NewExperiment(name string, rawVariants string) (*Experiment, error) {
+	if name == "" {
+		return nil, fmt.Errorf("experiment name is empty")
+	}
+
+	experiment := &Experiment{Name: name}
+	seen := make(map[string]bool)
+	for _, rawVariant := range strings.Split(rawVariants, ",") {
+		rawVariant = strings.TrimSpace(rawVariant)
+		if rawVariant == "" {
+			continue
+		}
+		variantName, rawShare, found := strings.Cut(rawVariant, ":")
+		variantName = strings.TrimSpace(variantName)
+		if !found || variantName == "" {
+			return nil, fmt.Errorf("variant %q must look like name:share", rawVariant)
+		}
+		share, err := strconv.Atoi(strings.TrimSpace(rawShare))
+		if err != nil || share <= 0 {
+			return nil, fmt.Errorf("share of variant %q must be a positive integer", variantName)
+		}
+		if seen[variantName] {
+			return nil, fmt.Errorf("variant %q is duplicated", variantName)
+		}
+		seen[variantName] = true
+
+		experiment.Variants = append(experiment.Variants, Variant{Name: variantName, Share: share})
+		experiment.totalShare += share
+	}
+	if len(experiment.Variants) == 0 {
+		return nil, fmt.Errorf("experiment %q has no variants", name)
+	}
+	return experiment, nil
+}
+
+// Assign buckets the subject by a hash of the experiment name and the subject,
+// so the same client always gets the same variant and different experiments are
+// split independently.
+func (e Experiment) Assign(subject string) Assignment {
+	hash := fnv.New32a()
+	hash.Write([]byte(e.Name + "/" + subject))
+	bucket := int(hash.Sum32() % uint32(e.totalShare))
+
+	for _, variant := range e.Variants {
+		if bucket < variant.Share {
+			return Assignment{Experiment: e.Name, Variant: variant.Name}
+		}
+		bucket -= variant.Share
+	}
+	return Assignment{Experiment: e.Name, Variant: e.Variants[len(e.Variants)-1].Name}
+}
+
+type Assignment struct {
+	Experiment string
+	Variant    string
+}
+
+func (a Assignment) ID() string {
+	if a.Experiment == "" {
+		return ""
+	}
+	return a.Experiment + ":" + a.Variant
+}
+
+// ConfigPrefix is the prefix of config keys that override values for the variant.
+func (a Assignment) ConfigPrefix() string {
+	return a.Experiment + "_" + a.Variant + "_"
+}
+
+func WithAssignment(ctx context.Context, assignment Assignment) context.Context {
+	return context.WithValue(ctx, assignmentKey, assignment)
+}
+
+func AssignmentFromContext(ctx context.Context) (Assignment, bool) {
+	assignment, ok := ctx.Value(assignmentKey).(Assignment)
+	return assignment, ok
+}
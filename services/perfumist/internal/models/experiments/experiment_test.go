@@ -0,0 +1,90 @@
+package experiments
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"testing"
+)
+
+func TestNewExperiment(t *testing.T) {
+	t.Parallel()
+
+	experiment, err := NewExperiment("weights", " control:50, tags_heavy:30 ,,")
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if len(experiment.Variants) != 2 || experiment.Variants[1] != (Variant{Name: "tags_heavy", Share: 30}) {
+		t.Fatalf("unexpected variants %+v", experiment.Variants)
+	}
+}
+
+func TestNewExperiment_Invalid(t *testing.T) {
+	t.Parallel()
+
+	for _, rawVariants := range []string{"", "control", "control:0", "control:half", ":50", "control:50,control:50"} {
+		if _, err := NewExperiment("weights", rawVariants); err == nil {
+			t.Fatalf("%q: expected error", rawVariants)
+		}
+	}
+	if _, err := NewExperiment("", "control:50"); err == nil {
+		t.Fatal("expected error for empty name")
+	}
+}
+
+func TestExperiment_AssignIsDeterministic(t *testing.T) {
+	t.Parallel()
+
+	experiment, _ := NewExperiment("weights", "control:50,treatment:50")
+	first := experiment.Assign("client-42")
+	for range 10 {
+		if assignment := experiment.Assign("client-42"); assignment != first {
+			t.Fatalf("expected %+v, got %+v", first, assignment)
+		}
+	}
+	if first.Experiment != "weights" {
+		t.Fatalf("expected experiment weights, got %s", first.Experiment)
+	}
+}
+
+func TestExperiment_AssignFollowsShares(t *testing.T) {
+	t.Parallel()
+
+	experiment, _ := NewExperiment("weights", "control:75,treatment:25")
+	counts := make(map[string]int)
+	const subjects = 10000
+	for i := range subjects {
+		counts[experiment.Assign(fmt.Sprintf("client-%d", i)).Variant]++
+	}
+	if share := float64(counts["treatment"]) / subjects; math.Abs(share-0.25) > 0.03 {
+		t.Fatalf("expected about 25%% of treatment, got %f", share)
+	}
+}
+
+func TestAssignment_ID(t *testing.T) {
+	t.Parallel()
+
+	assignment := Assignment{Experiment: "weights", Variant: "control"}
+	if assignment.ID() != "weights:control" {
+		t.Fatalf("expected weights:control, got %s", assignment.ID())
+	}
+	if assignment.ConfigPrefix() != "weights_control_" {
+		t.Fatalf("expected weights_control_, got %s", assignment.ConfigPrefix())
+	}
+	if (Assignment{}).ID() != "" {
+		t.Fatal("expected empty id without experiment")
+	}
+}
+
+func TestAssignmentContext(t *testing.T) {
+	t.Parallel()
+
+	if _, ok := AssignmentFromContext(context.Background()); ok {
+		t.Fatal("expected no assignment")
+	}
+	assignment := Assignment{Experiment: "weights", Variant: "control"}
+	got, ok := AssignmentFromContext(WithAssignment(context.Background(), assignment))
+	if !ok || got != assignment {
+		t.Fatalf("expected %+v, got %+v", assignment, got)
+	}
+}
@@ -0,0 +1,41 @@
+package experiments
+
+import (
+	"encoding/json"
+	"io"
+	"log"
+	"sync"
+	"time"
+)
+
+type Exposure struct {
+	Experiment string    `json:"experiment"`
+	Variant    string    `json:"variant"`
+	Subject    string    `json:"subject"`
+	Endpoint   string    `json:"endpoint"`
+	Time       time.Time `json:"time"`
+}
+
+type ExposureLogger interface {
+	LogExposure(exposure Exposure)
+}
+
+// JSONExposureLogger writes one JSON object per line, so the log can be shipped
+// and joined with the client events as is.
+type JSONExposureLogger struct {
+	mu      sync.Mutex
+	encoder *json.Encoder
+}
+
+func NewJSONExposureLogger(w io.Writer) *JSONExposureLogger {
+	return &JSONExposureLogger{encoder: json.NewEncoder(w)}
+}
+
+func (l *JSONExposureLogger) LogExposure(exposure Exposure) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if err := l.encoder.Encode(exposure); err != nil {
+		log.Printf("Cannot log exposure: %v\n", err)
+	}
+}
@@ -0,0 +1,30 @@
+package experiments
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestJSONExposureLogger(t *testing.T) {
+	t.Parallel()
+
+	var buffer bytes.Buffer
+	logger := NewJSONExposureLogger(&buffer)
+	logger.LogExposure(Exposure{Experiment: "weights", Variant: "control", Subject: "client-1", Time: time.Unix(0, 0).UTC()})
+	logger.LogExposure(Exposure{Experiment: "weights", Variant: "treatment", Subject: "client-2", Time: time.Unix(0, 0).UTC()})
+
+	lines := strings.Split(strings.TrimSpace(buffer.String()), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("expected 2 lines, got %d", len(lines))
+	}
+	var exposure Exposure
+	if err := json.Unmarshal([]byte(lines[1]), &exposure); err != nil {
+		t.Fatalf("expected valid json, got %v", err)
+	}
+	if exposure.Variant != "treatment" || exposure.Subject != "client-2" {
+		t.Fatalf("unexpected exposure %+v", exposure)
+	}
+}
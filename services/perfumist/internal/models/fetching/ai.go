@@ -1,74 +1,24 @@
 package fetching
 
 import (
-	"bytes"
 	"context"
 	"encoding/json"
 	"fmt"
-	"net/http"
+	"log"
+	"strings"
 	"time"
 
 	"github.com/zemld/Scently/models"
+	"github.com/zemld/Scently/perfumist/internal/models/llm"
 	"github.com/zemld/Scently/perfumist/internal/models/parameters"
 	"github.com/zemld/config-manager/pkg/cm"
 )
 
-const (
-	systemPrompt = `You are an expert perfume recommender. 
-             Your task is to suggest alternative perfumes 
-             based on the user's favorite one. 
-             Do not include explanations, text, or markdown — only JSON.`
-	userPrompt = `User's favorite perfume:
-            Brand: %s
-            Name: %s
-            Sex: %s
-
-            Return exactly 4 other perfumes that the user might also like.
-            Each item must include:
-            - brand
-            - name
-            - sex (must be one of: "male", "female", "unisex")
-
-            Sex constraint based on user's Sex:
-            - if user's Sex is "unisex": returned items' sex MUST be only "unisex"
-            - if user's Sex is "male": returned items' sex MUST be one of: "male", "unisex"
-            - if user's Sex is "female": returned items' sex MUST be one of: "female", "unisex"
-            Allowed for this request: %v
-
-            Respond strictly in this JSON format:
-            [
-            {{"brand": "string", "name": "string", "sex": "string"}},
-            {{"brand": "string", "name": "string", "sex": "string"}},
-            {{"brand": "string", "name": "string", "sex": "string"}},
-            {{"brand": "string", "name": "string", "sex": "string"}}
-            ]`
-)
-
-type requestBody struct {
-	ModelUri          string            `json:"modelUri"`
-	Messages          []message         `json:"messages"`
-	CompletionOptions completionOptions `json:"completionOptions"`
-	JsonSchema        jsonSchema        `json:"jsonSchema"`
-}
-
-type message struct {
-	Role string `json:"role"`
-	Text string `json:"text"`
-}
-
-type completionOptions struct {
-	MaxTokens   int     `json:"maxTokens"`
-	Temperature float64 `json:"temperature"`
-	Stream      bool    `json:"stream"`
-}
-
-type jsonSchema struct {
-	Schema schema `json:"schema"`
-}
-
 type schema struct {
-	Type_ string `json:"type"`
-	Items items  `json:"items"`
+	Type_    string `json:"type"`
+	Items    items  `json:"items"`
+	MinItems int    `json:"minItems"`
+	MaxItems int    `json:"maxItems"`
 }
 
 type items struct {
@@ -89,34 +39,21 @@ type valueType struct {
 	Enum  []string `json:"enum,omitempty"`
 }
 
-type aiCompletionResponse struct {
-	Result struct {
-		Alternatives []struct {
-			Message struct {
-				Text string `json:"text"`
-			} `json:"message"`
-		} `json:"alternatives"`
-	} `json:"result"`
+type AI struct {
+	provider llm.Provider
+	count    int
+	cm       cm.ConfigManager
 }
 
-type AI struct {
-	url       string
-	folderId  string
-	modelName string
-	apiKey    string
-	client    *http.Client
-	cm        cm.ConfigManager
+func NewAI(provider llm.Provider, cm cm.ConfigManager) *AI {
+	return &AI{provider: provider, cm: cm}
 }
 
-func NewAI(url string, folderId string, modelName string, apiKey string, cm cm.ConfigManager) *AI {
-	return &AI{
-		url:       url,
-		folderId:  folderId,
-		modelName: modelName,
-		apiKey:    apiKey,
-		client:    http.DefaultClient,
-		cm:        cm,
-	}
+// WithCount overrides the number of perfumes requested from the LLM, which is
+// suggest_count by default.
+func (f *AI) WithCount(count int) *AI {
+	f.count = count
+	return f
 }
 
 func (f *AI) FetchMany(ctx context.Context, params []parameters.RequestPerfume) <-chan models.Perfume {
@@ -137,19 +74,20 @@ func (f *AI) Fetch(ctx context.Context, parameter parameters.RequestPerfume) <-c
 		ctx, cancel := context.WithTimeout(ctx, f.cm.GetDurationWithDefault("ai_fetcher_timeout", 20*time.Second))
 		defer cancel()
 
-		r, err := f.createRequest(ctx, parameter)
+		request, err := f.createRequest(parameter)
 		if err != nil {
+			log.Printf("Cannot create llm request: %v\n", err)
 			return
 		}
-
-		response, err := f.client.Do(r)
+		text, err := f.provider.Complete(ctx, request)
 		if err != nil {
+			log.Printf("Cannot get completion from %s: %v\n", f.provider.Name(), err)
 			return
 		}
-		defer response.Body.Close()
 
-		perfumes, err := f.tryParseResponse(response)
+		perfumes, err := f.tryParseResponse(text)
 		if err != nil {
+			log.Printf("Cannot parse completion from %s: %v\n", f.provider.Name(), err)
 			return
 		}
 		for _, perfume := range perfumes {
@@ -165,49 +103,55 @@ func (f *AI) Fetch(ctx context.Context, parameter parameters.RequestPerfume) <-c
 	return perfumesChan
 }
 
-func (f *AI) createRequest(ctx context.Context, perfume parameters.RequestPerfume) (*http.Request, error) {
-	body := requestBody{
-		ModelUri: f.createModelUri(f.folderId, f.modelName),
-		Messages: []message{
-			{Role: "system", Text: systemPrompt},
-			{Role: "user", Text: fmt.Sprintf(userPrompt, perfume.Brand, perfume.Name, string(perfume.Sex), f.getAllowedSexes(perfume.Sex))},
-		},
-		CompletionOptions: completionOptions{
-			MaxTokens:   500,
-			Temperature: 0.4,
-			Stream:      false,
-		},
-		JsonSchema: jsonSchema{
-			Schema: schema{
-				Type_: "array",
-				Items: items{
-					Type_: "object",
-					Properties: properties{
-						Brand: valueType{Type_: "string"},
-						Name:  valueType{Type_: "string"},
-						Sex:   valueType{Type_: "string", Enum: f.getAllowedSexes(perfume.Sex)},
-					},
-					Required:             []string{"brand", "name", "sex"},
-					AdditionalProperties: false,
-				},
-			},
-		},
+func (f *AI) createRequest(perfume parameters.RequestPerfume) (llm.Request, error) {
+	data := PromptData{
+		Brand:         perfume.Brand,
+		Name:          perfume.Name,
+		Sex:           string(perfume.Sex),
+		AllowedSexes:  f.getAllowedSexes(perfume.Sex),
+		Count:         f.getCount(),
+		ExcludedNotes: perfume.Exclusions.Notes,
+		MaxPrice:      perfume.Offers.MaxPrice,
 	}
-	bodyBytes, err := json.Marshal(body)
+	systemPrompt, err := prompts.render(f.cm, "ai_system_prompt", data)
 	if err != nil {
-		return nil, err
+		return llm.Request{}, err
 	}
-	r, err := http.NewRequestWithContext(ctx, "POST", f.url, bytes.NewBuffer(bodyBytes))
+	userPrompt, err := prompts.render(f.cm, "ai_user_prompt", data)
 	if err != nil {
-		return nil, err
+		return llm.Request{}, err
 	}
-	r.Header.Set("Content-Type", "application/json")
-	r.Header.Set("Authorization", fmt.Sprintf("Bearer %s", f.apiKey))
-	return r, nil
+
+	return llm.Request{
+		Messages: []llm.Message{
+			{Role: "system", Text: systemPrompt},
+			{Role: "user", Text: userPrompt},
+		},
+		MaxTokens:   max(f.cm.GetIntWithDefault("llm_max_tokens", 500), 60*data.Count),
+		Temperature: f.cm.GetFloatWithDefault("llm_temperature", 0.4),
+		Schema: schema{
+			Type_: "array",
+			Items: items{
+				Type_: "object",
+				Properties: properties{
+					Brand: valueType{Type_: "string"},
+					Name:  valueType{Type_: "string"},
+					Sex:   valueType{Type_: "string", Enum: data.AllowedSexes},
+				},
+				Required:             []string{"brand", "name", "sex"},
+				AdditionalProperties: false,
+			},
+			MinItems: data.Count,
+			MaxItems: data.Count,
+		},
+	}, nil
 }
 
-func (f *AI) createModelUri(folderId string, modelName string) string {
-	return fmt.Sprintf("gpt://%s/%s", folderId, modelName)
+func (f *AI) getCount() int {
+	if f.count > 0 {
+		return f.count
+	}
+	return f.cm.GetIntWithDefault("suggest_count", 4)
 }
 
 func (f *AI) getAllowedSexes(sex models.Sex) []string {
@@ -221,21 +165,16 @@ func (f *AI) getAllowedSexes(sex models.Sex) []string {
 	return allowed
 }
 
-func (f *AI) tryParseResponse(response *http.Response) ([]models.Perfume, error) {
-	if response.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("bad request to llm service: %d", response.StatusCode)
-	}
-
-	var completionResponse aiCompletionResponse
-	if err := json.NewDecoder(response.Body).Decode(&completionResponse); err != nil {
-		return nil, err
-	}
-	if len(completionResponse.Result.Alternatives) == 0 {
-		return nil, fmt.Errorf("no alternatives in response")
+// tryParseResponse takes the outermost JSON array, so that answers wrapped in
+// markdown or text by providers without structured outputs are still parsed.
+func (f *AI) tryParseResponse(text string) ([]models.Perfume, error) {
+	start, end := strings.Index(text, "["), strings.LastIndex(text, "]")
+	if start == -1 || end < start {
+		return nil, fmt.Errorf("no json array in completion")
 	}
 
 	var perfumes []models.Perfume
-	if err := json.Unmarshal([]byte(completionResponse.Result.Alternatives[0].Message.Text), &perfumes); err != nil {
+	if err := json.Unmarshal([]byte(text[start:end+1]), &perfumes); err != nil {
 		return nil, err
 	}
 	return perfumes, nil
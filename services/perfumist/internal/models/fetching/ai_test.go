@@ -10,21 +10,22 @@ import (
 
 	"github.com/zemld/Scently/models"
 	"github.com/zemld/Scently/perfumist/internal/config"
+	"github.com/zemld/Scently/perfumist/internal/models/llm"
 	"github.com/zemld/Scently/perfumist/internal/models/parameters"
 )
 
 func TestNewAIFetcher(t *testing.T) {
 	t.Parallel()
 
-	url := "http://test-url:8000/v1/advise"
+	provider := llm.NewYandexGPT("http://test-url:8000/v1/advise", "test-folder", "test-model", "test-api-key")
 	mockConfig := &config.MockConfigManager{}
-	fetcher := NewAI(url, "test-folder", "test-model", "test-api-key", mockConfig)
+	fetcher := NewAI(provider, mockConfig)
 
 	if fetcher == nil {
 		t.Fatal("expected non-nil fetcher")
 	}
-	if fetcher.url != url {
-		t.Fatalf("expected url %q, got %q", url, fetcher.url)
+	if fetcher.provider != provider {
+		t.Fatal("expected provider to be set")
 	}
 }
 
@@ -32,7 +33,7 @@ func TestAIFetcher_FetchMany_EmptyParams(t *testing.T) {
 	t.Parallel()
 
 	mockConfig := &config.MockConfigManager{}
-	fetcher := NewAI("http://test-url:8000/v1/advise", "test-folder", "test-model", "test-api-key", mockConfig)
+	fetcher := NewAI(llm.NewYandexGPT("http://test-url:8000/v1/advise", "test-folder", "test-model", "test-api-key"), mockConfig)
 	perfumesChan := fetcher.FetchMany(context.Background(), []parameters.RequestPerfume{})
 
 	// Channel should be closed immediately for empty params
@@ -52,7 +53,7 @@ func TestAIFetcher_Fetch_HTTPError(t *testing.T) {
 	})
 
 	mockConfig := &config.MockConfigManager{}
-	fetcher := NewAI("http://test-url:8000/v1/advise", "test-folder", "test-model", "test-api-key", mockConfig)
+	fetcher := NewAI(llm.NewYandexGPT("http://test-url:8000/v1/advise", "test-folder", "test-model", "test-api-key"), mockConfig)
 	param := parameters.RequestPerfume{Brand: "Chanel"}
 	perfumesChan := fetcher.Fetch(context.Background(), param)
 
@@ -79,7 +80,7 @@ func TestAIFetcher_Fetch_Non200Status(t *testing.T) {
 	})
 
 	mockConfig := &config.MockConfigManager{}
-	fetcher := NewAI("http://test-url:8000/v1/advise", "test-folder", "test-model", "test-api-key", mockConfig)
+	fetcher := NewAI(llm.NewYandexGPT("http://test-url:8000/v1/advise", "test-folder", "test-model", "test-api-key"), mockConfig)
 	param := parameters.RequestPerfume{Brand: "Chanel"}
 	perfumesChan := fetcher.Fetch(context.Background(), param)
 
@@ -106,7 +107,7 @@ func TestAIFetcher_Fetch_EmptyBody(t *testing.T) {
 	})
 
 	mockConfig := &config.MockConfigManager{}
-	fetcher := NewAI("http://test-url:8000/v1/advise", "test-folder", "test-model", "test-api-key", mockConfig)
+	fetcher := NewAI(llm.NewYandexGPT("http://test-url:8000/v1/advise", "test-folder", "test-model", "test-api-key"), mockConfig)
 	param := parameters.RequestPerfume{Brand: "Chanel"}
 	perfumesChan := fetcher.Fetch(context.Background(), param)
 
@@ -133,7 +134,7 @@ func TestAIFetcher_Fetch_InvalidJSON(t *testing.T) {
 	})
 
 	mockConfig := &config.MockConfigManager{}
-	fetcher := NewAI("http://test-url:8000/v1/advise", "test-folder", "test-model", "test-api-key", mockConfig)
+	fetcher := NewAI(llm.NewYandexGPT("http://test-url:8000/v1/advise", "test-folder", "test-model", "test-api-key"), mockConfig)
 	param := parameters.RequestPerfume{Brand: "Chanel"}
 	perfumesChan := fetcher.Fetch(context.Background(), param)
 
@@ -184,7 +185,7 @@ func TestAIFetcher_Fetch_Success(t *testing.T) {
 	})
 
 	mockConfig := &config.MockConfigManager{}
-	fetcher := NewAI("http://test-url:8000/v1/advise", "test-folder", "test-model", "test-api-key", mockConfig)
+	fetcher := NewAI(llm.NewYandexGPT("http://test-url:8000/v1/advise", "test-folder", "test-model", "test-api-key"), mockConfig)
 	param := parameters.RequestPerfume{Brand: "Chanel"}
 	perfumesChan := fetcher.Fetch(context.Background(), param)
 
@@ -241,7 +242,7 @@ func TestAIFetcher_Fetch_BuildsPOSTRequestToCompletionAPI(t *testing.T) {
 
 	url := "http://test-url:8000/v1/advise"
 	mockConfig := &config.MockConfigManager{}
-	fetcher := NewAI(url, "test-folder", "aliceai-llm/latest", "test-api-key", mockConfig)
+	fetcher := NewAI(llm.NewYandexGPT(url, "test-folder", "aliceai-llm/latest", "test-api-key"), mockConfig)
 	param := parameters.RequestPerfume{
 		Brand: "Chanel",
 		Name:  "No5",
@@ -273,7 +274,13 @@ func TestAIFetcher_Fetch_BuildsPOSTRequestToCompletionAPI(t *testing.T) {
 	if len(capturedBody) == 0 {
 		t.Fatal("expected non-empty request body")
 	}
-	var rb requestBody
+	var rb struct {
+		ModelUri string `json:"modelUri"`
+		Messages []struct {
+			Role string `json:"role"`
+			Text string `json:"text"`
+		} `json:"messages"`
+	}
 	if err := json.Unmarshal(capturedBody, &rb); err != nil {
 		t.Fatalf("failed to unmarshal request body: %v", err)
 	}
@@ -293,3 +300,68 @@ type roundTripFunc func(*http.Request) (*http.Response, error)
 func (f roundTripFunc) RoundTrip(req *http.Request) (*http.Response, error) {
 	return f(req)
 }
+
+func TestAIFetcher_CreateRequest_UsesCount(t *testing.T) {
+	t.Parallel()
+
+	mockConfig := &config.MockConfigManager{}
+	provider := llm.NewYandexGPT("http://test-url", "folder", "model", "key")
+	param := parameters.RequestPerfume{Brand: "Chanel", Name: "No5", Sex: models.Female}
+	for _, tc := range []struct {
+		fetcher   *AI
+		expected  string
+		maxTokens int
+	}{
+		{NewAI(provider, mockConfig), "Return exactly 4 other perfumes", 500},
+		{NewAI(provider, mockConfig).WithCount(12), "Return exactly 12 other perfumes", 720},
+	} {
+		request, err := tc.fetcher.createRequest(param)
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		if !strings.Contains(request.Messages[1].Text, tc.expected) {
+			t.Fatalf("expected prompt to contain %q, got %q", tc.expected, request.Messages[1].Text)
+		}
+		if request.MaxTokens != tc.maxTokens || request.Temperature != 0.4 {
+			t.Fatalf("expected %d tokens and temperature 0.4, got %d and %f", tc.maxTokens, request.MaxTokens, request.Temperature)
+		}
+	}
+}
+
+func TestAIFetcher_TryParseResponse(t *testing.T) {
+	t.Parallel()
+
+	fetcher := NewAI(llm.NewYandexGPT("http://test-url", "folder", "model", "key"), &config.MockConfigManager{})
+	perfumes, err := fetcher.tryParseResponse("```json\n[{\"brand\": \"Dior\", \"name\": \"Sauvage\", \"sex\": \"male\"}]\n```")
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if len(perfumes) != 1 || perfumes[0].Name != "Sauvage" {
+		t.Fatalf("unexpected perfumes %+v", perfumes)
+	}
+	if _, err := fetcher.tryParseResponse("no perfumes"); err == nil {
+		t.Fatal("expected error without json array")
+	}
+}
+
+type stubProvider struct {
+	text string
+	err  error
+}
+
+func (p *stubProvider) Name() string {
+	return "stub"
+}
+
+func (p *stubProvider) Complete(ctx context.Context, request llm.Request) (string, error) {
+	return p.text, p.err
+}
+
+func TestAIFetcher_Fetch_ProviderError(t *testing.T) {
+	t.Parallel()
+
+	fetcher := NewAI(&stubProvider{err: io.ErrUnexpectedEOF}, &config.MockConfigManager{})
+	if _, ok := <-fetcher.Fetch(context.Background(), parameters.RequestPerfume{Brand: "Chanel"}); ok {
+		t.Fatal("expected closed channel on provider error")
+	}
+}
@@ -0,0 +1,135 @@
+package fetching
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/zemld/Scently/models"
+	"github.com/zemld/Scently/perfumist/internal/models/llm"
+	"github.com/zemld/Scently/perfumist/internal/models/matching"
+	"github.com/zemld/config-manager/pkg/cm"
+)
+
+type descriptionKey struct {
+	favourite  models.CanonizedPerfume
+	suggestion models.CanonizedPerfume
+}
+
+// DescriptionCache keeps descriptions by (favourite, suggestion) pair. When it
+// is full, an arbitrary entry is evicted.
+type DescriptionCache struct {
+	mu      sync.Mutex
+	size    int
+	entries map[descriptionKey]string
+}
+
+func NewDescriptionCache(size int) *DescriptionCache {
+	return &DescriptionCache{size: size, entries: make(map[descriptionKey]string, size)}
+}
+
+func (c *DescriptionCache) get(key descriptionKey) (string, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	description, ok := c.entries[key]
+	return description, ok
+}
+
+func (c *DescriptionCache) put(key descriptionKey, description string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.size <= 0 {
+		return
+	}
+	if _, ok := c.entries[key]; !ok && len(c.entries) >= c.size {
+		for evicted := range c.entries {
+			delete(c.entries, evicted)
+			break
+		}
+	}
+	c.entries[key] = description
+}
+
+type Describer struct {
+	provider llm.Provider
+	cache    *DescriptionCache
+	cm       cm.ConfigManager
+}
+
+func NewDescriber(provider llm.Provider, cache *DescriptionCache, cm cm.ConfigManager) *Describer {
+	return &Describer{provider: provider, cache: cache, cm: cm}
+}
+
+// Describe asks the LLM why suggestion resembles favourite. The prompt only
+// contains the overlap computed from the catalog, not the model's knowledge of
+// the perfumes.
+func (d *Describer) Describe(ctx context.Context, favourite models.Perfume, suggestion models.Perfume, overlap matching.Overlap) (string, error) {
+	key := descriptionKey{favourite: favourite.Canonize(), suggestion: suggestion.Canonize()}
+	if description, ok := d.cache.get(key); ok {
+		return description, nil
+	}
+
+	request, err := d.createRequest(favourite, suggestion, overlap)
+	if err != nil {
+		return "", err
+	}
+	completion, err := d.provider.Complete(ctx, request)
+	if err != nil {
+		return "", fmt.Errorf("cannot get completion from %s: %w", d.provider.Name(), err)
+	}
+	description := strings.Trim(strings.TrimSpace(completion), `"«»`)
+	if description == "" {
+		return "", fmt.Errorf("empty completion from %s", d.provider.Name())
+	}
+	d.cache.put(key, description)
+	return description, nil
+}
+
+func (d *Describer) createRequest(favourite models.Perfume, suggestion models.Perfume, overlap matching.Overlap) (llm.Request, error) {
+	notes := make([]string, 0, len(overlap.Notes.Upper)+len(overlap.Notes.Core)+len(overlap.Notes.Base))
+	for _, levelNotes := range [][]string{overlap.Notes.Upper, overlap.Notes.Core, overlap.Notes.Base} {
+		for _, note := range levelNotes {
+			if !containsFold(notes, note) {
+				notes = append(notes, note)
+			}
+		}
+	}
+	data := DescribePromptData{
+		Favourite:       favourite.Brand + " " + favourite.Name,
+		Suggestion:      suggestion.Brand + " " + suggestion.Name,
+		Notes:           notes,
+		Families:        overlap.Families,
+		Tags:            overlap.Tags,
+		Characteristics: overlap.Characteristics,
+		Language:        d.cm.GetStringWithDefault("describe_language", "Russian"),
+	}
+	systemPrompt, err := prompts.render(d.cm, "describe_system_prompt", data)
+	if err != nil {
+		return llm.Request{}, err
+	}
+	userPrompt, err := prompts.render(d.cm, "describe_user_prompt", data)
+	if err != nil {
+		return llm.Request{}, err
+	}
+
+	return llm.Request{
+		Messages: []llm.Message{
+			{Role: "system", Text: systemPrompt},
+			{Role: "user", Text: userPrompt},
+		},
+		MaxTokens:   d.cm.GetIntWithDefault("describe_max_tokens", 150),
+		Temperature: d.cm.GetFloatWithDefault("llm_temperature", 0.4),
+	}, nil
+}
+
+func containsFold(items []string, item string) bool {
+	for _, existing := range items {
+		if strings.EqualFold(existing, item) {
+			return true
+		}
+	}
+	return false
+}
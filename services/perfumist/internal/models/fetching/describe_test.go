@@ -0,0 +1,85 @@
+package fetching
+
+import (
+	"context"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/zemld/Scently/models"
+	"github.com/zemld/Scently/perfumist/internal/config"
+	"github.com/zemld/Scently/perfumist/internal/models/llm"
+	"github.com/zemld/Scently/perfumist/internal/models/matching"
+)
+
+type countingProvider struct {
+	calls   atomic.Int32
+	request llm.Request
+}
+
+func (p *countingProvider) Name() string {
+	return "counting"
+}
+
+func (p *countingProvider) Complete(ctx context.Context, request llm.Request) (string, error) {
+	p.calls.Add(1)
+	p.request = request
+	return ` "Both open with bergamot." `, nil
+}
+
+func TestDescriber_Describe_GroundedAndCached(t *testing.T) {
+	t.Parallel()
+
+	provider := &countingProvider{}
+	describer := NewDescriber(provider, NewDescriptionCache(10), &config.MockConfigManager{})
+	favourite := models.Perfume{Brand: "Dior", Name: "Sauvage", Sex: models.Male}
+	suggestion := models.Perfume{Brand: "Chanel", Name: "Bleu de Chanel", Sex: models.Male}
+	overlap := matching.Overlap{
+		Notes:           matching.LevelNotes{Upper: []string{"Bergamot"}, Core: []string{"bergamot", "Pepper"}},
+		Tags:            []string{"fresh"},
+		Characteristics: []string{"freshness"},
+	}
+
+	for range 2 {
+		description, err := describer.Describe(context.Background(), favourite, suggestion, overlap)
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		if description != "Both open with bergamot." {
+			t.Fatalf("expected trimmed description, got %q", description)
+		}
+	}
+	if calls := provider.calls.Load(); calls != 1 {
+		t.Fatalf("expected the second description to be cached, got %d calls", calls)
+	}
+
+	userPrompt := provider.request.Messages[1].Text
+	for _, expected := range []string{"Favourite perfume: Dior Sauvage", "Suggested perfume: Chanel Bleu de Chanel", "Shared notes: Bergamot, Pepper", "Shared tags: fresh", "Both are pronounced in: freshness", "in Russian"} {
+		if !strings.Contains(userPrompt, expected) {
+			t.Fatalf("expected prompt to contain %q, got %q", expected, userPrompt)
+		}
+	}
+	if strings.Contains(userPrompt, "Shared families") {
+		t.Fatalf("expected no families in prompt, got %q", userPrompt)
+	}
+}
+
+func TestDescriptionCache_EvictsWhenFull(t *testing.T) {
+	t.Parallel()
+
+	cache := NewDescriptionCache(2)
+	keys := []descriptionKey{
+		{favourite: models.CanonizedPerfume{Name: "a"}},
+		{favourite: models.CanonizedPerfume{Name: "b"}},
+		{favourite: models.CanonizedPerfume{Name: "c"}},
+	}
+	for _, key := range keys {
+		cache.put(key, key.favourite.Name)
+	}
+	if len(cache.entries) != 2 {
+		t.Fatalf("expected 2 entries, got %d", len(cache.entries))
+	}
+	if description, ok := cache.get(keys[2]); !ok || description != "c" {
+		t.Fatalf("expected the latest entry to be kept, got %q", description)
+	}
+}
@@ -11,3 +11,13 @@ type Fetcher interface {
 	Fetch(ctx context.Context, parameter parameters.RequestPerfume) <-chan models.Perfume
 	FetchMany(ctx context.Context, parameters []parameters.RequestPerfume) <-chan models.Perfume
 }
+
+type CandidatesFetcher interface {
+	FetchCandidates(ctx context.Context, parameter parameters.RequestPerfume, favourite models.Properties, count int) <-chan models.Perfume
+}
+
+// CatalogFetcher reads all perfumes at once and fails instead of returning
+// part of them when the transfer breaks.
+type CatalogFetcher interface {
+	FetchCatalog(ctx context.Context, parameter parameters.RequestPerfume) ([]models.Perfume, error)
+}
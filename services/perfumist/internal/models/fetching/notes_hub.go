@@ -0,0 +1,70 @@
+package fetching
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"strings"
+
+	"github.com/zemld/Scently/models"
+	"github.com/zemld/Scently/perfumist/internal/errors"
+	"github.com/zemld/Scently/perfumist/internal/models/perfume"
+)
+
+type NotesFetcher interface {
+	FetchNotes(ctx context.Context, names []string) ([]models.EnrichedNote, error)
+}
+
+type NotesResponse struct {
+	Notes []models.EnrichedNote `json:"notes"`
+	State perfume.State         `json:"state"`
+}
+
+type PerfumeHubNotes struct {
+	url    string
+	token  string
+	client *http.Client
+}
+
+func NewPerfumeHubNotes(url string, token string) *PerfumeHubNotes {
+	return &PerfumeHubNotes{url: url, token: token, client: http.DefaultClient}
+}
+
+func (f *PerfumeHubNotes) FetchNotes(ctx context.Context, names []string) ([]models.EnrichedNote, error) {
+	r, err := http.NewRequestWithContext(ctx, "GET", f.url, nil)
+	if err != nil {
+		return nil, errors.NewServiceError("can't create notes request", err)
+	}
+	query := r.URL.Query()
+	query.Set("names", strings.Join(names, ","))
+	r.URL.RawQuery = query.Encode()
+	r.Header.Set("Authorization", fmt.Sprintf("Bearer %s", f.token))
+
+	response, err := f.client.Do(r)
+	if err != nil {
+		return nil, errors.NewServiceError("can't get notes", err)
+	}
+	defer response.Body.Close()
+
+	switch response.StatusCode {
+	case http.StatusOK:
+	case http.StatusNotFound:
+		return []models.EnrichedNote{}, nil
+	default:
+		return nil, errors.NewServiceError(fmt.Sprintf("unexpected notes response status: %d", response.StatusCode), nil)
+	}
+
+	body, err := io.ReadAll(response.Body)
+	if err != nil {
+		return nil, errors.NewServiceError("can't read notes response", err)
+	}
+	var notes NotesResponse
+	if err := json.Unmarshal(body, &notes); err != nil {
+		return nil, errors.NewServiceError("can't unmarshal notes response", err)
+	}
+	log.Printf("Got %d notes of %d requested", len(notes.Notes), len(names))
+	return notes.Notes, nil
+}
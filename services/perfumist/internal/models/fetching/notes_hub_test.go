@@ -0,0 +1,68 @@
+package fetching
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/zemld/Scently/models"
+)
+
+func TestPerfumeHubNotes_FetchNotes_Success(t *testing.T) {
+	t.Parallel()
+
+	expected := []models.EnrichedNote{
+		{Name: "vanilla", Tags: []string{"sweet"}, Characteristics: []models.NoteCharacteristic{{Name: "sweetness", Value: 0.9}}},
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("names") != "vanilla,rose" {
+			t.Errorf("expected names vanilla,rose, got %q", r.URL.Query().Get("names"))
+		}
+		if r.Header.Get("Authorization") != "Bearer test-token" {
+			t.Errorf("expected bearer token, got %q", r.Header.Get("Authorization"))
+		}
+		json.NewEncoder(w).Encode(NotesResponse{Notes: expected})
+	}))
+	defer server.Close()
+
+	notes, err := NewPerfumeHubNotes(server.URL, "test-token").FetchNotes(context.Background(), []string{"vanilla", "rose"})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if len(notes) != 1 || notes[0].Name != "vanilla" || len(notes[0].Characteristics) != 1 {
+		t.Fatalf("expected %+v, got %+v", expected, notes)
+	}
+}
+
+func TestPerfumeHubNotes_FetchNotes_NotFound(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		json.NewEncoder(w).Encode(NotesResponse{Notes: []models.EnrichedNote{}})
+	}))
+	defer server.Close()
+
+	notes, err := NewPerfumeHubNotes(server.URL, "test-token").FetchNotes(context.Background(), []string{"unknown"})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if len(notes) != 0 {
+		t.Fatalf("expected no notes, got %+v", notes)
+	}
+}
+
+func TestPerfumeHubNotes_FetchNotes_ServerError(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	if _, err := NewPerfumeHubNotes(server.URL, "test-token").FetchNotes(context.Background(), []string{"vanilla"}); err == nil {
+		t.Fatal("expected error for server failure")
+	}
+}
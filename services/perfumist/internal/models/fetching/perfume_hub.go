@@ -12,6 +12,7 @@ import (
 	"time"
 
 	"github.com/zemld/Scently/models"
+	"github.com/zemld/Scently/perfumist/internal/errors"
 	"github.com/zemld/Scently/perfumist/internal/models/parameters"
 	"github.com/zemld/Scently/perfumist/internal/models/perfume"
 	"github.com/zemld/config-manager/pkg/cm"
@@ -23,11 +24,12 @@ type perfumesFetchAndGlueResult struct {
 }
 
 type PerfumeHub struct {
-	url     string
-	token   string
-	timeout time.Duration
-	client  *http.Client
-	cm      cm.ConfigManager
+	url       string
+	streamUrl string
+	token     string
+	timeout   time.Duration
+	client    *http.Client
+	cm        cm.ConfigManager
 }
 
 func NewPerfumeHub(url string, token string, cm cm.ConfigManager) *PerfumeHub {
@@ -40,7 +42,18 @@ func NewPerfumeHub(url string, token string, cm cm.ConfigManager) *PerfumeHub {
 	}
 }
 
+// WithStreamUrl makes the fetcher read the whole catalog from the perfumes
+// stream. Pages are still used when the stream is not available.
+func (f *PerfumeHub) WithStreamUrl(url string) *PerfumeHub {
+	f.streamUrl = url
+	return f
+}
+
 func (f *PerfumeHub) FetchMany(ctx context.Context, params []parameters.RequestPerfume) <-chan models.Perfume {
+	return fetchMany(ctx, f, params)
+}
+
+func fetchMany(ctx context.Context, fetcher Fetcher, params []parameters.RequestPerfume) <-chan models.Perfume {
 	allPerfumesChan := make(chan models.Perfume)
 
 	wg := sync.WaitGroup{}
@@ -48,7 +61,7 @@ func (f *PerfumeHub) FetchMany(ctx context.Context, params []parameters.RequestP
 	for _, param := range params {
 		go func(p parameters.RequestPerfume) {
 			defer wg.Done()
-			perfumesChan := f.Fetch(ctx, p)
+			perfumesChan := fetcher.Fetch(ctx, p)
 			for {
 				select {
 				case <-ctx.Done():
@@ -100,10 +113,121 @@ func (f *PerfumeHub) fetchConcretePerfume(ctx context.Context, parameter paramet
 }
 
 func (f *PerfumeHub) fetchAllPerfumes(ctx context.Context, parameter parameters.RequestPerfume) <-chan models.Perfume {
+	perfumeChan := make(chan models.Perfume)
+	go func() {
+		defer close(perfumeChan)
+		send := func(perfume models.Perfume) bool {
+			select {
+			case <-ctx.Done():
+				return false
+			case perfumeChan <- perfume:
+				return true
+			}
+		}
+		if err := f.fetchCatalog(ctx, parameter, send); err != nil {
+			log.Printf("Can't fetch perfumes: %v", err)
+		}
+	}()
+	return perfumeChan
+}
+
+func (f *PerfumeHub) FetchCatalog(ctx context.Context, parameter parameters.RequestPerfume) ([]models.Perfume, error) {
+	perfumes := make([]models.Perfume, 0)
+	err := f.fetchCatalog(ctx, parameter, func(perfume models.Perfume) bool {
+		perfumes = append(perfumes, perfume)
+		return true
+	})
+	if err != nil {
+		return nil, err
+	}
+	return perfumes, nil
+}
+
+func (f *PerfumeHub) fetchCatalog(ctx context.Context, parameter parameters.RequestPerfume, send func(models.Perfume) bool) error {
+	if f.streamUrl != "" {
+		err := f.streamPerfumes(ctx, parameter, send)
+		if err != errStreamNotAvailable {
+			return err
+		}
+	}
+	return f.fetchAllPages(ctx, parameter, send)
+}
+
+// errStreamNotAvailable means that nothing was sent from the stream, so the
+// perfumes can be fetched by pages instead.
+var errStreamNotAvailable = errors.NewServiceError("perfumes stream is not available", nil)
+
+// streamPerfumes fails if the stream breaks or ends before all perfumes
+// announced by the header are read.
+func (f *PerfumeHub) streamPerfumes(ctx context.Context, p parameters.RequestPerfume, send func(models.Perfume) bool) error {
+	start := time.Now()
+	r, err := http.NewRequestWithContext(ctx, "GET", f.streamUrl, nil)
+	if err != nil {
+		log.Printf("Can't create request: %v", err)
+		return errStreamNotAvailable
+	}
+	p.AddToQuery(r)
+	r.Header.Set("Authorization", fmt.Sprintf("Bearer %s", f.token))
+
+	response, err := f.client.Do(r)
+	if err != nil {
+		log.Printf("Can't stream perfumes: %v", err)
+		return errStreamNotAvailable
+	}
+	defer response.Body.Close()
+	if response.StatusCode != http.StatusOK {
+		log.Printf("Perfumes stream is not available (status: %d), fetching pages", response.StatusCode)
+		return errStreamNotAvailable
+	}
+
+	decoder := json.NewDecoder(response.Body)
+	var header perfume.StreamHeader
+	if err := decoder.Decode(&header); err != nil {
+		log.Printf("Can't decode stream header: %v", err)
+		return errStreamNotAvailable
+	}
+
+	count := 0
+	for {
+		var streamed models.Perfume
+		err := decoder.Decode(&streamed)
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return errors.NewServiceError(fmt.Sprintf("perfumes stream broken after %d of %d perfumes", count, header.Count), err)
+		}
+		if !send(streamed) {
+			return ctx.Err()
+		}
+		count++
+	}
+	if count != header.Count {
+		return errors.NewServiceError(fmt.Sprintf("perfumes stream of version %v ended after %d of %d perfumes", header.Version, count, header.Count), nil)
+	}
+	log.Printf("Streamed %d perfumes of version %v (%d bytes in %v)", count, header.Version, decoder.InputOffset(), time.Since(start))
+	return nil
+}
+
+// fetchAllPages reads pages until one comes back 404 or empty. Any other status
+// means a page of the catalog is lost, so the first such failure stops the
+// other workers and is returned.
+func (f *PerfumeHub) fetchAllPages(parent context.Context, parameter parameters.RequestPerfume, send func(models.Perfume) bool) error {
+	ctx, cancel := context.WithCancel(parent)
+	defer cancel()
+
 	var pageNumber atomic.Uint32
-	pageNumber.Store(1)
+	var sendMu, failureMu sync.Mutex
+	var failure error
+	fail := func(err error) {
+		failureMu.Lock()
+		defer failureMu.Unlock()
+		if failure == nil {
+			failure = err
+		}
+		cancel()
+	}
 
-	perfumeChan := make(chan models.Perfume)
 	workersCount := f.cm.GetIntWithDefault("threads_count", 8)
 	wg := sync.WaitGroup{}
 	wg.Add(workersCount)
@@ -112,31 +236,40 @@ func (f *PerfumeHub) fetchAllPerfumes(ctx context.Context, parameter parameters.
 			defer wg.Done()
 			localParameter := parameter
 			for {
-				localParameter.Page = pageNumber.Load()
-				pageNumber.Add(1)
+				localParameter.Page = pageNumber.Add(1)
 				perfumes, status := f.getPerfumes(ctx, localParameter)
+				if ctx.Err() != nil {
+					return
+				}
+				if status == http.StatusNotFound || (status == http.StatusOK && len(perfumes) == 0) {
+					return
+				}
 				if status != http.StatusOK {
-					break
+					fail(errors.NewServiceError(fmt.Sprintf("can't fetch page %d of perfumes (status: %d)", localParameter.Page, status), nil))
+					return
 				}
+
+				sendMu.Lock()
 				for _, perfume := range perfumes {
-					select {
-					case <-ctx.Done():
+					if ctx.Err() != nil || !send(perfume) {
+						sendMu.Unlock()
 						return
-					case perfumeChan <- perfume:
 					}
 				}
+				sendMu.Unlock()
 			}
 		}()
 	}
+	wg.Wait()
 
-	go func() {
-		wg.Wait()
-		close(perfumeChan)
-	}()
-	return perfumeChan
+	if failure != nil {
+		return failure
+	}
+	return parent.Err()
 }
 
 func (f *PerfumeHub) getPerfumes(ctx context.Context, p parameters.RequestPerfume) ([]models.Perfume, int) {
+	start := time.Now()
 	r, err := http.NewRequestWithContext(ctx, "GET", f.url, nil)
 	if err != nil {
 		log.Printf("Can't create request: %v", err)
@@ -189,7 +322,7 @@ func (f *PerfumeHub) getPerfumes(ctx context.Context, p parameters.RequestPerfum
 			log.Printf("Can't unmarshal response: %v", err)
 			return nil, http.StatusInternalServerError
 		}
-		log.Printf("Got %d perfumes", len(perfumes.Perfumes))
+		log.Printf("Got %d perfumes (%d bytes in %v)", len(perfumes.Perfumes), len(body), time.Since(start))
 		return perfumes.Perfumes, http.StatusOK
 	}
 
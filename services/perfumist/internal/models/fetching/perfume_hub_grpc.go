@@ -0,0 +1,244 @@
+package fetching
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log"
+	"strconv"
+	"time"
+
+	"github.com/zemld/Scently/models"
+	"github.com/zemld/Scently/perfumist/internal/errors"
+	"github.com/zemld/Scently/perfumist/internal/models/parameters"
+	perfumehubpb "github.com/zemld/Scently/shared/proto/perfume-hub"
+	pb "github.com/zemld/Scently/shared/proto/perfume-hub/models"
+	"github.com/zemld/config-manager/pkg/cm"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+// Header metadata of the perfume-hub perfumes stream.
+const (
+	catalogVersionKey = "x-catalog-version"
+	perfumesCountKey  = "x-perfumes-count"
+)
+
+// PerfumeHubGRPC fetches perfumes like PerfumeHub but over the gRPC service of
+// perfume-hub. The whole catalog comes in a single stream instead of pages.
+type PerfumeHubGRPC struct {
+	client  perfumehubpb.PerfumeHubServiceClient
+	token   string
+	timeout time.Duration
+}
+
+func NewPerfumeHubGRPC(conn grpc.ClientConnInterface, token string, cm cm.ConfigManager) *PerfumeHubGRPC {
+	return &PerfumeHubGRPC{
+		client:  perfumehubpb.NewPerfumeHubServiceClient(conn),
+		token:   token,
+		timeout: cm.GetDurationWithDefault("perfume_hub_fetcher_timeout", 5*time.Second),
+	}
+}
+
+func (f *PerfumeHubGRPC) FetchMany(ctx context.Context, params []parameters.RequestPerfume) <-chan models.Perfume {
+	return fetchMany(ctx, f, params)
+}
+
+func (f *PerfumeHubGRPC) Fetch(ctx context.Context, parameter parameters.RequestPerfume) <-chan models.Perfume {
+	perfumeChan := make(chan models.Perfume)
+	go func() {
+		defer close(perfumeChan)
+		send := func(perfume *pb.Perfume) bool {
+			select {
+			case <-ctx.Done():
+				return false
+			case perfumeChan <- fromProtoPerfume(perfume):
+				return true
+			}
+		}
+		if parameter.Brand != "" || parameter.Name != "" {
+			f.getPerfumes(ctx, parameter, send)
+			return
+		}
+		if err := f.streamPerfumes(ctx, parameter, send); err != nil {
+			log.Printf("Can't stream perfumes: %v", err)
+		}
+	}()
+	return perfumeChan
+}
+
+func (f *PerfumeHubGRPC) FetchCatalog(ctx context.Context, parameter parameters.RequestPerfume) ([]models.Perfume, error) {
+	perfumes := make([]models.Perfume, 0)
+	err := f.streamPerfumes(ctx, parameter, func(perfume *pb.Perfume) bool {
+		perfumes = append(perfumes, fromProtoPerfume(perfume))
+		return true
+	})
+	if err != nil {
+		return nil, err
+	}
+	return perfumes, nil
+}
+
+func (f *PerfumeHubGRPC) getPerfumes(ctx context.Context, p parameters.RequestPerfume, send func(*pb.Perfume) bool) {
+	start := time.Now()
+	ctx, cancel := context.WithTimeout(f.withToken(ctx), f.timeout)
+	defer cancel()
+
+	response, err := f.client.GetPerfume(ctx, &perfumehubpb.GetPerfumeRequest{
+		Brand: &p.Brand,
+		Name:  &p.Name,
+		Sex:   toProtoSex(p.Sex),
+	})
+	if status.Code(err) == codes.NotFound {
+		return
+	}
+	if err != nil {
+		log.Printf("Can't get perfumes: %v", err)
+		return
+	}
+	log.Printf("Got %d perfumes (%d bytes in %v)", len(response.GetPerfumes()), proto.Size(response), time.Since(start))
+	for _, perfume := range response.GetPerfumes() {
+		if !send(perfume) {
+			return
+		}
+	}
+}
+
+// streamPerfumes fails if the stream ends before all perfumes announced by the
+// header metadata are received.
+func (f *PerfumeHubGRPC) streamPerfumes(ctx context.Context, p parameters.RequestPerfume, send func(*pb.Perfume) bool) error {
+	start := time.Now()
+	stream, err := f.client.StreamPerfumes(f.withToken(ctx), &perfumehubpb.StreamPerfumesRequest{Sex: toProtoSex(p.Sex)})
+	if err != nil {
+		return errors.NewServiceError("can't stream perfumes", err)
+	}
+	header, err := stream.Header()
+	if err != nil {
+		return errors.NewServiceError("can't read perfumes stream header", err)
+	}
+	expected, err := streamCount(header)
+	if err != nil {
+		return err
+	}
+	version := header.Get(catalogVersionKey)
+
+	count, size := 0, 0
+	for {
+		perfume, err := stream.Recv()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return errors.NewServiceError(fmt.Sprintf("perfumes stream broken after %d of %d perfumes", count, expected), err)
+		}
+		count++
+		size += proto.Size(perfume)
+		if !send(perfume) {
+			return ctx.Err()
+		}
+	}
+	if count != expected {
+		return errors.NewServiceError(fmt.Sprintf("perfumes stream of version %v ended after %d of %d perfumes", version, count, expected), nil)
+	}
+	log.Printf("Streamed %d perfumes of version %v (%d bytes in %v)", count, version, size, time.Since(start))
+	return nil
+}
+
+func streamCount(header metadata.MD) (int, error) {
+	values := header.Get(perfumesCountKey)
+	if len(values) != 1 {
+		return 0, errors.NewServiceError("perfumes stream header has no perfumes count", nil)
+	}
+	count, err := strconv.Atoi(values[0])
+	if err != nil {
+		return 0, errors.NewServiceError("invalid perfumes count in stream header", err)
+	}
+	return count, nil
+}
+
+func (f *PerfumeHubGRPC) withToken(ctx context.Context) context.Context {
+	return metadata.AppendToOutgoingContext(ctx, "authorization", "Bearer "+f.token)
+}
+
+func toProtoSex(sex models.Sex) pb.Perfume_Sex {
+	switch sex {
+	case models.Male:
+		return pb.Perfume_MALE
+	case models.Female:
+		return pb.Perfume_FEMALE
+	default:
+		return pb.Perfume_UNISEX
+	}
+}
+
+func fromProtoSex(sex pb.Perfume_Sex) models.Sex {
+	switch sex {
+	case pb.Perfume_MALE:
+		return models.Male
+	case pb.Perfume_FEMALE:
+		return models.Female
+	default:
+		return models.Unisex
+	}
+}
+
+func fromProtoPerfume(perfume *pb.Perfume) models.Perfume {
+	shops := make([]models.ShopInfo, 0, len(perfume.GetShops()))
+	for _, shop := range perfume.GetShops() {
+		variants := make([]models.Variant, 0, len(shop.GetVariants()))
+		for _, variant := range shop.GetVariants() {
+			variants = append(variants, models.Variant{
+				Volume: int(variant.GetVolume()),
+				Link:   variant.GetLink(),
+				Price:  int(variant.GetPrice()),
+			})
+		}
+		shops = append(shops, models.ShopInfo{
+			ShopName: shop.GetName(),
+			Domain:   shop.GetDomain(),
+			ImageUrl: shop.GetImageUrl(),
+			Variants: variants,
+		})
+	}
+
+	properties := perfume.GetProperties()
+	return models.Perfume{
+		Brand:    perfume.GetBrand(),
+		Name:     perfume.GetName(),
+		Sex:      fromProtoSex(perfume.GetSex()),
+		ImageUrl: perfume.GetImageUrl(),
+		Properties: models.Properties{
+			Type:               properties.GetType(),
+			Family:             properties.GetFamily(),
+			UpperNotes:         properties.GetUpperNotes(),
+			CoreNotes:          properties.GetCoreNotes(),
+			BaseNotes:          properties.GetBaseNotes(),
+			EnrichedUpperNotes: fromProtoEnrichedNotes(properties.GetEnrichedUpperNotes()),
+			EnrichedCoreNotes:  fromProtoEnrichedNotes(properties.GetEnrichedCoreNotes()),
+			EnrichedBaseNotes:  fromProtoEnrichedNotes(properties.GetEnrichedBaseNotes()),
+		},
+		Shops: shops,
+	}
+}
+
+func fromProtoEnrichedNotes(notes []*pb.Perfume_Properties_EnrichedNote) []models.EnrichedNote {
+	converted := make([]models.EnrichedNote, 0, len(notes))
+	for _, note := range notes {
+		characteristics := make([]models.NoteCharacteristic, 0, len(note.GetCharacteristics()))
+		for _, characteristic := range note.GetCharacteristics() {
+			characteristics = append(characteristics, models.NoteCharacteristic{
+				Name:  characteristic.GetName(),
+				Value: characteristic.GetValue(),
+			})
+		}
+		converted = append(converted, models.EnrichedNote{
+			Name:            note.GetName(),
+			Tags:            note.GetTags(),
+			Characteristics: characteristics,
+		})
+	}
+	return converted
+}
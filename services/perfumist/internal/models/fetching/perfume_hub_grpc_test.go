@@ -0,0 +1,217 @@
+package fetching
+
+import (
+	"context"
+	"net"
+	"strconv"
+	"sync"
+	"testing"
+
+	"github.com/zemld/Scently/models"
+	"github.com/zemld/Scently/perfumist/internal/config"
+	"github.com/zemld/Scently/perfumist/internal/models/parameters"
+	perfumehubpb "github.com/zemld/Scently/shared/proto/perfume-hub"
+	pb "github.com/zemld/Scently/shared/proto/perfume-hub/models"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+type fakePerfumeHubServer struct {
+	perfumehubpb.UnimplementedPerfumeHubServiceServer
+	perfumes []*pb.Perfume
+	// failAfter breaks the stream after this many perfumes if positive.
+	failAfter int
+	// endAfter ends the stream without an error after this many perfumes if
+	// positive, so fewer perfumes arrive than announced.
+	endAfter int
+
+	mu     sync.Mutex
+	tokens []string
+}
+
+func (s *fakePerfumeHubServer) recordToken(ctx context.Context) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.tokens = append(s.tokens, metadata.ValueFromIncomingContext(ctx, "authorization")...)
+}
+
+func (s *fakePerfumeHubServer) GetPerfume(ctx context.Context, req *perfumehubpb.GetPerfumeRequest) (*perfumehubpb.GetPerfumeResponse, error) {
+	s.recordToken(ctx)
+	for _, perfume := range s.perfumes {
+		if perfume.GetBrand() == req.GetBrand() && perfume.GetName() == req.GetName() {
+			return &perfumehubpb.GetPerfumeResponse{Perfumes: []*pb.Perfume{perfume}}, nil
+		}
+	}
+	return nil, status.Error(codes.NotFound, "perfumes not found")
+}
+
+func (s *fakePerfumeHubServer) StreamPerfumes(req *perfumehubpb.StreamPerfumesRequest, stream grpc.ServerStreamingServer[pb.Perfume]) error {
+	s.recordToken(stream.Context())
+	perfumes := make([]*pb.Perfume, 0, len(s.perfumes))
+	for _, perfume := range s.perfumes {
+		if perfume.GetSex() == pb.Perfume_UNISEX || perfume.GetSex() == req.GetSex() {
+			perfumes = append(perfumes, perfume)
+		}
+	}
+	if err := stream.SendHeader(metadata.Pairs(
+		catalogVersionKey, "2026-10-01T12:00:00Z",
+		perfumesCountKey, strconv.Itoa(len(perfumes)),
+	)); err != nil {
+		return err
+	}
+
+	sent := 0
+	for _, perfume := range perfumes {
+		if s.failAfter > 0 && sent == s.failAfter {
+			return status.Error(codes.Unavailable, "connection reset")
+		}
+		if s.endAfter > 0 && sent == s.endAfter {
+			return nil
+		}
+		sent++
+		if err := stream.Send(perfume); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func newTestPerfumeHubGRPC(t *testing.T, hub *fakePerfumeHubServer) *PerfumeHubGRPC {
+	t.Helper()
+
+	lis := bufconn.Listen(1024 * 1024)
+	server := grpc.NewServer()
+	perfumehubpb.RegisterPerfumeHubServiceServer(server, hub)
+	go server.Serve(lis)
+	t.Cleanup(server.Stop)
+
+	conn, err := grpc.NewClient(
+		"passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return lis.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	if err != nil {
+		t.Fatalf("failed to connect: %v", err)
+	}
+	t.Cleanup(func() { conn.Close() })
+	return NewPerfumeHubGRPC(conn, "test-token", &config.MockConfigManager{})
+}
+
+func testHubPerfumes() []*pb.Perfume {
+	return []*pb.Perfume{
+		{
+			Brand: "Dior",
+			Name:  "Sauvage",
+			Sex:   pb.Perfume_MALE,
+			Properties: &pb.Perfume_Properties{
+				BaseNotes: []string{"ambroxan"},
+				EnrichedBaseNotes: []*pb.Perfume_Properties_EnrichedNote{{
+					Name:            "ambroxan",
+					Tags:            []string{"woody"},
+					Characteristics: []*pb.Perfume_Properties_EnrichedNote_NoteCharacteristic{{Name: "warm", Value: 0.7}},
+				}},
+			},
+			Shops: []*pb.Perfume_Shop{{
+				Name:     "Gold Apple",
+				Variants: []*pb.Perfume_Shop_Variant{{Volume: 100, Price: 12000}},
+			}},
+		},
+		{Brand: "Le Labo", Name: "Santal 33", Sex: pb.Perfume_UNISEX},
+		{Brand: "Chanel", Name: "No5", Sex: pb.Perfume_FEMALE},
+	}
+}
+
+func collect(perfumesChan <-chan models.Perfume) []models.Perfume {
+	perfumes := make([]models.Perfume, 0)
+	for perfume := range perfumesChan {
+		perfumes = append(perfumes, perfume)
+	}
+	return perfumes
+}
+
+func TestPerfumeHubGRPC_FetchStreamsCatalog(t *testing.T) {
+	hub := &fakePerfumeHubServer{perfumes: testHubPerfumes()}
+	fetcher := newTestPerfumeHubGRPC(t, hub)
+
+	perfumes := collect(fetcher.Fetch(context.Background(), *parameters.NewGet().WithSex(models.Male)))
+	if len(perfumes) != 2 || perfumes[0].Name != "Sauvage" || perfumes[1].Sex != models.Unisex {
+		t.Fatalf("expected male and unisex perfumes, got %+v", perfumes)
+	}
+
+	sauvage := perfumes[0]
+	if sauvage.Sex != models.Male || sauvage.Properties.EnrichedBaseNotes[0].Characteristics[0].Value != 0.7 {
+		t.Fatalf("unexpected properties: %+v", sauvage.Properties)
+	}
+	if sauvage.Shops[0].ShopName != "Gold Apple" || sauvage.Shops[0].Variants[0].Price != 12000 {
+		t.Fatalf("unexpected shops: %+v", sauvage.Shops)
+	}
+	if len(hub.tokens) != 1 || hub.tokens[0] != "Bearer test-token" {
+		t.Fatalf("expected bearer token in metadata, got %v", hub.tokens)
+	}
+}
+
+func TestPerfumeHubGRPC_FetchConcretePerfume(t *testing.T) {
+	fetcher := newTestPerfumeHubGRPC(t, &fakePerfumeHubServer{perfumes: testHubPerfumes()})
+
+	perfumes := collect(fetcher.Fetch(context.Background(), *parameters.NewGet().WithBrand("Chanel").WithName("No5").WithSex(models.Female)))
+	if len(perfumes) != 1 || perfumes[0].Sex != models.Female {
+		t.Fatalf("expected Chanel No5, got %+v", perfumes)
+	}
+
+	perfumes = collect(fetcher.Fetch(context.Background(), *parameters.NewGet().WithBrand("Chanel").WithName("No6")))
+	if len(perfumes) != 0 {
+		t.Fatalf("expected no perfumes, got %+v", perfumes)
+	}
+}
+
+func TestPerfumeHubGRPC_FetchMany(t *testing.T) {
+	fetcher := newTestPerfumeHubGRPC(t, &fakePerfumeHubServer{perfumes: testHubPerfumes()})
+
+	perfumes := collect(fetcher.FetchMany(context.Background(), []parameters.RequestPerfume{
+		*parameters.NewGet().WithBrand("Dior").WithName("Sauvage"),
+		*parameters.NewGet().WithBrand("Le Labo").WithName("Santal 33"),
+	}))
+	if len(perfumes) != 2 {
+		t.Fatalf("expected 2 perfumes, got %+v", perfumes)
+	}
+}
+
+func TestPerfumeHubGRPC_FetchCatalog(t *testing.T) {
+	fetcher := newTestPerfumeHubGRPC(t, &fakePerfumeHubServer{perfumes: testHubPerfumes()})
+
+	perfumes, err := fetcher.FetchCatalog(context.Background(), *parameters.NewGet().WithSex(models.Female))
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if len(perfumes) != 2 || perfumes[1].Name != "No5" {
+		t.Fatalf("expected unisex and female perfumes, got %+v", perfumes)
+	}
+}
+
+func TestPerfumeHubGRPC_FetchCatalogFailsOnBrokenStream(t *testing.T) {
+	fetcher := newTestPerfumeHubGRPC(t, &fakePerfumeHubServer{perfumes: testHubPerfumes(), failAfter: 1})
+
+	perfumes, err := fetcher.FetchCatalog(context.Background(), *parameters.NewGet().WithSex(models.Male))
+	if err == nil {
+		t.Fatalf("expected error for broken stream, got %+v", perfumes)
+	}
+	if perfumes != nil {
+		t.Fatalf("expected no perfumes from broken stream, got %+v", perfumes)
+	}
+}
+
+func TestPerfumeHubGRPC_FetchCatalogFailsOnShortStream(t *testing.T) {
+	fetcher := newTestPerfumeHubGRPC(t, &fakePerfumeHubServer{perfumes: testHubPerfumes(), endAfter: 1})
+
+	perfumes, err := fetcher.FetchCatalog(context.Background(), *parameters.NewGet().WithSex(models.Male))
+	if err == nil {
+		t.Fatalf("expected error for stream shorter than announced, got %+v", perfumes)
+	}
+	if perfumes != nil {
+		t.Fatalf("expected no perfumes from short stream, got %+v", perfumes)
+	}
+}
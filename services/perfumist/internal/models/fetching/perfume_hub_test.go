@@ -419,3 +419,183 @@ func (errorReader) Read([]byte) (int, error) {
 func (errorReader) Close() error {
 	return nil
 }
+
+func TestDbFetcher_Fetch_Stream(t *testing.T) {
+	expectedPerfumes := []models.Perfume{
+		{Brand: "Chanel", Name: "No5", Sex: "female"},
+		{Brand: "Dior", Name: "Sauvage", Sex: "unisex"},
+	}
+	lines := []any{perfume.StreamHeader{Version: time.Date(2026, 1, 9, 10, 36, 8, 0, time.UTC), Count: 2}}
+	for _, p := range expectedPerfumes {
+		lines = append(lines, p)
+	}
+	var body strings.Builder
+	encoder := json.NewEncoder(&body)
+	for _, line := range lines {
+		if err := encoder.Encode(line); err != nil {
+			t.Fatalf("failed to marshal test data: %v", err)
+		}
+	}
+
+	origTransport := http.DefaultClient.Transport
+	http.DefaultClient.Transport = roundTripFunc(func(r *http.Request) (*http.Response, error) {
+		if r.URL.Path != "/v1/perfumes/stream" {
+			t.Errorf("expected only stream request, got %s", r.URL)
+		}
+		if sex := r.URL.Query().Get("sex"); sex != "female" {
+			t.Errorf("expected sex female, got %q", sex)
+		}
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Body:       io.NopCloser(strings.NewReader(body.String())),
+			Header:     make(http.Header),
+			Request:    r,
+		}, nil
+	})
+	t.Cleanup(func() {
+		http.DefaultClient.Transport = origTransport
+	})
+
+	fetcher := NewPerfumeHub("http://test-url:8080/v1/perfumes/get", "test-token", &config.MockConfigManager{}).
+		WithStreamUrl("http://test-url:8080/v1/perfumes/stream")
+	var perfumes []models.Perfume
+	for p := range fetcher.Fetch(context.Background(), parameters.RequestPerfume{Sex: models.Female}) {
+		perfumes = append(perfumes, p)
+	}
+
+	if len(perfumes) != len(expectedPerfumes) {
+		t.Fatalf("expected %d perfumes, got %d", len(expectedPerfumes), len(perfumes))
+	}
+	for i, p := range expectedPerfumes {
+		if !perfumes[i].Equal(p) {
+			t.Fatalf("perfume %d: expected %+v, got %+v", i, p, perfumes[i])
+		}
+	}
+}
+
+func TestDbFetcher_Fetch_StreamNotAvailable(t *testing.T) {
+	expectedPerfume := models.Perfume{Brand: "Chanel", Name: "No5", Sex: "female"}
+	body, err := json.Marshal(perfume.PerfumeResponse{Perfumes: []models.Perfume{expectedPerfume}})
+	if err != nil {
+		t.Fatalf("failed to marshal test data: %v", err)
+	}
+
+	origTransport := http.DefaultClient.Transport
+	http.DefaultClient.Transport = roundTripFunc(func(r *http.Request) (*http.Response, error) {
+		status, responseBody := http.StatusNotFound, "404 page not found"
+		if r.URL.Path == "/v1/perfumes/get" && r.URL.Query().Get("page") == "1" {
+			status, responseBody = http.StatusOK, string(body)
+		} else if r.URL.Path == "/v1/perfumes/get" {
+			responseBody = `{"perfumes":[]}`
+		}
+		return &http.Response{
+			StatusCode: status,
+			Body:       io.NopCloser(strings.NewReader(responseBody)),
+			Header:     make(http.Header),
+			Request:    r,
+		}, nil
+	})
+	t.Cleanup(func() {
+		http.DefaultClient.Transport = origTransport
+	})
+
+	mockConfig := &config.MockConfigManager{
+		GetIntWithDefaultFunc: func(key string, defaultValue int) int { return 1 },
+	}
+	fetcher := NewPerfumeHub("http://test-url:8080/v1/perfumes/get", "test-token", mockConfig).
+		WithStreamUrl("http://test-url:8080/v1/perfumes/stream")
+	var perfumes []models.Perfume
+	for p := range fetcher.Fetch(context.Background(), parameters.RequestPerfume{}) {
+		perfumes = append(perfumes, p)
+	}
+
+	if len(perfumes) != 1 || !perfumes[0].Equal(expectedPerfume) {
+		t.Fatalf("expected perfumes from pages, got %+v", perfumes)
+	}
+}
+
+func TestDbFetcher_FetchCatalog_IncompleteStream(t *testing.T) {
+	header := `{"version":"2026-01-09T10:36:08Z","count":2}` + "\n"
+	perfumeLine := `{"brand":"Chanel","name":"No5","sex":"female"}` + "\n"
+	for name, body := range map[string]string{
+		"short":  header + perfumeLine,
+		"broken": header + perfumeLine + `{"brand":"Dior","na`,
+	} {
+		t.Run(name, func(t *testing.T) {
+			origTransport := http.DefaultClient.Transport
+			http.DefaultClient.Transport = roundTripFunc(func(r *http.Request) (*http.Response, error) {
+				if r.URL.Path != "/v1/perfumes/stream" {
+					t.Errorf("expected no fallback to pages, got %s", r.URL)
+				}
+				return &http.Response{
+					StatusCode: http.StatusOK,
+					Body:       io.NopCloser(strings.NewReader(body)),
+					Header:     make(http.Header),
+					Request:    r,
+				}, nil
+			})
+			t.Cleanup(func() {
+				http.DefaultClient.Transport = origTransport
+			})
+
+			fetcher := NewPerfumeHub("http://test-url:8080/v1/perfumes/get", "test-token", &config.MockConfigManager{}).
+				WithStreamUrl("http://test-url:8080/v1/perfumes/stream")
+			perfumes, err := fetcher.FetchCatalog(context.Background(), parameters.RequestPerfume{Sex: models.Female})
+			if err == nil {
+				t.Fatalf("expected error for incomplete stream, got %+v", perfumes)
+			}
+			if perfumes != nil {
+				t.Fatalf("expected no perfumes from incomplete stream, got %+v", perfumes)
+			}
+		})
+	}
+}
+
+func TestDbFetcher_FetchCatalog_Pages(t *testing.T) {
+	pageBody := `{"perfumes":[{"brand":"Chanel","name":"No5","sex":"female"}]}`
+	for name, tt := range map[string]struct {
+		secondPage int
+		wantErr    bool
+	}{
+		"complete":     {secondPage: http.StatusOK},
+		"server error": {secondPage: http.StatusInternalServerError, wantErr: true},
+		"forbidden":    {secondPage: http.StatusForbidden, wantErr: true},
+	} {
+		t.Run(name, func(t *testing.T) {
+			origTransport := http.DefaultClient.Transport
+			http.DefaultClient.Transport = roundTripFunc(func(r *http.Request) (*http.Response, error) {
+				status, body := http.StatusNotFound, `{"perfumes":[]}`
+				switch r.URL.Query().Get("page") {
+				case "1":
+					status, body = http.StatusOK, pageBody
+				case "2":
+					status, body = tt.secondPage, pageBody
+				}
+				return &http.Response{
+					StatusCode: status,
+					Body:       io.NopCloser(strings.NewReader(body)),
+					Header:     make(http.Header),
+					Request:    r,
+				}, nil
+			})
+			t.Cleanup(func() {
+				http.DefaultClient.Transport = origTransport
+			})
+
+			fetcher := NewPerfumeHub("http://test-url:8080/v1/perfumes/get", "test-token", &config.MockConfigManager{})
+			perfumes, err := fetcher.FetchCatalog(context.Background(), parameters.RequestPerfume{Sex: models.Female})
+			if tt.wantErr {
+				if err == nil || perfumes != nil {
+					t.Fatalf("expected error for a lost page, got %+v", perfumes)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("expected no error, got %v", err)
+			}
+			if len(perfumes) != 2 {
+				t.Fatalf("expected perfumes of both pages, got %+v", perfumes)
+			}
+		})
+	}
+}
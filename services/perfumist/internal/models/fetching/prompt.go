@@ -0,0 +1,254 @@
+package fetching
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"maps"
+	"slices"
+	"strings"
+	"sync"
+	"text/template"
+	"time"
+
+	"github.com/zemld/config-manager/pkg/cm"
+)
+
+const (
+	defaultSystemPrompt = `You are an expert perfume recommender. Your task is to suggest alternative perfumes based on the user's favorite one. Do not include explanations, text, or markdown — only JSON.`
+
+	defaultUserPrompt = `User's favorite perfume:
+Brand: {{.Brand}}
+Name: {{.Name}}
+Sex: {{.Sex}}
+
+Return exactly {{.Count}} other perfumes that the user might also like.
+Each item must include:
+- brand
+- name
+- sex (must be one of: "male", "female", "unisex")
+
+Sex constraint based on user's Sex:
+- if user's Sex is "unisex": returned items' sex MUST be only "unisex"
+- if user's Sex is "male": returned items' sex MUST be one of: "male", "unisex"
+- if user's Sex is "female": returned items' sex MUST be one of: "female", "unisex"
+Allowed for this request: {{join .AllowedSexes ", "}}
+{{if .ExcludedNotes}}
+Returned perfumes MUST NOT contain these notes: {{join .ExcludedNotes ", "}}
+{{end}}{{if .MaxPrice}}
+Prefer perfumes that cost no more than {{.MaxPrice}} rubles.
+{{end}}
+Respond strictly in this JSON format:
+[
+{"brand": "string", "name": "string", "sex": "string"}
+]`
+
+	defaultTextSystemPrompt = `You are an expert perfumer. Your task is to translate a customer's free-text wish into search constraints. Use only the tags, notes and characteristics listed by the user. Do not include explanations, text, or markdown — only JSON.`
+
+	defaultTextUserPrompt = `Customer's wish:
+{{.Text}}
+
+Allowed tags: {{join .Tags ", "}}
+Allowed notes: {{join .Notes ", "}}
+Allowed characteristics: {{join .Characteristics ", "}}
+
+Pick at most {{.MaxTags}} tags and at most {{.MaxNotes}} notes that describe the wish.
+For characteristics the wish clearly implies, set a target between 0 and 1.
+Leave a list or the characteristics object empty if the wish does not mention it.
+
+Respond strictly in this JSON format:
+{"tags": ["string"], "notes": ["string"], "characteristics": {"string": 0.5}}`
+
+	defaultDescribeSystemPrompt = `You are a perfume consultant. Explain in one or two sentences why the suggested perfume resembles the favourite one. Rely only on the facts given by the user and never mention notes, accords or characteristics that are not listed. Answer in plain text without markdown.`
+
+	defaultDescribeUserPrompt = `Favourite perfume: {{.Favourite}}
+Suggested perfume: {{.Suggestion}}
+{{if .Notes}}Shared notes: {{join .Notes ", "}}
+{{end}}{{if .Families}}Shared families: {{join .Families ", "}}
+{{end}}{{if .Tags}}Shared tags: {{join .Tags ", "}}
+{{end}}{{if .Characteristics}}Both are pronounced in: {{join .Characteristics ", "}}
+{{end}}
+Write the explanation in {{.Language}}.`
+)
+
+type PromptData struct {
+	Brand         string
+	Name          string
+	Sex           string
+	AllowedSexes  []string
+	Count         int
+	ExcludedNotes []string
+	MaxPrice      int
+}
+
+type TextPromptData struct {
+	Text            string
+	Tags            []string
+	Notes           []string
+	Characteristics []string
+	MaxTags         int
+	MaxNotes        int
+}
+
+type DescribePromptData struct {
+	Favourite       string
+	Suggestion      string
+	Notes           []string
+	Families        []string
+	Tags            []string
+	Characteristics []string
+	Language        string
+}
+
+var promptFuncs = template.FuncMap{"join": strings.Join}
+
+// Sample data has every optional field set, so that validation executes all
+// branches of a template.
+var sampleData = PromptData{
+	Brand:         "Dior",
+	Name:          "Sauvage",
+	Sex:           "male",
+	AllowedSexes:  []string{"unisex", "male"},
+	Count:         4,
+	ExcludedNotes: []string{"oud"},
+	MaxPrice:      10000,
+}
+
+var sampleTextData = TextPromptData{
+	Text:            "something warm and cozy for winter evenings",
+	Tags:            []string{"warm", "sweet"},
+	Notes:           []string{"vanilla", "amber"},
+	Characteristics: []string{"warmth", "sweetness"},
+	MaxTags:         5,
+	MaxNotes:        5,
+}
+
+var sampleDescribeData = DescribePromptData{
+	Favourite:       "Dior Sauvage",
+	Suggestion:      "Chanel Bleu de Chanel",
+	Notes:           []string{"Bergamot", "Ambroxan"},
+	Families:        []string{"Aromatic"},
+	Tags:            []string{"fresh", "woody"},
+	Characteristics: []string{"freshness"},
+	Language:        "Russian",
+}
+
+func parsePrompt(name string, source string, sample any) (*template.Template, error) {
+	tmpl, err := template.New(name).Funcs(promptFuncs).Parse(source)
+	if err != nil {
+		return nil, err
+	}
+	if err := tmpl.Execute(io.Discard, sample); err != nil {
+		return nil, err
+	}
+	return tmpl, nil
+}
+
+type cachedPrompt struct {
+	source string
+	tmpl   *template.Template
+}
+
+type defaultPrompt struct {
+	tmpl   *template.Template
+	sample any
+}
+
+func newDefaultPrompt(name string, source string, sample any) defaultPrompt {
+	return defaultPrompt{tmpl: template.Must(parsePrompt(name, source, sample)), sample: sample}
+}
+
+// promptTemplates keeps the last parsed template of every config key. Config is
+// reloaded in the background, so the templates are validated after each reload
+// by WatchPrompts; an invalid one is reported once and replaced by the default
+// template. A source changed between two validations is parsed on render.
+type promptTemplates struct {
+	mu       sync.Mutex
+	cached   map[string]cachedPrompt
+	defaults map[string]defaultPrompt
+}
+
+var prompts = &promptTemplates{
+	cached: make(map[string]cachedPrompt),
+	defaults: map[string]defaultPrompt{
+		"ai_system_prompt":   newDefaultPrompt("ai_system_prompt", defaultSystemPrompt, sampleData),
+		"ai_user_prompt":     newDefaultPrompt("ai_user_prompt", defaultUserPrompt, sampleData),
+		"text_system_prompt": newDefaultPrompt("text_system_prompt", defaultTextSystemPrompt, sampleTextData),
+		"text_user_prompt":   newDefaultPrompt("text_user_prompt", defaultTextUserPrompt, sampleTextData),
+
+		"describe_system_prompt": newDefaultPrompt("describe_system_prompt", defaultDescribeSystemPrompt, sampleDescribeData),
+		"describe_user_prompt":   newDefaultPrompt("describe_user_prompt", defaultDescribeUserPrompt, sampleDescribeData),
+	},
+}
+
+func (p *promptTemplates) render(cm cm.ConfigManager, key string, data any) (string, error) {
+	var rendered strings.Builder
+	if err := p.template(cm, key).Execute(&rendered, data); err != nil {
+		return "", fmt.Errorf("cannot render %s: %w", key, err)
+	}
+	return strings.TrimSpace(rendered.String()), nil
+}
+
+func (p *promptTemplates) template(cm cm.ConfigManager, key string) *template.Template {
+	tmpl, err := p.load(cm, key)
+	if err != nil {
+		log.Printf("Invalid %s, using the default one: %v\n", key, err)
+	}
+	return tmpl
+}
+
+// load returns the template of the key and an error only when its source has
+// changed since the last call and doesn't parse.
+func (p *promptTemplates) load(cm cm.ConfigManager, key string) (*template.Template, error) {
+	source := cm.GetStringWithDefault(key, "")
+	if source == "" {
+		return p.defaults[key].tmpl, nil
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	var err error
+	cached, ok := p.cached[key]
+	if !ok || cached.source != source {
+		var tmpl *template.Template
+		tmpl, err = parsePrompt(key, source, p.defaults[key].sample)
+		cached = cachedPrompt{source: source, tmpl: tmpl}
+		p.cached[key] = cached
+	}
+	if cached.tmpl == nil {
+		return p.defaults[key].tmpl, err
+	}
+	return cached.tmpl, nil
+}
+
+func (p *promptTemplates) validate(cm cm.ConfigManager) error {
+	keys := slices.Sorted(maps.Keys(p.defaults))
+	var errs []error
+	for _, key := range keys {
+		if _, err := p.load(cm, key); err != nil {
+			errs = append(errs, fmt.Errorf("invalid %s, the default one is used: %w", key, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// WatchPrompts validates the ai_*, text_* and describe_* prompt templates right
+// away and then every interval, the period config is reloaded with, until ctx
+// is done.
+func WatchPrompts(ctx context.Context, cm cm.ConfigManager, interval time.Duration) {
+	for {
+		if err := prompts.validate(cm); err != nil {
+			log.Printf("Prompt templates from config: %v\n", err)
+		}
+		timer := time.NewTimer(interval)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return
+		case <-timer.C:
+		}
+	}
+}
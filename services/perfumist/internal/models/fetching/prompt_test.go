@@ -0,0 +1,128 @@
+package fetching
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/zemld/Scently/models"
+	"github.com/zemld/Scently/perfumist/internal/config"
+	"github.com/zemld/Scently/perfumist/internal/models/llm"
+	"github.com/zemld/Scently/perfumist/internal/models/parameters"
+)
+
+func promptConfig(source *string) *config.MockConfigManager {
+	return &config.MockConfigManager{
+		GetStringWithDefaultFunc: func(key string, defaultValue string) string {
+			if key == "ai_user_prompt" {
+				return *source
+			}
+			return defaultValue
+		},
+	}
+}
+
+func TestCreateRequest_DefaultPromptWithConstraints(t *testing.T) {
+	t.Parallel()
+
+	fetcher := NewAI(llm.NewFallback(), &config.MockConfigManager{}).WithCount(6)
+	param := *parameters.NewGet().WithBrand("Dior").WithName("Sauvage").WithSex(models.Male).
+		WithExclusions(parameters.Exclusions{Notes: []string{"oud", "patchouli"}}).
+		WithOffers(parameters.OfferFilter{MaxPrice: 9000})
+
+	request, err := fetcher.createRequest(param)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	userPrompt := request.Messages[1].Text
+	for _, expected := range []string{"Brand: Dior", "Return exactly 6 other perfumes", "Allowed for this request: unisex, male", "oud, patchouli", "9000 rubles"} {
+		if !strings.Contains(userPrompt, expected) {
+			t.Fatalf("expected prompt to contain %q, got %q", expected, userPrompt)
+		}
+	}
+
+	generated := request.Schema.(schema)
+	if generated.MinItems != 6 || generated.MaxItems != 6 || strings.Join(generated.Items.Properties.Sex.Enum, ",") != "unisex,male" {
+		t.Fatalf("expected schema to match the prompt, got %+v", generated)
+	}
+
+	request, _ = fetcher.createRequest(*parameters.NewGet().WithBrand("Dior").WithName("Sauvage").WithSex(models.Male))
+	if strings.Contains(request.Messages[1].Text, "MUST NOT contain") || strings.Contains(request.Messages[1].Text, "rubles") {
+		t.Fatalf("expected no constraints in prompt, got %q", request.Messages[1].Text)
+	}
+}
+
+func TestCreateRequest_PromptFromConfigIsReloaded(t *testing.T) {
+	t.Parallel()
+
+	source := "Suggest {{.Count}} perfumes like {{.Brand}} {{.Name}}"
+	fetcher := NewAI(llm.NewFallback(), promptConfig(&source))
+	param := *parameters.NewGet().WithBrand("Dior").WithName("Sauvage")
+
+	request, err := fetcher.createRequest(param)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if request.Messages[1].Text != "Suggest 4 perfumes like Dior Sauvage" {
+		t.Fatalf("unexpected prompt %q", request.Messages[1].Text)
+	}
+
+	source = "Find {{.Count}} alternatives to {{.Name}}"
+	request, _ = fetcher.createRequest(param)
+	if request.Messages[1].Text != "Find 4 alternatives to Sauvage" {
+		t.Fatalf("expected changed template to be used, got %q", request.Messages[1].Text)
+	}
+}
+
+func TestCreateRequest_InvalidPromptFallsBackToDefault(t *testing.T) {
+	t.Parallel()
+
+	for _, source := range []string{"Suggest {{.Count", "Suggest {{.Budget}} perfumes"} {
+		fetcher := NewAI(llm.NewFallback(), promptConfig(&source))
+		request, err := fetcher.createRequest(*parameters.NewGet().WithBrand("Dior").WithName("Sauvage"))
+		if err != nil {
+			t.Fatalf("%q: expected no error, got %v", source, err)
+		}
+		if !strings.Contains(request.Messages[1].Text, "Return exactly 4 other perfumes") {
+			t.Fatalf("%q: expected default prompt, got %q", source, request.Messages[1].Text)
+		}
+	}
+}
+
+func TestPromptTemplates_Validate(t *testing.T) {
+	t.Parallel()
+
+	sources := map[string]string{
+		"ai_user_prompt":       "Suggest {{.Count}} perfumes like {{.Name}}",
+		"text_system_prompt":   "Use only {{.Tags",
+		"describe_user_prompt": "Describe {{.Budget}}",
+	}
+	cm := &config.MockConfigManager{
+		GetStringWithDefaultFunc: func(key string, defaultValue string) string {
+			if source, ok := sources[key]; ok {
+				return source
+			}
+			return defaultValue
+		},
+	}
+	templates := &promptTemplates{cached: make(map[string]cachedPrompt), defaults: prompts.defaults}
+
+	err := templates.validate(cm)
+	if err == nil {
+		t.Fatal("expected invalid templates to be reported")
+	}
+	for _, key := range []string{"text_system_prompt", "describe_user_prompt"} {
+		if !strings.Contains(err.Error(), key) {
+			t.Fatalf("expected %s to be reported, got %v", key, err)
+		}
+	}
+	if strings.Contains(err.Error(), "ai_user_prompt") {
+		t.Fatalf("expected valid ai_user_prompt not to be reported, got %v", err)
+	}
+
+	if err := templates.validate(cm); err != nil {
+		t.Fatalf("expected unchanged templates to be reported once, got %v", err)
+	}
+	if templates.template(cm, "text_system_prompt") != prompts.defaults["text_system_prompt"].tmpl {
+		t.Fatal("expected invalid template to be replaced by the default one")
+	}
+}
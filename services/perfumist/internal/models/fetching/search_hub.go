@@ -0,0 +1,79 @@
+package fetching
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"strconv"
+
+	"github.com/zemld/Scently/models"
+	"github.com/zemld/Scently/perfumist/internal/errors"
+	"github.com/zemld/Scently/perfumist/internal/models/perfume"
+)
+
+type PerfumeSearcher interface {
+	Search(ctx context.Context, query string, sex models.Sex, limit int) ([]SearchResult, error)
+}
+
+type SearchResult struct {
+	Brand string     `json:"brand"`
+	Name  string     `json:"name"`
+	Sex   models.Sex `json:"sex"`
+	Score float64    `json:"score"`
+}
+
+type SearchResponse struct {
+	Perfumes []SearchResult `json:"perfumes"`
+	State    perfume.State  `json:"state"`
+}
+
+type PerfumeHubSearch struct {
+	url    string
+	token  string
+	client *http.Client
+}
+
+func NewPerfumeHubSearch(url string, token string) *PerfumeHubSearch {
+	return &PerfumeHubSearch{url: url, token: token, client: http.DefaultClient}
+}
+
+func (f *PerfumeHubSearch) Search(ctx context.Context, query string, sex models.Sex, limit int) ([]SearchResult, error) {
+	r, err := http.NewRequestWithContext(ctx, "GET", f.url, nil)
+	if err != nil {
+		return nil, errors.NewServiceError("can't create search request", err)
+	}
+	values := r.URL.Query()
+	values.Set("q", query)
+	values.Set("sex", string(sex))
+	values.Set("limit", strconv.Itoa(limit))
+	r.URL.RawQuery = values.Encode()
+	r.Header.Set("Authorization", fmt.Sprintf("Bearer %s", f.token))
+
+	response, err := f.client.Do(r)
+	if err != nil {
+		return nil, errors.NewServiceError("can't search perfumes", err)
+	}
+	defer response.Body.Close()
+
+	switch response.StatusCode {
+	case http.StatusOK:
+	case http.StatusBadRequest:
+		return []SearchResult{}, nil
+	default:
+		return nil, errors.NewServiceError(fmt.Sprintf("unexpected search response status: %d", response.StatusCode), nil)
+	}
+
+	body, err := io.ReadAll(response.Body)
+	if err != nil {
+		return nil, errors.NewServiceError("can't read search response", err)
+	}
+	var results SearchResponse
+	if err := json.Unmarshal(body, &results); err != nil {
+		return nil, errors.NewServiceError("can't unmarshal search response", err)
+	}
+	log.Printf("Found %d perfumes by %q", len(results.Perfumes), query)
+	return results.Perfumes, nil
+}
@@ -0,0 +1,64 @@
+package fetching
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/zemld/Scently/models"
+	"github.com/zemld/Scently/perfumist/internal/errors"
+)
+
+func TestPerfumeHubSearch_Search_Success(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+		if query.Get("q") != "Dior Sauvge" || query.Get("sex") != "male" || query.Get("limit") != "5" {
+			t.Errorf("unexpected query %q", r.URL.RawQuery)
+		}
+		if r.Header.Get("Authorization") != "Bearer test-token" {
+			t.Errorf("expected bearer token, got %q", r.Header.Get("Authorization"))
+		}
+		json.NewEncoder(w).Encode(SearchResponse{Perfumes: []SearchResult{{Brand: "Dior", Name: "Sauvage", Sex: models.Male, Score: 0.8}}})
+	}))
+	defer server.Close()
+
+	results, err := NewPerfumeHubSearch(server.URL, "test-token").Search(context.Background(), "Dior Sauvge", models.Male, 5)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if len(results) != 1 || results[0].Name != "Sauvage" {
+		t.Fatalf("expected Dior Sauvage, got %+v", results)
+	}
+}
+
+func TestPerfumeHubSearch_Search_ShortQuery(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+	}))
+	defer server.Close()
+
+	results, err := NewPerfumeHubSearch(server.URL, "test-token").Search(context.Background(), "d", models.Unisex, 5)
+	if err != nil || len(results) != 0 {
+		t.Fatalf("expected no results and no error, got %+v, %v", results, err)
+	}
+}
+
+func TestPerfumeHubSearch_Search_ServerError(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	_, err := NewPerfumeHubSearch(server.URL, "test-token").Search(context.Background(), "dior", models.Unisex, 5)
+	if _, ok := err.(*errors.ServiceError); !ok {
+		t.Fatalf("expected ServiceError, got %v", err)
+	}
+}
@@ -0,0 +1,194 @@
+package fetching
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/zemld/Scently/perfumist/internal/errors"
+	"github.com/zemld/Scently/perfumist/internal/models/llm"
+	"github.com/zemld/Scently/perfumist/internal/models/parameters"
+	"github.com/zemld/config-manager/pkg/cm"
+)
+
+// Vocabulary lists the tags, notes and characteristics that perfumes of the
+// catalog are described with.
+type Vocabulary struct {
+	Tags            []string
+	Notes           []string
+	Characteristics []string
+}
+
+type Constraints struct {
+	Tags            []string           `json:"tags"`
+	Notes           []string           `json:"notes"`
+	Characteristics map[string]float64 `json:"characteristics"`
+}
+
+func (c Constraints) Empty() bool {
+	return len(c.Tags) == 0 && len(c.Notes) == 0 && len(c.Characteristics) == 0
+}
+
+type constraintsSchema struct {
+	Type_                string                `json:"type"`
+	Properties           constraintsProperties `json:"properties"`
+	Required             []string              `json:"required"`
+	AdditionalProperties bool                  `json:"additionalProperties"`
+}
+
+type constraintsProperties struct {
+	Tags            arrayType  `json:"tags"`
+	Notes           arrayType  `json:"notes"`
+	Characteristics objectType `json:"characteristics"`
+}
+
+type arrayType struct {
+	Type_    string    `json:"type"`
+	Items    valueType `json:"items"`
+	MaxItems int       `json:"maxItems"`
+}
+
+type objectType struct {
+	Type_                string               `json:"type"`
+	Properties           map[string]valueType `json:"properties"`
+	AdditionalProperties bool                 `json:"additionalProperties"`
+}
+
+type TextInterpreter struct {
+	provider llm.Provider
+	cm       cm.ConfigManager
+}
+
+func NewTextInterpreter(provider llm.Provider, cm cm.ConfigManager) *TextInterpreter {
+	return &TextInterpreter{provider: provider, cm: cm}
+}
+
+// Interpret asks the LLM to describe text with the vocabulary. Anything the
+// model answers outside of the vocabulary is dropped.
+func (i *TextInterpreter) Interpret(ctx context.Context, text string, vocabulary Vocabulary) (Constraints, error) {
+	ctx, cancel := context.WithTimeout(ctx, i.cm.GetDurationWithDefault("ai_fetcher_timeout", 20*time.Second))
+	defer cancel()
+
+	request, err := i.createRequest(text, vocabulary)
+	if err != nil {
+		return Constraints{}, errors.NewServiceError("can't create llm request", err)
+	}
+	completion, err := i.provider.Complete(ctx, request)
+	if err != nil {
+		return Constraints{}, errors.NewServiceError(fmt.Sprintf("can't get completion from %s", i.provider.Name()), err)
+	}
+	constraints, err := i.tryParseResponse(completion)
+	if err != nil {
+		return Constraints{}, errors.NewServiceError(fmt.Sprintf("can't parse completion from %s", i.provider.Name()), err)
+	}
+
+	constraints = i.restrict(constraints, vocabulary)
+	if constraints.Empty() {
+		return Constraints{}, errors.NewValidationError(parameters.TextParamKey, "does not match any tag, note or characteristic")
+	}
+	return constraints, nil
+}
+
+func (i *TextInterpreter) createRequest(text string, vocabulary Vocabulary) (llm.Request, error) {
+	data := TextPromptData{
+		Text:            text,
+		Tags:            vocabulary.Tags,
+		Notes:           vocabulary.Notes,
+		Characteristics: vocabulary.Characteristics,
+		MaxTags:         i.cm.GetIntWithDefault("text_max_tags", 5),
+		MaxNotes:        i.cm.GetIntWithDefault("text_max_notes", 5),
+	}
+	systemPrompt, err := prompts.render(i.cm, "text_system_prompt", data)
+	if err != nil {
+		return llm.Request{}, err
+	}
+	userPrompt, err := prompts.render(i.cm, "text_user_prompt", data)
+	if err != nil {
+		return llm.Request{}, err
+	}
+
+	characteristics := make(map[string]valueType, len(vocabulary.Characteristics))
+	for _, characteristic := range vocabulary.Characteristics {
+		characteristics[characteristic] = valueType{Type_: "number"}
+	}
+	return llm.Request{
+		Messages: []llm.Message{
+			{Role: "system", Text: systemPrompt},
+			{Role: "user", Text: userPrompt},
+		},
+		MaxTokens:   i.cm.GetIntWithDefault("llm_max_tokens", 500),
+		Temperature: i.cm.GetFloatWithDefault("llm_temperature", 0.4),
+		Schema: constraintsSchema{
+			Type_: "object",
+			Properties: constraintsProperties{
+				Tags:  arrayType{Type_: "array", Items: valueType{Type_: "string", Enum: vocabulary.Tags}, MaxItems: data.MaxTags},
+				Notes: arrayType{Type_: "array", Items: valueType{Type_: "string"}, MaxItems: data.MaxNotes},
+				Characteristics: objectType{
+					Type_:                "object",
+					Properties:           characteristics,
+					AdditionalProperties: false,
+				},
+			},
+			Required:             []string{"tags", "notes", "characteristics"},
+			AdditionalProperties: false,
+		},
+	}, nil
+}
+
+func (i *TextInterpreter) tryParseResponse(text string) (Constraints, error) {
+	start, end := strings.Index(text, "{"), strings.LastIndex(text, "}")
+	if start == -1 || end < start {
+		return Constraints{}, fmt.Errorf("no json object in completion")
+	}
+
+	var constraints Constraints
+	if err := json.Unmarshal([]byte(text[start:end+1]), &constraints); err != nil {
+		return Constraints{}, err
+	}
+	return constraints, nil
+}
+
+func (i *TextInterpreter) restrict(constraints Constraints, vocabulary Vocabulary) Constraints {
+	restricted := Constraints{
+		Tags:            pickKnown(constraints.Tags, vocabulary.Tags, i.cm.GetIntWithDefault("text_max_tags", 5)),
+		Notes:           pickKnown(constraints.Notes, vocabulary.Notes, i.cm.GetIntWithDefault("text_max_notes", 5)),
+		Characteristics: make(map[string]float64),
+	}
+	known := lowerIndex(vocabulary.Characteristics)
+	for characteristic, value := range constraints.Characteristics {
+		if name, ok := known[strings.ToLower(strings.TrimSpace(characteristic))]; ok {
+			restricted.Characteristics[name] = min(max(value, 0), 1)
+		}
+	}
+	return restricted
+}
+
+// pickKnown keeps the first limit items found in the vocabulary, spelled as in
+// the vocabulary.
+func pickKnown(items []string, vocabulary []string, limit int) []string {
+	known := lowerIndex(vocabulary)
+	picked := make([]string, 0, len(items))
+	seen := make(map[string]bool, len(items))
+	for _, item := range items {
+		name, ok := known[strings.ToLower(strings.TrimSpace(item))]
+		if !ok || seen[name] {
+			continue
+		}
+		if len(picked) == limit {
+			break
+		}
+		seen[name] = true
+		picked = append(picked, name)
+	}
+	return picked
+}
+
+func lowerIndex(vocabulary []string) map[string]string {
+	index := make(map[string]string, len(vocabulary))
+	for _, name := range vocabulary {
+		index[strings.ToLower(name)] = name
+	}
+	return index
+}
@@ -0,0 +1,82 @@
+package fetching
+
+import (
+	"context"
+	"io"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/zemld/Scently/perfumist/internal/config"
+	"github.com/zemld/Scently/perfumist/internal/errors"
+)
+
+var testVocabulary = Vocabulary{
+	Tags:            []string{"fresh", "sweet", "warm", "woody"},
+	Notes:           []string{"Amber", "Bergamot", "Vanilla"},
+	Characteristics: []string{"sweetness", "warmth"},
+}
+
+func TestTextInterpreter_Interpret_RestrictsToVocabulary(t *testing.T) {
+	t.Parallel()
+
+	provider := &stubProvider{text: "```json\n" + `{"tags": ["Warm", "cozy", "sweet", "warm"], "notes": ["vanilla", "cashmere"], "characteristics": {"Warmth": 1.3, "darkness": 0.8}}` + "\n```"}
+	interpreter := NewTextInterpreter(provider, &config.MockConfigManager{})
+
+	constraints, err := interpreter.Interpret(context.Background(), "something warm and cozy", testVocabulary)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if !slices.Equal(constraints.Tags, []string{"warm", "sweet"}) {
+		t.Fatalf("expected known deduplicated tags, got %v", constraints.Tags)
+	}
+	if !slices.Equal(constraints.Notes, []string{"Vanilla"}) {
+		t.Fatalf("expected known notes spelled as in vocabulary, got %v", constraints.Notes)
+	}
+	if len(constraints.Characteristics) != 1 || constraints.Characteristics["warmth"] != 1 {
+		t.Fatalf("expected clamped warmth only, got %v", constraints.Characteristics)
+	}
+}
+
+func TestTextInterpreter_Interpret_NothingKnown(t *testing.T) {
+	t.Parallel()
+
+	provider := &stubProvider{text: `{"tags": ["cozy"], "notes": [], "characteristics": {}}`}
+	_, err := NewTextInterpreter(provider, &config.MockConfigManager{}).Interpret(context.Background(), "cozy", testVocabulary)
+	if _, ok := err.(*errors.ValidationError); !ok {
+		t.Fatalf("expected validation error, got %v", err)
+	}
+}
+
+func TestTextInterpreter_Interpret_ProviderError(t *testing.T) {
+	t.Parallel()
+
+	_, err := NewTextInterpreter(&stubProvider{err: io.ErrUnexpectedEOF}, &config.MockConfigManager{}).
+		Interpret(context.Background(), "cozy", testVocabulary)
+	if _, ok := err.(*errors.ServiceError); !ok {
+		t.Fatalf("expected service error, got %v", err)
+	}
+}
+
+func TestTextInterpreter_CreateRequest(t *testing.T) {
+	t.Parallel()
+
+	interpreter := NewTextInterpreter(&stubProvider{}, &config.MockConfigManager{})
+	request, err := interpreter.createRequest("something warm", testVocabulary)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	for _, expected := range []string{"something warm", "Allowed tags: fresh, sweet, warm, woody", "Allowed notes: Amber, Bergamot, Vanilla"} {
+		if !strings.Contains(request.Messages[1].Text, expected) {
+			t.Fatalf("expected prompt to contain %q, got %q", expected, request.Messages[1].Text)
+		}
+	}
+
+	generated := request.Schema.(constraintsSchema)
+	if !slices.Equal(generated.Properties.Tags.Items.Enum, testVocabulary.Tags) {
+		t.Fatalf("expected tags enum from vocabulary, got %v", generated.Properties.Tags.Items.Enum)
+	}
+	if len(generated.Properties.Characteristics.Properties) != 2 {
+		t.Fatalf("expected a property per characteristic, got %v", generated.Properties.Characteristics.Properties)
+	}
+}
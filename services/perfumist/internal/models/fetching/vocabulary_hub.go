@@ -0,0 +1,104 @@
+package fetching
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/zemld/Scently/perfumist/internal/errors"
+	"github.com/zemld/Scently/perfumist/internal/models/matching"
+	"github.com/zemld/Scently/perfumist/internal/models/perfume"
+	"github.com/zemld/config-manager/pkg/cm"
+)
+
+type VocabularyFetcher interface {
+	FetchVocabulary(ctx context.Context) (Vocabulary, error)
+}
+
+type VocabularyResponse struct {
+	Items []VocabularyItem `json:"items"`
+	State perfume.State    `json:"state"`
+}
+
+type VocabularyItem struct {
+	Name string `json:"name"`
+}
+
+// PerfumeHubVocabulary reads the tags and notes tables of perfume-hub and keeps
+// them for vocabulary_cache_ttl. Characteristics are the axes of the matchers.
+type PerfumeHubVocabulary struct {
+	url    string
+	token  string
+	client *http.Client
+	ttl    time.Duration
+
+	mu       sync.Mutex
+	cached   Vocabulary
+	loadedAt time.Time
+}
+
+func NewPerfumeHubVocabulary(url string, token string, cm cm.ConfigManager) *PerfumeHubVocabulary {
+	return &PerfumeHubVocabulary{
+		url:    url,
+		token:  token,
+		client: http.DefaultClient,
+		ttl:    cm.GetDurationWithDefault("vocabulary_cache_ttl", 10*time.Minute),
+	}
+}
+
+func (f *PerfumeHubVocabulary) FetchVocabulary(ctx context.Context) (Vocabulary, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if !f.loadedAt.IsZero() && time.Since(f.loadedAt) < f.ttl {
+		return f.cached, nil
+	}
+
+	tags, err := f.fetchWords(ctx, "tags")
+	if err != nil {
+		return Vocabulary{}, err
+	}
+	notes, err := f.fetchWords(ctx, "notes")
+	if err != nil {
+		return Vocabulary{}, err
+	}
+	if len(tags) == 0 || len(notes) == 0 {
+		return Vocabulary{}, errors.NewServiceError("vocabulary is empty", nil)
+	}
+
+	f.cached = Vocabulary{Tags: tags, Notes: notes, Characteristics: slices.Clone(matching.CharacteristicNames)}
+	f.loadedAt = time.Now()
+	log.Printf("Got vocabulary: %d tags, %d notes", len(tags), len(notes))
+	return f.cached, nil
+}
+
+func (f *PerfumeHubVocabulary) fetchWords(ctx context.Context, kind string) ([]string, error) {
+	r, err := http.NewRequestWithContext(ctx, "GET", f.url+"/"+kind, nil)
+	if err != nil {
+		return nil, errors.NewServiceError("can't create vocabulary request", err)
+	}
+	r.Header.Set("Authorization", fmt.Sprintf("Bearer %s", f.token))
+
+	response, err := f.client.Do(r)
+	if err != nil {
+		return nil, errors.NewServiceError(fmt.Sprintf("can't get %s vocabulary", kind), err)
+	}
+	defer response.Body.Close()
+	if response.StatusCode != http.StatusOK {
+		return nil, errors.NewServiceError(fmt.Sprintf("unexpected %s vocabulary response status: %d", kind, response.StatusCode), nil)
+	}
+
+	var vocabulary VocabularyResponse
+	if err := json.NewDecoder(response.Body).Decode(&vocabulary); err != nil {
+		return nil, errors.NewServiceError(fmt.Sprintf("can't unmarshal %s vocabulary response", kind), err)
+	}
+	words := make([]string, 0, len(vocabulary.Items))
+	for _, item := range vocabulary.Items {
+		words = append(words, item.Name)
+	}
+	return words, nil
+}
@@ -0,0 +1,67 @@
+package fetching
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"sync/atomic"
+	"testing"
+
+	"github.com/zemld/Scently/perfumist/internal/config"
+)
+
+func TestPerfumeHubVocabulary_FetchVocabulary(t *testing.T) {
+	t.Parallel()
+
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		if r.Header.Get("Authorization") != "Bearer test-token" {
+			t.Errorf("expected bearer token, got %q", r.Header.Get("Authorization"))
+		}
+		words := map[string][]string{"/tags": {"sweet", "woody"}, "/notes": {"oud"}}[r.URL.Path]
+		items := make([]VocabularyItem, 0, len(words))
+		for _, word := range words {
+			items = append(items, VocabularyItem{Name: word})
+		}
+		json.NewEncoder(w).Encode(VocabularyResponse{Items: items})
+	}))
+	defer server.Close()
+
+	fetcher := NewPerfumeHubVocabulary(server.URL, "test-token", &config.MockConfigManager{})
+	for range 2 {
+		vocabulary, err := fetcher.FetchVocabulary(context.Background())
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		if !slices.Equal(vocabulary.Tags, []string{"sweet", "woody"}) || !slices.Equal(vocabulary.Notes, []string{"oud"}) {
+			t.Fatalf("unexpected vocabulary: %+v", vocabulary)
+		}
+		if len(vocabulary.Characteristics) == 0 {
+			t.Fatal("expected characteristics of the matchers")
+		}
+	}
+	if requests.Load() != 2 {
+		t.Fatalf("expected vocabulary to be cached, got %d requests", requests.Load())
+	}
+}
+
+func TestPerfumeHubVocabulary_FetchVocabulary_Errors(t *testing.T) {
+	t.Parallel()
+
+	for name, handler := range map[string]http.HandlerFunc{
+		"status": func(w http.ResponseWriter, r *http.Request) { w.WriteHeader(http.StatusInternalServerError) },
+		"empty":  func(w http.ResponseWriter, r *http.Request) { json.NewEncoder(w).Encode(VocabularyResponse{}) },
+	} {
+		t.Run(name, func(t *testing.T) {
+			server := httptest.NewServer(handler)
+			defer server.Close()
+
+			if _, err := NewPerfumeHubVocabulary(server.URL, "test-token", &config.MockConfigManager{}).FetchVocabulary(context.Background()); err == nil {
+				t.Fatal("expected error")
+			}
+		})
+	}
+}
@@ -0,0 +1,44 @@
+package llm
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// Fallback asks the providers in order and returns the first answer.
+type Fallback struct {
+	providers []Provider
+}
+
+func NewFallback(providers ...Provider) *Fallback {
+	return &Fallback{providers: providers}
+}
+
+func (p *Fallback) Name() string {
+	names := make([]string, len(p.providers))
+	for i, provider := range p.providers {
+		names[i] = provider.Name()
+	}
+	return strings.Join(names, ",")
+}
+
+func (p *Fallback) Complete(ctx context.Context, request Request) (string, error) {
+	if len(p.providers) == 0 {
+		return "", fmt.Errorf("no llm providers configured")
+	}
+
+	var errs []error
+	for _, provider := range p.providers {
+		text, err := provider.Complete(ctx, request)
+		if err == nil {
+			return text, nil
+		}
+		errs = append(errs, fmt.Errorf("%s: %w", provider.Name(), err))
+		if ctx.Err() != nil {
+			break
+		}
+	}
+	return "", errors.Join(errs...)
+}
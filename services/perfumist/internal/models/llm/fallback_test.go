@@ -0,0 +1,41 @@
+package llm
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestFallback_UsesNextProvider(t *testing.T) {
+	t.Parallel()
+
+	primary := &scriptedProvider{name: "yandex", errs: []error{errors.New("unavailable")}}
+	secondary := &scriptedProvider{name: "openai"}
+	fallback := NewFallback(primary, secondary)
+
+	text, err := fallback.Complete(context.Background(), Request{})
+	if err != nil || text != "openai" {
+		t.Fatalf("expected answer of openai, got %q (%v)", text, err)
+	}
+	if fallback.Name() != "yandex,openai" {
+		t.Fatalf("unexpected name %q", fallback.Name())
+	}
+}
+
+func TestFallback_AllProvidersFail(t *testing.T) {
+	t.Parallel()
+
+	fallback := NewFallback(
+		&scriptedProvider{name: "yandex", errs: []error{errors.New("unavailable")}},
+		&scriptedProvider{name: "openai", errs: []error{errors.New("unauthorized")}},
+	)
+	_, err := fallback.Complete(context.Background(), Request{})
+	if err == nil || !strings.Contains(err.Error(), "yandex: unavailable") || !strings.Contains(err.Error(), "openai: unauthorized") {
+		t.Fatalf("expected errors of both providers, got %v", err)
+	}
+
+	if _, err := NewFallback().Complete(context.Background(), Request{}); err == nil {
+		t.Fatal("expected error without providers")
+	}
+}
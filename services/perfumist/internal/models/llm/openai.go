@@ -0,0 +1,63 @@
+package llm
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+)
+
+type openAIRequest struct {
+	Model       string          `json:"model"`
+	Messages    []openAIMessage `json:"messages"`
+	MaxTokens   int             `json:"max_tokens"`
+	Temperature float64         `json:"temperature"`
+}
+
+type openAIMessage struct {
+	Role    string `json:"role"`
+	Content string `json:"content"`
+}
+
+type openAIResponse struct {
+	Choices []struct {
+		Message openAIMessage `json:"message"`
+	} `json:"choices"`
+}
+
+// OpenAI is a client of any OpenAI compatible chat completions API. Structured
+// outputs require an object at the top level, so the schema is left to the prompt.
+type OpenAI struct {
+	url    string
+	model  string
+	apiKey string
+	client *http.Client
+}
+
+func NewOpenAI(url string, model string, apiKey string) *OpenAI {
+	return &OpenAI{url: url, model: model, apiKey: apiKey, client: http.DefaultClient}
+}
+
+func (p *OpenAI) Name() string {
+	return "openai"
+}
+
+func (p *OpenAI) Complete(ctx context.Context, request Request) (string, error) {
+	body := openAIRequest{
+		Model:       p.model,
+		Messages:    make([]openAIMessage, len(request.Messages)),
+		MaxTokens:   request.MaxTokens,
+		Temperature: request.Temperature,
+	}
+	for i, message := range request.Messages {
+		body.Messages[i] = openAIMessage{Role: message.Role, Content: message.Text}
+	}
+
+	var response openAIResponse
+	if err := postJSON(ctx, p.client, p.Name(), p.url, p.apiKey, body, &response); err != nil {
+		return "", err
+	}
+	if len(response.Choices) == 0 {
+		return "", fmt.Errorf("no choices in response")
+	}
+	return response.Choices[0].Message.Content, nil
+}
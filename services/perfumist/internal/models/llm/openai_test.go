@@ -0,0 +1,54 @@
+package llm
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestOpenAI_Complete(t *testing.T) {
+	t.Parallel()
+
+	var captured openAIRequest
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer test-key" {
+			t.Errorf("unexpected authorization %q", r.Header.Get("Authorization"))
+		}
+		json.NewDecoder(r.Body).Decode(&captured)
+		w.Write([]byte(`{"choices": [{"message": {"role": "assistant", "content": "[]"}}]}`))
+	}))
+	defer server.Close()
+
+	text, err := NewOpenAI(server.URL, "gpt-4o-mini", "test-key").Complete(context.Background(), Request{
+		Messages:    []Message{{Role: "system", Text: "be brief"}, {Role: "user", Text: "hello"}},
+		MaxTokens:   100,
+		Temperature: 0.2,
+	})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if text != "[]" {
+		t.Fatalf("expected [], got %q", text)
+	}
+	if captured.Model != "gpt-4o-mini" || captured.MaxTokens != 100 || captured.Temperature != 0.2 {
+		t.Fatalf("unexpected request %+v", captured)
+	}
+	if len(captured.Messages) != 2 || captured.Messages[1] != (openAIMessage{Role: "user", Content: "hello"}) {
+		t.Fatalf("unexpected messages %+v", captured.Messages)
+	}
+}
+
+func TestOpenAI_Complete_NoChoices(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"choices": []}`))
+	}))
+	defer server.Close()
+
+	if _, err := NewOpenAI(server.URL, "model", "key").Complete(context.Background(), Request{}); err == nil {
+		t.Fatal("expected error without choices")
+	}
+}
@@ -0,0 +1,41 @@
+package llm
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+)
+
+type Message struct {
+	Role string
+	Text string
+}
+
+type Request struct {
+	Messages    []Message
+	Schema      any
+	MaxTokens   int
+	Temperature float64
+}
+
+// Provider completes a chat and returns the text of the first answer. Providers
+// that can't enforce a JSON schema ignore Request.Schema.
+type Provider interface {
+	Name() string
+	Complete(ctx context.Context, request Request) (string, error)
+}
+
+type StatusError struct {
+	Provider   string
+	StatusCode int
+}
+
+func (e *StatusError) Error() string {
+	return fmt.Sprintf("%s returned status %d", e.Provider, e.StatusCode)
+}
+
+// Retryable reports whether the request may succeed later: the provider is rate
+// limiting us or is temporarily broken.
+func (e *StatusError) Retryable() bool {
+	return e.StatusCode == http.StatusTooManyRequests || e.StatusCode >= http.StatusInternalServerError
+}
@@ -0,0 +1,45 @@
+package llm
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+// Retrying retries a provider on rate limits and server errors, doubling the
+// backoff after every attempt.
+type Retrying struct {
+	provider Provider
+	retries  int
+	backoff  time.Duration
+}
+
+func NewRetrying(provider Provider, retries int, backoff time.Duration) *Retrying {
+	return &Retrying{provider: provider, retries: max(0, retries), backoff: backoff}
+}
+
+func (p *Retrying) Name() string {
+	return p.provider.Name()
+}
+
+func (p *Retrying) Complete(ctx context.Context, request Request) (string, error) {
+	backoff := p.backoff
+	for attempt := 0; ; attempt++ {
+		text, err := p.provider.Complete(ctx, request)
+		if err == nil || attempt == p.retries || !isRetryable(err) {
+			return text, err
+		}
+
+		select {
+		case <-ctx.Done():
+			return "", errors.Join(err, ctx.Err())
+		case <-time.After(backoff):
+		}
+		backoff *= 2
+	}
+}
+
+func isRetryable(err error) bool {
+	var statusErr *StatusError
+	return errors.As(err, &statusErr) && statusErr.Retryable()
+}
@@ -0,0 +1,72 @@
+package llm
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+)
+
+type scriptedProvider struct {
+	name  string
+	errs  []error
+	calls int
+}
+
+func (p *scriptedProvider) Name() string {
+	return p.name
+}
+
+func (p *scriptedProvider) Complete(ctx context.Context, request Request) (string, error) {
+	p.calls++
+	if p.calls <= len(p.errs) {
+		return "", p.errs[p.calls-1]
+	}
+	return p.name, nil
+}
+
+func TestRetrying_RetriesRetryableErrors(t *testing.T) {
+	t.Parallel()
+
+	provider := &scriptedProvider{name: "yandex", errs: []error{
+		&StatusError{StatusCode: http.StatusTooManyRequests},
+		&StatusError{StatusCode: http.StatusServiceUnavailable},
+	}}
+	text, err := NewRetrying(provider, 2, time.Millisecond).Complete(context.Background(), Request{})
+	if err != nil || text != "yandex" {
+		t.Fatalf("expected success after retries, got %q (%v)", text, err)
+	}
+	if provider.calls != 3 {
+		t.Fatalf("expected 3 calls, got %d", provider.calls)
+	}
+}
+
+func TestRetrying_StopsOnLimitAndPermanentErrors(t *testing.T) {
+	t.Parallel()
+
+	exhausted := &scriptedProvider{errs: []error{
+		&StatusError{StatusCode: http.StatusInternalServerError},
+		&StatusError{StatusCode: http.StatusInternalServerError},
+	}}
+	if _, err := NewRetrying(exhausted, 1, time.Millisecond).Complete(context.Background(), Request{}); err == nil || exhausted.calls != 2 {
+		t.Fatalf("expected error after 2 calls, got %v after %d", err, exhausted.calls)
+	}
+
+	permanent := &scriptedProvider{errs: []error{&StatusError{StatusCode: http.StatusUnauthorized}}}
+	if _, err := NewRetrying(permanent, 3, time.Millisecond).Complete(context.Background(), Request{}); err == nil || permanent.calls != 1 {
+		t.Fatalf("expected no retries of permanent error, got %v after %d", err, permanent.calls)
+	}
+}
+
+func TestRetrying_StopsOnContextCancel(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	provider := &scriptedProvider{errs: []error{&StatusError{StatusCode: http.StatusTooManyRequests}}}
+	_, err := NewRetrying(provider, 3, time.Hour).Complete(ctx, Request{})
+	if !errors.Is(err, context.Canceled) || provider.calls != 1 {
+		t.Fatalf("expected cancellation after 1 call, got %v after %d", err, provider.calls)
+	}
+}
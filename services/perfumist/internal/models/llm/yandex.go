@@ -0,0 +1,114 @@
+package llm
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+)
+
+type yandexRequest struct {
+	ModelUri          string                  `json:"modelUri"`
+	Messages          []yandexMessage         `json:"messages"`
+	CompletionOptions yandexCompletionOptions `json:"completionOptions"`
+	JsonSchema        *yandexJsonSchema       `json:"jsonSchema,omitempty"`
+}
+
+type yandexMessage struct {
+	Role string `json:"role"`
+	Text string `json:"text"`
+}
+
+type yandexCompletionOptions struct {
+	MaxTokens   int     `json:"maxTokens"`
+	Temperature float64 `json:"temperature"`
+	Stream      bool    `json:"stream"`
+}
+
+type yandexJsonSchema struct {
+	Schema any `json:"schema"`
+}
+
+type yandexResponse struct {
+	Result struct {
+		Alternatives []struct {
+			Message struct {
+				Text string `json:"text"`
+			} `json:"message"`
+		} `json:"alternatives"`
+	} `json:"result"`
+}
+
+type YandexGPT struct {
+	url       string
+	folderId  string
+	modelName string
+	apiKey    string
+	client    *http.Client
+}
+
+func NewYandexGPT(url string, folderId string, modelName string, apiKey string) *YandexGPT {
+	return &YandexGPT{
+		url:       url,
+		folderId:  folderId,
+		modelName: modelName,
+		apiKey:    apiKey,
+		client:    http.DefaultClient,
+	}
+}
+
+func (p *YandexGPT) Name() string {
+	return "yandex"
+}
+
+func (p *YandexGPT) Complete(ctx context.Context, request Request) (string, error) {
+	body := yandexRequest{
+		ModelUri: fmt.Sprintf("gpt://%s/%s", p.folderId, p.modelName),
+		Messages: make([]yandexMessage, len(request.Messages)),
+		CompletionOptions: yandexCompletionOptions{
+			MaxTokens:   request.MaxTokens,
+			Temperature: request.Temperature,
+			Stream:      false,
+		},
+	}
+	for i, message := range request.Messages {
+		body.Messages[i] = yandexMessage{Role: message.Role, Text: message.Text}
+	}
+	if request.Schema != nil {
+		body.JsonSchema = &yandexJsonSchema{Schema: request.Schema}
+	}
+
+	var response yandexResponse
+	if err := postJSON(ctx, p.client, p.Name(), p.url, p.apiKey, body, &response); err != nil {
+		return "", err
+	}
+	if len(response.Result.Alternatives) == 0 {
+		return "", fmt.Errorf("no alternatives in response")
+	}
+	return response.Result.Alternatives[0].Message.Text, nil
+}
+
+func postJSON(ctx context.Context, client *http.Client, provider string, url string, apiKey string, body any, response any) error {
+	bodyBytes, err := json.Marshal(body)
+	if err != nil {
+		return err
+	}
+	r, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewBuffer(bodyBytes))
+	if err != nil {
+		return err
+	}
+	r.Header.Set("Content-Type", "application/json")
+	r.Header.Set("Authorization", fmt.Sprintf("Bearer %s", apiKey))
+
+	resp, err := client.Do(r)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return &StatusError{Provider: provider, StatusCode: resp.StatusCode}
+	}
+	return json.NewDecoder(resp.Body).Decode(response)
+}
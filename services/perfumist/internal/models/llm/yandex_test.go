@@ -0,0 +1,78 @@
+package llm
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestYandexGPT_Complete(t *testing.T) {
+	t.Parallel()
+
+	var captured yandexRequest
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer test-key" {
+			t.Errorf("unexpected authorization %q", r.Header.Get("Authorization"))
+		}
+		json.NewDecoder(r.Body).Decode(&captured)
+		w.Write([]byte(`{"result": {"alternatives": [{"message": {"text": "[]"}}]}}`))
+	}))
+	defer server.Close()
+
+	provider := NewYandexGPT(server.URL, "folder", "model/latest", "test-key")
+	text, err := provider.Complete(context.Background(), Request{
+		Messages:    []Message{{Role: "user", Text: "hello"}},
+		Schema:      map[string]string{"type": "array"},
+		MaxTokens:   100,
+		Temperature: 0.2,
+	})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if text != "[]" {
+		t.Fatalf("expected [], got %q", text)
+	}
+	if captured.ModelUri != "gpt://folder/model/latest" {
+		t.Fatalf("unexpected model uri %q", captured.ModelUri)
+	}
+	if captured.CompletionOptions.MaxTokens != 100 || captured.CompletionOptions.Temperature != 0.2 {
+		t.Fatalf("unexpected completion options %+v", captured.CompletionOptions)
+	}
+	if len(captured.Messages) != 1 || captured.Messages[0].Text != "hello" || captured.JsonSchema == nil {
+		t.Fatalf("unexpected request %+v", captured)
+	}
+}
+
+func TestYandexGPT_Complete_StatusError(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusTooManyRequests)
+	}))
+	defer server.Close()
+
+	_, err := NewYandexGPT(server.URL, "folder", "model", "key").Complete(context.Background(), Request{})
+	var statusErr *StatusError
+	if !errors.As(err, &statusErr) || !statusErr.Retryable() {
+		t.Fatalf("expected retryable StatusError, got %v", err)
+	}
+}
+
+func TestStatusError_Retryable(t *testing.T) {
+	t.Parallel()
+
+	for status, expected := range map[int]bool{
+		http.StatusTooManyRequests:     true,
+		http.StatusInternalServerError: true,
+		http.StatusBadGateway:          true,
+		http.StatusBadRequest:          false,
+		http.StatusUnauthorized:        false,
+	} {
+		if retryable := (&StatusError{StatusCode: status}).Retryable(); retryable != expected {
+			t.Fatalf("%d: expected %v, got %v", status, expected, retryable)
+		}
+	}
+}
@@ -0,0 +1,146 @@
+package matching
+
+import (
+	"sort"
+
+	"github.com/zemld/Scently/models"
+	"github.com/zemld/Scently/perfumist/pkg/set"
+)
+
+const (
+	CharacteristicsComponent = "characteristics"
+	TagsComponent            = "tags"
+	OverlayComponent         = "overlay"
+	DislikeComponent         = "dislike"
+)
+
+type ExplainingMatcher interface {
+	Matcher
+	Explain(first models.Properties, second models.Properties) Explanation
+}
+
+type Explanation struct {
+	Score           float64        `json:"score"`
+	Components      []Component    `json:"components"`
+	SharedNotes     LevelNotes     `json:"shared_notes"`
+	SharedFamilies  []string       `json:"shared_families"`
+	OverlappingTags map[string]int `json:"overlapping_tags"`
+}
+
+type Component struct {
+	Name         string        `json:"name"`
+	Score        float64       `json:"score"`
+	Weight       float64       `json:"weight"`
+	Contribution float64       `json:"contribution"`
+	Levels       []LevelDetail `json:"levels,omitempty"`
+}
+
+type LevelDetail struct {
+	Level        string  `json:"level"`
+	Score        float64 `json:"score"`
+	Weight       float64 `json:"weight"`
+	Contribution float64 `json:"contribution"`
+}
+
+type LevelNotes struct {
+	Upper []string `json:"upper"`
+	Core  []string `json:"core"`
+	Base  []string `json:"base"`
+}
+
+func NewComponent(name string, score float64, weight float64, levels ...LevelDetail) Component {
+	return Component{Name: name, Score: score, Weight: weight, Contribution: score * weight, Levels: levels}
+}
+
+func NewLevelDetail(level string, score float64, weight float64) LevelDetail {
+	return LevelDetail{Level: level, Score: score, Weight: weight, Contribution: score * weight}
+}
+
+func (m CombinedMatcher) Explain(first models.Properties, second models.Properties) Explanation {
+	characteristics := NewCharacteristicsMatcher(m.Weights).explain(first, second)
+	tags := NewComponent(TagsComponent, NewTags(m.Weights).GetSimilarityScore(first, second), m.TagsWeight)
+	overlay := NewOverlay(m.Weights).explain(first, second)
+
+	characteristics.Weight, characteristics.Contribution = m.CharacteristicsWeight, characteristics.Score*m.CharacteristicsWeight
+	overlay.Weight, overlay.Contribution = m.OverlayWeight, overlay.Score*m.OverlayWeight
+
+	return Explanation{
+		Score:           characteristics.Contribution + tags.Contribution + overlay.Contribution,
+		Components:      []Component{characteristics, tags, overlay},
+		SharedNotes:     sharedNotes(first, second),
+		SharedFamilies:  sharedList(first.Family, second.Family),
+		OverlappingTags: getTagMapsIntersection(CalculatePerfumeTags(&first, m.Weights), CalculatePerfumeTags(&second, m.Weights)),
+	}
+}
+
+func (m CharacteristicsMatcher) explain(first models.Properties, second models.Properties) Component {
+	levels := []LevelDetail{
+		NewLevelDetail("upper", cosineSimilarity(first.UpperCharacteristics, second.UpperCharacteristics), m.UpperNotesWeight),
+		NewLevelDetail("core", cosineSimilarity(first.CoreCharacteristics, second.CoreCharacteristics), m.CoreNotesWeight),
+		NewLevelDetail("base", cosineSimilarity(first.BaseCharacteristics, second.BaseCharacteristics), m.BaseNotesWeight),
+	}
+	return NewComponent(CharacteristicsComponent, sumContributions(levels), 1, levels...)
+}
+
+func (m Overlay) explain(first models.Properties, second models.Properties) Component {
+	levels := []LevelDetail{
+		NewLevelDetail("upper", m.getListSimilarityScore(first.UpperNotes, second.UpperNotes), m.UpperNotesWeight*m.NotesWeight),
+		NewLevelDetail("core", m.getListSimilarityScore(first.CoreNotes, second.CoreNotes), m.CoreNotesWeight*m.NotesWeight),
+		NewLevelDetail("base", m.getListSimilarityScore(first.BaseNotes, second.BaseNotes), m.BaseNotesWeight*m.NotesWeight),
+		NewLevelDetail("family", m.getListSimilarityScore(first.Family, second.Family), m.FamilyWeight),
+		NewLevelDetail("type", m.getTypeSimilarityScore(first.Type, second.Type), m.TypeWeight),
+	}
+	return NewComponent(OverlayComponent, sumContributions(levels), 1, levels...)
+}
+
+func (a *TagsBasedAdapter) Explain(first models.Properties, second models.Properties) Explanation {
+	perfumeTags := CalculatePerfumeTags(&second, a.Weights)
+	tags := NewComponent(TagsComponent, a.score(perfumeTags), 1)
+	return Explanation{
+		Score:           tags.Contribution,
+		Components:      []Component{tags},
+		SharedNotes:     LevelNotes{Upper: []string{}, Core: []string{}, Base: []string{}},
+		SharedFamilies:  []string{},
+		OverlappingTags: getTagMapsIntersection(a.RequestedTags, perfumeTags),
+	}
+}
+
+func (m *MaxSimilarity) Explain(first models.Properties, second models.Properties) Explanation {
+	best, score := m.bestFavourite(second)
+	explainingMatcher, ok := m.Matcher.(ExplainingMatcher)
+	if best == -1 || !ok {
+		return Explanation{
+			Score:           score,
+			Components:      []Component{},
+			SharedNotes:     LevelNotes{Upper: []string{}, Core: []string{}, Base: []string{}},
+			SharedFamilies:  []string{},
+			OverlappingTags: map[string]int{},
+		}
+	}
+	return explainingMatcher.Explain(m.Favourites[best], second)
+}
+
+func sumContributions(levels []LevelDetail) float64 {
+	score := 0.0
+	for _, level := range levels {
+		score += level.Contribution
+	}
+	return score
+}
+
+func sharedNotes(first models.Properties, second models.Properties) LevelNotes {
+	return LevelNotes{
+		Upper: sharedList(first.UpperNotes, second.UpperNotes),
+		Core:  sharedList(first.CoreNotes, second.CoreNotes),
+		Base:  sharedList(first.BaseNotes, second.BaseNotes),
+	}
+}
+
+func sharedList(first []string, second []string) []string {
+	shared := make([]string, 0)
+	for item := range set.Intersect(set.MakeSet(first), set.MakeSet(second)) {
+		shared = append(shared, item)
+	}
+	sort.Strings(shared)
+	return shared
+}
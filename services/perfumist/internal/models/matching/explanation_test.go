@@ -0,0 +1,162 @@
+package matching
+
+import (
+	"math"
+	"testing"
+
+	"github.com/zemld/Scently/models"
+)
+
+func explanationFixtures() (models.Properties, models.Properties) {
+	first := models.Properties{
+		Type:       "Eau de Parfum",
+		Family:     []string{"Floral", "Oriental"},
+		UpperNotes: []string{"Rose", "Bergamot"},
+		CoreNotes:  []string{"Vanilla", "Amber"},
+		BaseNotes:  []string{"Musk"},
+		EnrichedUpperNotes: []models.EnrichedNote{
+			{Name: "Rose", Tags: []string{"floral", "romantic"}, Characteristics: []models.NoteCharacteristic{{Name: "floralcy", Value: 0.9}}},
+			{Name: "Bergamot", Tags: []string{"fresh"}, Characteristics: []models.NoteCharacteristic{{Name: "freshness", Value: 0.8}}},
+		},
+		EnrichedCoreNotes: []models.EnrichedNote{
+			{Name: "Vanilla", Tags: []string{"sweet", "warm"}, Characteristics: []models.NoteCharacteristic{{Name: "sweetness", Value: 0.9}}},
+		},
+		EnrichedBaseNotes: []models.EnrichedNote{
+			{Name: "Musk", Tags: []string{"warm"}, Characteristics: []models.NoteCharacteristic{{Name: "warmth", Value: 0.6}}},
+		},
+	}
+	second := models.Properties{
+		Type:       "Eau de Parfum",
+		Family:     []string{"Floral"},
+		UpperNotes: []string{"Rose"},
+		CoreNotes:  []string{"Vanilla"},
+		BaseNotes:  []string{"Cedar"},
+		EnrichedUpperNotes: []models.EnrichedNote{
+			{Name: "Rose", Tags: []string{"floral", "romantic"}, Characteristics: []models.NoteCharacteristic{{Name: "floralcy", Value: 0.9}}},
+		},
+		EnrichedCoreNotes: []models.EnrichedNote{
+			{Name: "Vanilla", Tags: []string{"sweet", "warm"}, Characteristics: []models.NoteCharacteristic{{Name: "sweetness", Value: 0.9}}},
+		},
+		EnrichedBaseNotes: []models.EnrichedNote{
+			{Name: "Cedar", Tags: []string{"woody"}, Characteristics: []models.NoteCharacteristic{{Name: "woodiness", Value: 0.8}}},
+		},
+	}
+	for _, p := range []*models.Properties{&first, &second} {
+		p.UpperCharacteristics = uniteCharacteristics(p.EnrichedUpperNotes)
+		p.CoreCharacteristics = uniteCharacteristics(p.EnrichedCoreNotes)
+		p.BaseCharacteristics = uniteCharacteristics(p.EnrichedBaseNotes)
+	}
+	return first, second
+}
+
+func TestCombinedMatcher_Explain_ScoreMatchesSimilarity(t *testing.T) {
+	t.Parallel()
+
+	matcher := NewCombinedMatcher(*NewWeights(0.4, 0.55, 0.05, 0.2, 0.35, 0.45, 0.3, 0.5, 0.2))
+	first, second := explanationFixtures()
+
+	explanation := matcher.Explain(first, second)
+	expected := matcher.GetSimilarityScore(first, second)
+	if math.Abs(explanation.Score-expected) > 1e-9 {
+		t.Fatalf("expected explained score %f, got %f", expected, explanation.Score)
+	}
+
+	sum := 0.0
+	for _, component := range explanation.Components {
+		sum += component.Contribution
+	}
+	if math.Abs(sum-expected) > 1e-9 {
+		t.Fatalf("expected components to sum to %f, got %f", expected, sum)
+	}
+}
+
+func TestCombinedMatcher_Explain_Components(t *testing.T) {
+	t.Parallel()
+
+	matcher := NewCombinedMatcher(*NewWeights(0.4, 0.55, 0.05, 0.2, 0.35, 0.45, 0.3, 0.5, 0.2))
+	first, second := explanationFixtures()
+
+	explanation := matcher.Explain(first, second)
+	if len(explanation.Components) != 3 {
+		t.Fatalf("expected 3 components, got %d", len(explanation.Components))
+	}
+
+	characteristics := explanation.Components[0]
+	if characteristics.Name != CharacteristicsComponent || characteristics.Weight != 0.3 {
+		t.Fatalf("unexpected characteristics component %+v", characteristics)
+	}
+	if len(characteristics.Levels) != 3 {
+		t.Fatalf("expected 3 characteristic levels, got %d", len(characteristics.Levels))
+	}
+	if characteristics.Levels[1].Level != "core" || characteristics.Levels[1].Score != 1 {
+		t.Fatalf("expected identical core characteristics, got %+v", characteristics.Levels[1])
+	}
+	if characteristics.Levels[2].Score != 0 {
+		t.Fatalf("expected orthogonal base characteristics, got %+v", characteristics.Levels[2])
+	}
+
+	if explanation.Components[1].Name != TagsComponent || explanation.Components[1].Weight != 0.5 {
+		t.Fatalf("unexpected tags component %+v", explanation.Components[1])
+	}
+
+	overlay := explanation.Components[2]
+	if overlay.Name != OverlayComponent || overlay.Weight != 0.2 {
+		t.Fatalf("unexpected overlay component %+v", overlay)
+	}
+	if len(overlay.Levels) != 5 {
+		t.Fatalf("expected 5 overlay parts, got %d", len(overlay.Levels))
+	}
+}
+
+func TestCombinedMatcher_Explain_SharedItems(t *testing.T) {
+	t.Parallel()
+
+	matcher := NewCombinedMatcher(*NewWeights(0.4, 0.55, 0.05, 1, 1, 1, 0.3, 0.5, 0.2))
+	first, second := explanationFixtures()
+
+	explanation := matcher.Explain(first, second)
+	if len(explanation.SharedNotes.Upper) != 1 || explanation.SharedNotes.Upper[0] != "Rose" {
+		t.Fatalf("expected shared upper note Rose, got %v", explanation.SharedNotes.Upper)
+	}
+	if len(explanation.SharedNotes.Core) != 1 || explanation.SharedNotes.Core[0] != "Vanilla" {
+		t.Fatalf("expected shared core note Vanilla, got %v", explanation.SharedNotes.Core)
+	}
+	if len(explanation.SharedNotes.Base) != 0 {
+		t.Fatalf("expected no shared base notes, got %v", explanation.SharedNotes.Base)
+	}
+	if len(explanation.SharedFamilies) != 1 || explanation.SharedFamilies[0] != "Floral" {
+		t.Fatalf("expected shared family Floral, got %v", explanation.SharedFamilies)
+	}
+	if _, ok := explanation.OverlappingTags["sweet"]; !ok {
+		t.Fatalf("expected sweet among overlapping tags, got %v", explanation.OverlappingTags)
+	}
+	if _, ok := explanation.OverlappingTags["fresh"]; ok {
+		t.Fatalf("unexpected fresh among overlapping tags, got %v", explanation.OverlappingTags)
+	}
+}
+
+func TestTagsBasedAdapter_Explain(t *testing.T) {
+	t.Parallel()
+
+	adapter := NewTagsBasedAdapter(*NewBaseWeights(1, 1, 1), []string{"sweet", "woody"})
+	_, second := explanationFixtures()
+
+	explanation := adapter.Explain(models.Properties{}, second)
+	expected := adapter.GetSimilarityScore(models.Properties{}, second)
+	if math.Abs(explanation.Score-expected) > 1e-9 {
+		t.Fatalf("expected explained score %f, got %f", expected, explanation.Score)
+	}
+	if len(explanation.Components) != 1 || explanation.Components[0].Name != TagsComponent {
+		t.Fatalf("expected single tags component, got %+v", explanation.Components)
+	}
+	if len(explanation.OverlappingTags) != 2 {
+		t.Fatalf("expected sweet and woody to overlap, got %v", explanation.OverlappingTags)
+	}
+}
+
+func TestExplainingMatcher_Implementations(t *testing.T) {
+	t.Parallel()
+
+	var _ ExplainingMatcher = CombinedMatcher{}
+	var _ ExplainingMatcher = &TagsBasedAdapter{}
+}
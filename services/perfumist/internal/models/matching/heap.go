@@ -41,17 +41,19 @@ func (h *PerfumeHeap) Push(x any) {
 	if ranked.Score <= h.perfumes[0].Score {
 		return
 	}
-	heap.Pop(h)
-	h.perfumes = append(h.perfumes, ranked)
-	heap.Fix(h, h.Len()-1)
+	h.perfumes[0] = ranked
+	heap.Fix(h, 0)
 }
 
+// Pop removes the last perfume, as heap.Interface requires, so the lowest
+// scored one is taken with heap.Pop.
 func (h *PerfumeHeap) Pop() any {
 	if h.Len() == 0 {
 		return nil
 	}
-	x := h.perfumes[0]
-	h.perfumes = h.perfumes[1:]
+	last := h.Len() - 1
+	x := h.perfumes[last]
+	h.perfumes = h.perfumes[:last]
 	return x
 }
 
@@ -86,7 +86,7 @@ func TestPerfumeHeap_Pop(t *testing.T) {
 
 	heap.Init(h)
 
-	popped := h.Pop()
+	popped := heap.Pop(h)
 	if popped == nil {
 		t.Fatal("expected non-nil result from Pop")
 	}
@@ -320,3 +320,40 @@ func TestPerfumeHeap_HeapProperty(t *testing.T) {
 		}
 	}
 }
+
+func TestPerfumeHeap_KeepsTopScoresAndPopsInOrder(t *testing.T) {
+	t.Parallel()
+
+	h := NewPerfumeHeap(3)
+	heap.Init(h)
+	for _, score := range []float64{0.5, 0.9, 0.1, 0.7, 0.3, 0.8, 0.2} {
+		h.Push(models.Ranked{Score: score})
+	}
+
+	expected := []float64{0.7, 0.8, 0.9}
+	for _, score := range expected {
+		ranked := heap.Pop(h).(models.Ranked)
+		if ranked.Score != score {
+			t.Fatalf("expected score %f, got %f", score, ranked.Score)
+		}
+	}
+	if h.Len() != 0 {
+		t.Fatalf("expected empty heap, got %d perfumes", h.Len())
+	}
+}
+
+func TestPerfumeHeap_WorksWithHeapPackage(t *testing.T) {
+	t.Parallel()
+
+	h := NewPerfumeHeap(10)
+	for _, score := range []float64{0.5, 0.9, 0.1, 0.7} {
+		heap.Push(h, models.Ranked{Score: score})
+	}
+	heap.Init(h)
+
+	for _, score := range []float64{0.1, 0.5, 0.7, 0.9} {
+		if ranked := heap.Pop(h).(models.Ranked); ranked.Score != score {
+			t.Fatalf("expected score %f, got %f", score, ranked.Score)
+		}
+	}
+}
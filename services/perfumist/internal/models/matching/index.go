@@ -0,0 +1,263 @@
+package matching
+
+import (
+	"container/heap"
+	"math"
+	"math/rand/v2"
+	"sort"
+
+	"github.com/zemld/Scently/models"
+)
+
+var CharacteristicNames = []string{
+	"sweetness",
+	"freshness",
+	"spiciness",
+	"woodiness",
+	"floralcy",
+	"fruityness",
+	"powderiness",
+	"earthiness",
+	"warmth",
+	"density",
+}
+
+type NoteLevel int
+
+const (
+	UpperLevel NoteLevel = iota
+	CoreLevel
+	BaseLevel
+)
+
+var NoteLevels = []NoteLevel{UpperLevel, CoreLevel, BaseLevel}
+
+type IndexOptions struct {
+	TreesCount   int
+	LeafSize     int
+	SearchFactor int
+	Seed         uint64
+}
+
+func NewIndexOptions(treesCount int, leafSize int) IndexOptions {
+	return IndexOptions{TreesCount: max(treesCount, 1), LeafSize: max(leafSize, 2), SearchFactor: 4, Seed: 42}
+}
+
+func (o IndexOptions) WithSearchFactor(searchFactor int) IndexOptions {
+	o.SearchFactor = max(searchFactor, 1)
+	return o
+}
+
+type CharacteristicsIndex struct {
+	forests [3]*projectionForest
+	size    int
+}
+
+func NewCharacteristicsIndex(perfumes []models.Perfume, options IndexOptions) *CharacteristicsIndex {
+	index := &CharacteristicsIndex{size: len(perfumes)}
+	for _, level := range NoteLevels {
+		vectors := make([][]float64, len(perfumes))
+		for i := range perfumes {
+			vectors[i] = CharacteristicsVector(perfumes[i].Properties, level)
+		}
+		index.forests[level] = newProjectionForest(vectors, options)
+	}
+	return index
+}
+
+func (i *CharacteristicsIndex) Len() int {
+	return i.size
+}
+
+func (i *CharacteristicsIndex) Search(properties models.Properties, count int) []int {
+	seen := make(map[int]struct{})
+	candidates := make([]int, 0, count*len(NoteLevels))
+	for _, level := range NoteLevels {
+		for _, id := range i.SearchLevel(level, CharacteristicsVector(properties, level), count) {
+			if _, ok := seen[id]; ok {
+				continue
+			}
+			seen[id] = struct{}{}
+			candidates = append(candidates, id)
+		}
+	}
+	return candidates
+}
+
+func (i *CharacteristicsIndex) SearchLevel(level NoteLevel, vector []float64, count int) []int {
+	return i.forests[level].search(normalize(vector), count)
+}
+
+func CharacteristicsVector(properties models.Properties, level NoteLevel) []float64 {
+	characteristics := levelCharacteristics(properties, level)
+	vector := make([]float64, len(CharacteristicNames))
+	for j, name := range CharacteristicNames {
+		vector[j] = characteristics[name]
+	}
+	return vector
+}
+
+func levelCharacteristics(properties models.Properties, level NoteLevel) map[string]float64 {
+	switch level {
+	case UpperLevel:
+		if properties.UpperCharacteristics != nil {
+			return properties.UpperCharacteristics
+		}
+		return uniteCharacteristics(properties.EnrichedUpperNotes)
+	case CoreLevel:
+		if properties.CoreCharacteristics != nil {
+			return properties.CoreCharacteristics
+		}
+		return uniteCharacteristics(properties.EnrichedCoreNotes)
+	default:
+		if properties.BaseCharacteristics != nil {
+			return properties.BaseCharacteristics
+		}
+		return uniteCharacteristics(properties.EnrichedBaseNotes)
+	}
+}
+
+type projectionNode struct {
+	normal []float64
+	left   *projectionNode
+	right  *projectionNode
+	ids    []int
+}
+
+type projectionForest struct {
+	vectors [][]float64
+	roots   []*projectionNode
+	leaf    int
+	factor  int
+}
+
+func newProjectionForest(vectors [][]float64, options IndexOptions) *projectionForest {
+	normalized := make([][]float64, len(vectors))
+	for i, vector := range vectors {
+		normalized[i] = normalize(vector)
+	}
+	f := &projectionForest{vectors: normalized, leaf: options.LeafSize, factor: max(options.SearchFactor, 1)}
+	random := rand.New(rand.NewPCG(options.Seed, uint64(len(vectors))))
+
+	ids := make([]int, len(vectors))
+	for i := range ids {
+		ids[i] = i
+	}
+	for range options.TreesCount {
+		f.roots = append(f.roots, f.build(append([]int(nil), ids...), random))
+	}
+	return f
+}
+
+func (f *projectionForest) build(ids []int, random *rand.Rand) *projectionNode {
+	if len(ids) <= f.leaf {
+		return &projectionNode{ids: ids}
+	}
+
+	first := f.vectors[ids[random.IntN(len(ids))]]
+	second := f.vectors[ids[random.IntN(len(ids))]]
+	normal := make([]float64, len(first))
+	for i := range normal {
+		normal[i] = first[i] - second[i]
+	}
+
+	var left, right []int
+	for _, id := range ids {
+		if dot(normal, f.vectors[id]) < 0 {
+			left = append(left, id)
+		} else {
+			right = append(right, id)
+		}
+	}
+	if len(left) == 0 || len(right) == 0 {
+		left, right = splitRandomly(ids, random)
+		normal = nil
+	}
+	return &projectionNode{
+		normal: normal,
+		left:   f.build(left, random),
+		right:  f.build(right, random),
+	}
+}
+
+func splitRandomly(ids []int, random *rand.Rand) ([]int, []int) {
+	random.Shuffle(len(ids), func(i, j int) { ids[i], ids[j] = ids[j], ids[i] })
+	return ids[:len(ids)/2], ids[len(ids)/2:]
+}
+
+func (f *projectionForest) search(query []float64, count int) []int {
+	if count <= 0 || len(f.vectors) == 0 {
+		return nil
+	}
+
+	searchLimit := count * len(f.roots) * f.factor
+	queue := &nodeQueue{}
+	for _, root := range f.roots {
+		heap.Push(queue, nodePriority{node: root, margin: math.Inf(1)})
+	}
+
+	seen := make(map[int]struct{})
+	candidates := make([]int, 0, searchLimit)
+	for queue.Len() > 0 && len(candidates) < searchLimit {
+		item := heap.Pop(queue).(nodePriority)
+		node := item.node
+		if node.left == nil {
+			for _, id := range node.ids {
+				if _, ok := seen[id]; !ok {
+					seen[id] = struct{}{}
+					candidates = append(candidates, id)
+				}
+			}
+			continue
+		}
+		margin := 0.0
+		if node.normal != nil {
+			margin = dot(node.normal, query)
+		}
+		heap.Push(queue, nodePriority{node: node.right, margin: min(item.margin, margin)})
+		heap.Push(queue, nodePriority{node: node.left, margin: min(item.margin, -margin)})
+	}
+
+	sort.Slice(candidates, func(i, j int) bool {
+		return dot(query, f.vectors[candidates[i]]) > dot(query, f.vectors[candidates[j]])
+	})
+	return candidates[:min(count, len(candidates))]
+}
+
+type nodePriority struct {
+	node   *projectionNode
+	margin float64
+}
+
+type nodeQueue []nodePriority
+
+func (q nodeQueue) Len() int           { return len(q) }
+func (q nodeQueue) Less(i, j int) bool { return q[i].margin > q[j].margin }
+func (q nodeQueue) Swap(i, j int)      { q[i], q[j] = q[j], q[i] }
+func (q *nodeQueue) Push(x any)        { *q = append(*q, x.(nodePriority)) }
+func (q *nodeQueue) Pop() any {
+	old := *q
+	item := old[len(old)-1]
+	*q = old[:len(old)-1]
+	return item
+}
+
+func normalize(vector []float64) []float64 {
+	norm := math.Sqrt(dot(vector, vector))
+	normalized := make([]float64, len(vector))
+	if norm == 0 {
+		return normalized
+	}
+	for i, value := range vector {
+		normalized[i] = value / norm
+	}
+	return normalized
+}
+
+func dot(first []float64, second []float64) float64 {
+	score := 0.0
+	for i := range first {
+		score += first[i] * second[i]
+	}
+	return score
+}
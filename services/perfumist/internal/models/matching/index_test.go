@@ -0,0 +1,165 @@
+package matching
+
+import (
+	"math/rand/v2"
+	"sort"
+	"testing"
+
+	"github.com/zemld/Scently/models"
+)
+
+func randomCharacteristics(random *rand.Rand) map[string]float64 {
+	characteristics := make(map[string]float64, len(CharacteristicNames))
+	for _, name := range CharacteristicNames {
+		if random.Float64() < 0.3 {
+			continue
+		}
+		characteristics[name] = random.Float64()
+	}
+	return characteristics
+}
+
+func randomPerfumes(count int, seed uint64) []models.Perfume {
+	random := rand.New(rand.NewPCG(seed, seed))
+	perfumes := make([]models.Perfume, count)
+	for i := range perfumes {
+		perfumes[i].Properties = models.Properties{
+			UpperCharacteristics: randomCharacteristics(random),
+			CoreCharacteristics:  randomCharacteristics(random),
+			BaseCharacteristics:  randomCharacteristics(random),
+		}
+	}
+	return perfumes
+}
+
+func exactSearchLevel(perfumes []models.Perfume, level NoteLevel, query models.Properties, count int) []int {
+	queryVector := normalize(CharacteristicsVector(query, level))
+	ids := make([]int, len(perfumes))
+	scores := make([]float64, len(perfumes))
+	for i := range perfumes {
+		ids[i] = i
+		scores[i] = dot(queryVector, normalize(CharacteristicsVector(perfumes[i].Properties, level)))
+	}
+	sort.Slice(ids, func(i, j int) bool { return scores[ids[i]] > scores[ids[j]] })
+	return ids[:min(count, len(ids))]
+}
+
+func recall(expected []int, actual []int) float64 {
+	found := make(map[int]struct{}, len(actual))
+	for _, id := range actual {
+		found[id] = struct{}{}
+	}
+	hits := 0
+	for _, id := range expected {
+		if _, ok := found[id]; ok {
+			hits++
+		}
+	}
+	return float64(hits) / float64(len(expected))
+}
+
+func TestCharacteristicsIndex_RecallAgainstExactSearch(t *testing.T) {
+	t.Parallel()
+
+	perfumes := randomPerfumes(3000, 1)
+	queries := randomPerfumes(50, 2)
+	index := NewCharacteristicsIndex(perfumes, NewIndexOptions(8, 16))
+
+	const count = 10
+	for _, level := range NoteLevels {
+		total := 0.0
+		for _, query := range queries {
+			expected := exactSearchLevel(perfumes, level, query.Properties, count)
+			actual := index.SearchLevel(level, CharacteristicsVector(query.Properties, level), count)
+			total += recall(expected, actual)
+		}
+		averageRecall := total / float64(len(queries))
+		t.Logf("level %d recall@%d: %.3f", level, count, averageRecall)
+		if averageRecall < 0.9 {
+			t.Fatalf("expected recall@%d >= 0.9 for level %d, got %.3f", count, level, averageRecall)
+		}
+	}
+}
+
+func TestCharacteristicsIndex_SearchFindsItself(t *testing.T) {
+	t.Parallel()
+
+	perfumes := randomPerfumes(500, 3)
+	index := NewCharacteristicsIndex(perfumes, NewIndexOptions(4, 8))
+
+	for _, i := range []int{0, 42, 499} {
+		found := false
+		for _, id := range index.Search(perfumes[i].Properties, 5) {
+			if id == i {
+				found = true
+			}
+		}
+		if !found {
+			t.Fatalf("expected perfume %d among its own neighbours", i)
+		}
+	}
+}
+
+func TestCharacteristicsIndex_SearchUnitesLevels(t *testing.T) {
+	t.Parallel()
+
+	perfumes := randomPerfumes(200, 4)
+	index := NewCharacteristicsIndex(perfumes, NewIndexOptions(4, 8))
+
+	candidates := index.Search(perfumes[0].Properties, 10)
+	if len(candidates) < 10 || len(candidates) > 30 {
+		t.Fatalf("expected between 10 and 30 candidates, got %d", len(candidates))
+	}
+	seen := make(map[int]struct{})
+	for _, id := range candidates {
+		if _, ok := seen[id]; ok {
+			t.Fatalf("duplicate candidate %d", id)
+		}
+		seen[id] = struct{}{}
+	}
+}
+
+func TestCharacteristicsIndex_UsesEnrichedNotesWhenNotPrepared(t *testing.T) {
+	t.Parallel()
+
+	perfumes := []models.Perfume{
+		{Properties: models.Properties{UpperCharacteristics: map[string]float64{"sweetness": 1}}},
+		{Properties: models.Properties{UpperCharacteristics: map[string]float64{"freshness": 1}}},
+	}
+	index := NewCharacteristicsIndex(perfumes, NewIndexOptions(1, 2))
+
+	query := models.Properties{
+		EnrichedUpperNotes: []models.EnrichedNote{
+			{Name: "Bergamot", Characteristics: []models.NoteCharacteristic{{Name: "freshness", Value: 0.8}}},
+		},
+	}
+	result := index.SearchLevel(UpperLevel, CharacteristicsVector(query, UpperLevel), 1)
+	if len(result) != 1 || result[0] != 1 {
+		t.Fatalf("expected fresh perfume to be nearest, got %v", result)
+	}
+}
+
+func TestCharacteristicsIndex_Empty(t *testing.T) {
+	t.Parallel()
+
+	index := NewCharacteristicsIndex(nil, NewIndexOptions(4, 8))
+	if index.Len() != 0 {
+		t.Fatalf("expected empty index, got %d", index.Len())
+	}
+	if result := index.Search(models.Properties{}, 5); len(result) != 0 {
+		t.Fatalf("expected no candidates, got %v", result)
+	}
+}
+
+func TestCharacteristicsVector_Order(t *testing.T) {
+	t.Parallel()
+
+	properties := models.Properties{CoreCharacteristics: map[string]float64{"density": 0.5, "sweetness": 0.25}}
+	vector := CharacteristicsVector(properties, CoreLevel)
+	if len(vector) != len(CharacteristicNames) {
+		t.Fatalf("expected %d dimensions, got %d", len(CharacteristicNames), len(vector))
+	}
+	if vector[0] != 0.25 || vector[len(vector)-1] != 0.5 {
+		t.Fatalf("unexpected vector %v", vector)
+	}
+}
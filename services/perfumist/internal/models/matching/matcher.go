@@ -10,6 +10,12 @@ type Matcher interface {
 	GetSimilarityScore(first models.Properties, second models.Properties) float64
 }
 
+// Filter is implemented by matchers that rule perfumes out instead of only
+// scoring them low.
+type Filter interface {
+	Accepts(properties models.Properties) bool
+}
+
 func cosineSimilarity[Number ~int | ~float64](first map[string]Number, second map[string]Number) float64 {
 	dotProduct := multiplyMaps(first, second)
 	firstNorm := math.Sqrt(multiplyMaps(first, first))
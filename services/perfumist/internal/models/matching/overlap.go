@@ -0,0 +1,60 @@
+package matching
+
+import (
+	"github.com/zemld/Scently/models"
+)
+
+// Overlap lists what two perfumes have in common, whatever matcher scored them.
+type Overlap struct {
+	Notes           LevelNotes
+	Families        []string
+	Tags            []string
+	Characteristics []string
+}
+
+// FindOverlap treats a characteristic as shared when its mean value over the
+// levels is at least threshold for both perfumes.
+func FindOverlap(first models.Properties, second models.Properties, threshold float64) Overlap {
+	return Overlap{
+		Notes:           sharedNotes(first, second),
+		Families:        sharedList(first.Family, second.Family),
+		Tags:            sharedList(noteTags(first), noteTags(second)),
+		Characteristics: sharedCharacteristics(first, second, threshold),
+	}
+}
+
+func (o Overlap) Empty() bool {
+	return len(o.Notes.Upper) == 0 && len(o.Notes.Core) == 0 && len(o.Notes.Base) == 0 &&
+		len(o.Families) == 0 && len(o.Tags) == 0 && len(o.Characteristics) == 0
+}
+
+func noteTags(properties models.Properties) []string {
+	tags := make([]string, 0)
+	for _, notes := range [][]models.EnrichedNote{properties.EnrichedUpperNotes, properties.EnrichedCoreNotes, properties.EnrichedBaseNotes} {
+		for _, note := range notes {
+			tags = append(tags, note.Tags...)
+		}
+	}
+	return tags
+}
+
+func sharedCharacteristics(first models.Properties, second models.Properties, threshold float64) []string {
+	firstMean, secondMean := meanCharacteristics(first), meanCharacteristics(second)
+	shared := make([]string, 0)
+	for _, characteristic := range CharacteristicNames {
+		if firstMean[characteristic] >= threshold && secondMean[characteristic] >= threshold {
+			shared = append(shared, characteristic)
+		}
+	}
+	return shared
+}
+
+func meanCharacteristics(properties models.Properties) map[string]float64 {
+	mean := make(map[string]float64)
+	for _, level := range NoteLevels {
+		for characteristic, value := range levelCharacteristics(properties, level) {
+			mean[characteristic] += value / float64(len(NoteLevels))
+		}
+	}
+	return mean
+}
@@ -0,0 +1,53 @@
+package matching
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/zemld/Scently/models"
+)
+
+func TestFindOverlap(t *testing.T) {
+	t.Parallel()
+
+	first := models.Properties{
+		Family:               []string{"Woody", "Aromatic"},
+		UpperNotes:           []string{"Bergamot", "Pepper"},
+		BaseNotes:            []string{"Ambroxan"},
+		EnrichedUpperNotes:   []models.EnrichedNote{{Name: "Bergamot", Tags: []string{"fresh", "citrus"}}},
+		EnrichedBaseNotes:    []models.EnrichedNote{{Name: "Ambroxan", Tags: []string{"woody"}}},
+		UpperCharacteristics: map[string]float64{"freshness": 0.9, "sweetness": 0.1},
+		CoreCharacteristics:  map[string]float64{"freshness": 0.6},
+		BaseCharacteristics:  map[string]float64{"freshness": 0.6, "woodiness": 0.9},
+	}
+	second := models.Properties{
+		Family:               []string{"Aromatic"},
+		UpperNotes:           []string{"Bergamot"},
+		CoreNotes:            []string{"Ambroxan"},
+		EnrichedUpperNotes:   []models.EnrichedNote{{Name: "Bergamot", Tags: []string{"citrus"}}},
+		EnrichedCoreNotes:    []models.EnrichedNote{{Name: "Ambroxan", Tags: []string{"woody", "amber"}}},
+		UpperCharacteristics: map[string]float64{"freshness": 0.8},
+		CoreCharacteristics:  map[string]float64{"freshness": 0.7},
+		BaseCharacteristics:  map[string]float64{"freshness": 0.5, "woodiness": 0.1},
+	}
+
+	overlap := FindOverlap(first, second, 0.5)
+	if !slices.Equal(overlap.Notes.Upper, []string{"Bergamot"}) || len(overlap.Notes.Base) != 0 {
+		t.Fatalf("expected notes shared on the same level only, got %+v", overlap.Notes)
+	}
+	if !slices.Equal(overlap.Families, []string{"Aromatic"}) {
+		t.Fatalf("expected shared families, got %v", overlap.Families)
+	}
+	if !slices.Equal(overlap.Tags, []string{"citrus", "woody"}) {
+		t.Fatalf("expected tags shared across levels, got %v", overlap.Tags)
+	}
+	if !slices.Equal(overlap.Characteristics, []string{"freshness"}) {
+		t.Fatalf("expected only freshness to be pronounced in both, got %v", overlap.Characteristics)
+	}
+	if overlap.Empty() {
+		t.Fatal("expected overlap not to be empty")
+	}
+	if !FindOverlap(models.Properties{}, second, 0.5).Empty() {
+		t.Fatal("expected empty overlap with an empty perfume")
+	}
+}
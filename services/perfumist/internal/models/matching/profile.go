@@ -0,0 +1,150 @@
+package matching
+
+import (
+	"github.com/zemld/Scently/models"
+)
+
+type ProfileStrategy string
+
+const (
+	CentroidStrategy ProfileStrategy = "centroid"
+	MaxStrategy      ProfileStrategy = "max"
+)
+
+func ParseProfileStrategy(strategy string) (ProfileStrategy, bool) {
+	switch ProfileStrategy(strategy) {
+	case "", CentroidStrategy:
+		return CentroidStrategy, true
+	case MaxStrategy:
+		return MaxStrategy, true
+	default:
+		return "", false
+	}
+}
+
+func BuildProfile(favourites []models.Properties) models.Properties {
+	profile := models.Properties{}
+	types := make(map[string]int)
+	for _, favourite := range favourites {
+		profile.Family = appendMissing(profile.Family, favourite.Family)
+		profile.UpperNotes = appendMissing(profile.UpperNotes, favourite.UpperNotes)
+		profile.CoreNotes = appendMissing(profile.CoreNotes, favourite.CoreNotes)
+		profile.BaseNotes = appendMissing(profile.BaseNotes, favourite.BaseNotes)
+
+		profile.EnrichedUpperNotes = append(profile.EnrichedUpperNotes, favourite.EnrichedUpperNotes...)
+		profile.EnrichedCoreNotes = append(profile.EnrichedCoreNotes, favourite.EnrichedCoreNotes...)
+		profile.EnrichedBaseNotes = append(profile.EnrichedBaseNotes, favourite.EnrichedBaseNotes...)
+
+		if favourite.Type != "" {
+			types[favourite.Type]++
+			if types[favourite.Type] > types[profile.Type] {
+				profile.Type = favourite.Type
+			}
+		}
+	}
+	profile.UpperCharacteristics = centroid(favourites, UpperLevel)
+	profile.CoreCharacteristics = centroid(favourites, CoreLevel)
+	profile.BaseCharacteristics = centroid(favourites, BaseLevel)
+	return profile
+}
+
+func centroid(favourites []models.Properties, level NoteLevel) map[string]float64 {
+	united := make(map[string]float64)
+	if len(favourites) == 0 {
+		return united
+	}
+	for _, favourite := range favourites {
+		for characteristic, value := range levelCharacteristics(favourite, level) {
+			united[characteristic] += value
+		}
+	}
+	for characteristic, value := range united {
+		united[characteristic] = value / float64(len(favourites))
+	}
+	return united
+}
+
+func appendMissing(list []string, items []string) []string {
+	for _, item := range items {
+		found := false
+		for _, existing := range list {
+			if existing == item {
+				found = true
+				break
+			}
+		}
+		if !found {
+			list = append(list, item)
+		}
+	}
+	return list
+}
+
+type MaxSimilarity struct {
+	Matcher    Matcher
+	Favourites []models.Properties
+}
+
+func NewMaxSimilarity(matcher Matcher, favourites []models.Properties) *MaxSimilarity {
+	return &MaxSimilarity{Matcher: matcher, Favourites: favourites}
+}
+
+func (m *MaxSimilarity) GetSimilarityScore(first models.Properties, second models.Properties) float64 {
+	_, score := m.bestFavourite(second)
+	return score
+}
+
+func (m *MaxSimilarity) bestFavourite(perfume models.Properties) (int, float64) {
+	best, bestScore := -1, 0.0
+	for i, favourite := range m.Favourites {
+		score := m.Matcher.GetSimilarityScore(favourite, perfume)
+		if best == -1 || score > bestScore {
+			best, bestScore = i, score
+		}
+	}
+	return best, bestScore
+}
+
+var noteLevelNames = map[NoteLevel]string{
+	UpperLevel: "upper",
+	CoreLevel:  "core",
+	BaseLevel:  "base",
+}
+
+func ParseNoteLevel(level string) (NoteLevel, bool) {
+	for noteLevel, name := range noteLevelNames {
+		if name == level {
+			return noteLevel, true
+		}
+	}
+	return 0, false
+}
+
+func BuildNotesProfile(notes []models.EnrichedNote, levels []NoteLevel) models.Properties {
+	if len(levels) == 0 {
+		levels = NoteLevels
+	}
+	names := make([]string, len(notes))
+	for i, note := range notes {
+		names[i] = note.Name
+	}
+
+	profile := models.Properties{}
+	for _, level := range levels {
+		switch level {
+		case UpperLevel:
+			profile.UpperNotes = names
+			profile.EnrichedUpperNotes = notes
+		case CoreLevel:
+			profile.CoreNotes = names
+			profile.EnrichedCoreNotes = notes
+		case BaseLevel:
+			profile.BaseNotes = names
+			profile.EnrichedBaseNotes = notes
+		}
+	}
+	profile.UpperCharacteristics = uniteCharacteristics(profile.EnrichedUpperNotes)
+	profile.CoreCharacteristics = uniteCharacteristics(profile.EnrichedCoreNotes)
+	profile.BaseCharacteristics = uniteCharacteristics(profile.EnrichedBaseNotes)
+	return profile
+}
@@ -0,0 +1,172 @@
+package matching
+
+import (
+	"math"
+	"testing"
+
+	"github.com/zemld/Scently/models"
+)
+
+func TestParseProfileStrategy(t *testing.T) {
+	t.Parallel()
+
+	cases := map[string]ProfileStrategy{
+		"":         CentroidStrategy,
+		"centroid": CentroidStrategy,
+		"max":      MaxStrategy,
+	}
+	for raw, expected := range cases {
+		strategy, ok := ParseProfileStrategy(raw)
+		if !ok || strategy != expected {
+			t.Fatalf("%q: expected %q, got %q (ok=%v)", raw, expected, strategy, ok)
+		}
+	}
+	if _, ok := ParseProfileStrategy("median"); ok {
+		t.Fatal("expected unknown strategy to be rejected")
+	}
+}
+
+func TestBuildProfile_Centroid(t *testing.T) {
+	t.Parallel()
+
+	profile := BuildProfile([]models.Properties{
+		{UpperCharacteristics: map[string]float64{"freshness": 1, "sweetness": 0.5}},
+		{UpperCharacteristics: map[string]float64{"freshness": 0.5}},
+	})
+	if math.Abs(profile.UpperCharacteristics["freshness"]-0.75) > 1e-9 {
+		t.Fatalf("expected freshness 0.75, got %f", profile.UpperCharacteristics["freshness"])
+	}
+	if math.Abs(profile.UpperCharacteristics["sweetness"]-0.25) > 1e-9 {
+		t.Fatalf("expected sweetness 0.25, got %f", profile.UpperCharacteristics["sweetness"])
+	}
+}
+
+func TestBuildProfile_UsesEnrichedNotesWhenNotPrepared(t *testing.T) {
+	t.Parallel()
+
+	profile := BuildProfile([]models.Properties{
+		{EnrichedCoreNotes: []models.EnrichedNote{{Name: "Vanilla", Characteristics: []models.NoteCharacteristic{{Name: "sweetness", Value: 0.8}}}}},
+	})
+	if profile.CoreCharacteristics["sweetness"] != 0.8 {
+		t.Fatalf("expected sweetness 0.8, got %v", profile.CoreCharacteristics)
+	}
+}
+
+func TestBuildProfile_SumsTagsAndUnitesLists(t *testing.T) {
+	t.Parallel()
+
+	profile := BuildProfile([]models.Properties{
+		{
+			Type:              "Eau de Parfum",
+			Family:            []string{"Floral"},
+			CoreNotes:         []string{"Rose"},
+			EnrichedCoreNotes: []models.EnrichedNote{{Name: "Rose", Tags: []string{"floral"}}},
+		},
+		{
+			Type:              "Eau de Toilette",
+			Family:            []string{"Floral", "Woody"},
+			CoreNotes:         []string{"Rose", "Cedar"},
+			EnrichedCoreNotes: []models.EnrichedNote{{Name: "Rose", Tags: []string{"floral"}}, {Name: "Cedar", Tags: []string{"woody"}}},
+		},
+		{
+			Type: "Eau de Toilette",
+		},
+	})
+
+	if len(profile.Family) != 2 || len(profile.CoreNotes) != 2 {
+		t.Fatalf("expected united families and notes, got %v and %v", profile.Family, profile.CoreNotes)
+	}
+	if profile.Type != "Eau de Toilette" {
+		t.Fatalf("expected most common type, got %q", profile.Type)
+	}
+	tags := CalculatePerfumeTags(&profile, *NewBaseWeights(1, 1, 1))
+	if tags["floral"] != 2 || tags["woody"] != 1 {
+		t.Fatalf("expected summed tags, got %v", tags)
+	}
+}
+
+func TestMaxSimilarity_TakesBestFavourite(t *testing.T) {
+	t.Parallel()
+
+	fresh := models.Properties{UpperCharacteristics: map[string]float64{"freshness": 1}}
+	sweet := models.Properties{UpperCharacteristics: map[string]float64{"sweetness": 1}}
+	matcher := NewMaxSimilarity(NewCharacteristicsMatcher(*NewBaseWeights(1, 0, 0)), []models.Properties{fresh, sweet})
+
+	if score := matcher.GetSimilarityScore(models.Properties{}, sweet); score != 1 {
+		t.Fatalf("expected score 1 for a perfume equal to one favourite, got %f", score)
+	}
+	mixed := models.Properties{UpperCharacteristics: map[string]float64{"freshness": 1, "sweetness": 1}}
+	if score := matcher.GetSimilarityScore(models.Properties{}, mixed); math.Abs(score-math.Sqrt2/2) > 1e-9 {
+		t.Fatalf("expected score %f, got %f", math.Sqrt2/2, score)
+	}
+}
+
+func TestMaxSimilarity_ExplainUsesBestFavourite(t *testing.T) {
+	t.Parallel()
+
+	first, second := explanationFixtures()
+	other := models.Properties{Family: []string{"Woody"}}
+	combined := NewCombinedMatcher(*NewWeights(0.4, 0.55, 0.05, 0.2, 0.35, 0.45, 0.3, 0.5, 0.2))
+	matcher := NewMaxSimilarity(combined, []models.Properties{other, first})
+
+	explanation := matcher.Explain(models.Properties{}, second)
+	if math.Abs(explanation.Score-matcher.GetSimilarityScore(models.Properties{}, second)) > 1e-9 {
+		t.Fatalf("expected explained score to match similarity, got %f", explanation.Score)
+	}
+	if len(explanation.SharedFamilies) != 1 || explanation.SharedFamilies[0] != "Floral" {
+		t.Fatalf("expected explanation against the floral favourite, got %v", explanation.SharedFamilies)
+	}
+}
+
+func TestParseNoteLevel(t *testing.T) {
+	t.Parallel()
+
+	cases := map[string]NoteLevel{
+		"upper": UpperLevel,
+		"core":  CoreLevel,
+		"base":  BaseLevel,
+	}
+	for raw, expected := range cases {
+		level, ok := ParseNoteLevel(raw)
+		if !ok || level != expected {
+			t.Fatalf("%q: expected %d, got %d (ok=%v)", raw, expected, level, ok)
+		}
+	}
+	if _, ok := ParseNoteLevel("heart"); ok {
+		t.Fatal("expected unknown level to be rejected")
+	}
+}
+
+func TestBuildNotesProfile_SingleLevel(t *testing.T) {
+	t.Parallel()
+
+	notes := []models.EnrichedNote{
+		{Name: "vanilla", Tags: []string{"sweet"}, Characteristics: []models.NoteCharacteristic{{Name: "sweetness", Value: 1}}},
+		{Name: "musk", Tags: []string{"warm"}, Characteristics: []models.NoteCharacteristic{{Name: "sweetness", Value: 0.5}}},
+	}
+	profile := BuildNotesProfile(notes, []NoteLevel{BaseLevel})
+
+	if len(profile.BaseNotes) != 2 || len(profile.EnrichedBaseNotes) != 2 {
+		t.Fatalf("expected 2 base notes, got %v", profile.BaseNotes)
+	}
+	if len(profile.UpperNotes) != 0 || len(profile.CoreNotes) != 0 {
+		t.Fatalf("expected only base notes, got upper %v and core %v", profile.UpperNotes, profile.CoreNotes)
+	}
+	if math.Abs(profile.BaseCharacteristics["sweetness"]-0.75) > 1e-9 {
+		t.Fatalf("expected sweetness 0.75, got %f", profile.BaseCharacteristics["sweetness"])
+	}
+	if len(profile.UpperCharacteristics) != 0 {
+		t.Fatalf("expected empty upper characteristics, got %v", profile.UpperCharacteristics)
+	}
+}
+
+func TestBuildNotesProfile_AllLevelsByDefault(t *testing.T) {
+	t.Parallel()
+
+	profile := BuildNotesProfile([]models.EnrichedNote{{Name: "rose"}}, nil)
+	for _, notes := range [][]string{profile.UpperNotes, profile.CoreNotes, profile.BaseNotes} {
+		if len(notes) != 1 || notes[0] != "rose" {
+			t.Fatalf("expected rose on every level, got %v", notes)
+		}
+	}
+}
@@ -0,0 +1,77 @@
+package matching
+
+import (
+	"github.com/zemld/Scently/models"
+)
+
+// Query scores perfumes against constraints that are not bound to a favourite
+// perfume: requested tags, notes and characteristic targets. Every present
+// constraint weighs the same.
+type Query struct {
+	Weights         Weights
+	Tags            *TagsBasedAdapter
+	Notes           models.Properties
+	Characteristics *CharacteristicsTarget
+}
+
+func NewQuery(weights Weights, tags []string, notes []string, targets map[string]float64) *Query {
+	query := &Query{Weights: weights}
+	if len(tags) > 0 {
+		query.Tags = NewTagsBasedAdapter(weights, tags)
+	}
+	if len(notes) > 0 {
+		query.Notes = models.Properties{UpperNotes: notes, CoreNotes: notes, BaseNotes: notes}
+	}
+	if len(targets) > 0 {
+		leveled := make(map[NoteLevel]map[string]float64, len(NoteLevels))
+		for _, level := range NoteLevels {
+			leveled[level] = targets
+		}
+		query.Characteristics = NewCharacteristicsTarget(weights, leveled, nil)
+	}
+	return query
+}
+
+func (m *Query) GetSimilarityScore(first models.Properties, second models.Properties) float64 {
+	return m.Explain(first, second).Score
+}
+
+func (m *Query) Explain(first models.Properties, second models.Properties) Explanation {
+	explanation := Explanation{
+		Components:      []Component{},
+		SharedNotes:     LevelNotes{Upper: []string{}, Core: []string{}, Base: []string{}},
+		SharedFamilies:  []string{},
+		OverlappingTags: map[string]int{},
+	}
+	if m.Tags != nil {
+		tags := m.Tags.Explain(first, second)
+		explanation.Components = append(explanation.Components, tags.Components...)
+		explanation.OverlappingTags = tags.OverlappingTags
+	}
+	if len(m.Notes.UpperNotes) > 0 {
+		overlay := NewOverlay(Weights{
+			UpperNotesWeight: m.Weights.UpperNotesWeight,
+			CoreNotesWeight:  m.Weights.CoreNotesWeight,
+			BaseNotesWeight:  m.Weights.BaseNotesWeight,
+			NotesWeight:      1,
+		})
+		levels := []LevelDetail{
+			NewLevelDetail("upper", overlay.getListSimilarityScore(m.Notes.UpperNotes, second.UpperNotes), m.Weights.UpperNotesWeight),
+			NewLevelDetail("core", overlay.getListSimilarityScore(m.Notes.CoreNotes, second.CoreNotes), m.Weights.CoreNotesWeight),
+			NewLevelDetail("base", overlay.getListSimilarityScore(m.Notes.BaseNotes, second.BaseNotes), m.Weights.BaseNotesWeight),
+		}
+		explanation.Components = append(explanation.Components, NewComponent(OverlayComponent, sumContributions(levels), 1, levels...))
+		explanation.SharedNotes = sharedNotes(m.Notes, second)
+	}
+	if m.Characteristics != nil {
+		explanation.Components = append(explanation.Components, m.Characteristics.Explain(first, second).Components...)
+	}
+
+	for i := range explanation.Components {
+		component := &explanation.Components[i]
+		component.Weight = 1 / float64(len(explanation.Components))
+		component.Contribution = component.Score * component.Weight
+		explanation.Score += component.Contribution
+	}
+	return explanation
+}
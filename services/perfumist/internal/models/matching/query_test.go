@@ -0,0 +1,56 @@
+package matching
+
+import (
+	"math"
+	"testing"
+
+	"github.com/zemld/Scently/models"
+)
+
+func TestQuery_OnlyNotes(t *testing.T) {
+	t.Parallel()
+
+	query := NewQuery(*NewBaseWeights(0.2, 0.35, 0.45), nil, []string{"Vanilla"}, nil)
+
+	perfume := models.Properties{UpperNotes: []string{"Vanilla"}, CoreNotes: []string{"Vanilla"}, BaseNotes: []string{"Vanilla"}}
+	if score := query.GetSimilarityScore(models.Properties{}, perfume); math.Abs(score-1) > 1e-9 {
+		t.Fatalf("expected score 1, got %f", score)
+	}
+	if score := query.GetSimilarityScore(models.Properties{}, models.Properties{BaseNotes: []string{"Oud"}}); score != 0 {
+		t.Fatalf("expected score 0, got %f", score)
+	}
+}
+
+func TestQuery_ComponentsWeighEqually(t *testing.T) {
+	t.Parallel()
+
+	query := NewQuery(
+		*NewBaseWeights(1, 1, 1),
+		[]string{"warm"},
+		nil,
+		map[string]float64{"sweetness": 1},
+	)
+	perfume := models.Properties{
+		EnrichedCoreNotes:    []models.EnrichedNote{{Name: "Vanilla", Tags: []string{"warm"}}},
+		UpperCharacteristics: map[string]float64{"sweetness": 0},
+		CoreCharacteristics:  map[string]float64{"sweetness": 0},
+		BaseCharacteristics:  map[string]float64{"sweetness": 0},
+	}
+
+	explanation := query.Explain(models.Properties{}, perfume)
+	if len(explanation.Components) != 2 {
+		t.Fatalf("expected 2 components, got %d", len(explanation.Components))
+	}
+	if explanation.Components[0].Name != TagsComponent || explanation.Components[1].Name != CharacteristicsComponent {
+		t.Fatalf("expected tags and characteristics components, got %+v", explanation.Components)
+	}
+	if math.Abs(explanation.Score-0.5) > 1e-9 {
+		t.Fatalf("expected score 0.5, got %f", explanation.Score)
+	}
+	if explanation.OverlappingTags["warm"] != 1 {
+		t.Fatalf("expected overlapping warm tag, got %v", explanation.OverlappingTags)
+	}
+	if score := query.GetSimilarityScore(models.Properties{}, perfume); score != explanation.Score {
+		t.Fatalf("expected score %f, got %f", explanation.Score, score)
+	}
+}
@@ -0,0 +1,37 @@
+package matching
+
+type AlgType string
+
+const (
+	OverlayAlg         AlgType = "overlay"
+	TagsAlg            AlgType = "tags"
+	CharacteristicsAlg AlgType = "characteristics"
+	TagsOverlayAlg     AlgType = "tags_overlay"
+	SmartAlg           AlgType = "smart"
+	SmartEnhancedAlg   AlgType = "smart_enhanced"
+)
+
+func (a AlgType) String() string {
+	return string(a)
+}
+
+var matchers = map[AlgType]func(weights Weights) Matcher{
+	OverlayAlg:         func(weights Weights) Matcher { return NewOverlay(weights) },
+	TagsAlg:            func(weights Weights) Matcher { return NewTags(weights) },
+	CharacteristicsAlg: func(weights Weights) Matcher { return NewCharacteristicsMatcher(weights) },
+	TagsOverlayAlg:     func(weights Weights) Matcher { return NewTagsOverlay(weights) },
+	SmartAlg:           func(weights Weights) Matcher { return NewSmart(weights) },
+	SmartEnhancedAlg:   func(weights Weights) Matcher { return NewCombinedMatcher(weights) },
+}
+
+func Algorithms() []AlgType {
+	return []AlgType{OverlayAlg, TagsAlg, CharacteristicsAlg, TagsOverlayAlg, SmartAlg, SmartEnhancedAlg}
+}
+
+func GetMatcherByAlg(alg AlgType, weights Weights) (Matcher, bool) {
+	newMatcher, ok := matchers[alg]
+	if !ok {
+		return nil, false
+	}
+	return newMatcher(weights), true
+}
@@ -0,0 +1,24 @@
+package matching
+
+import "testing"
+
+func TestGetMatcherByAlg(t *testing.T) {
+	t.Parallel()
+
+	weights := *NewWeights(0.4, 0.55, 0.05, 0.2, 0.35, 0.45, 0.3, 0.5, 0.2)
+	for _, alg := range Algorithms() {
+		matcher, ok := GetMatcherByAlg(alg, weights)
+		if !ok || matcher == nil {
+			t.Fatalf("expected matcher for %s", alg)
+		}
+	}
+	if _, ok := GetMatcherByAlg(SmartEnhancedAlg, weights); !ok {
+		t.Fatal("expected smart_enhanced matcher")
+	}
+	if matcher, _ := GetMatcherByAlg(SmartEnhancedAlg, weights); matcher.(*CombinedMatcher).Weights != weights {
+		t.Fatal("expected weights to be passed to the matcher")
+	}
+	if _, ok := GetMatcherByAlg("random", weights); ok {
+		t.Fatal("expected unknown algorithm to be rejected")
+	}
+}
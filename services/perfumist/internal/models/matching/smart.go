@@ -0,0 +1,19 @@
+package matching
+
+import "github.com/zemld/Scently/models"
+
+type Smart struct {
+	Weights
+}
+
+func NewSmart(weights Weights) *Smart {
+	return &Smart{Weights: weights}
+}
+
+func (m Smart) GetSimilarityScore(first models.Properties, second models.Properties) float64 {
+	cm := NewCharacteristicsMatcher(m.Weights)
+	tm := NewTags(m.Weights)
+
+	return (m.CharacteristicsWeight*cm.GetSimilarityScore(first, second) +
+		m.TagsWeight*tm.GetSimilarityScore(first, second))
+}
@@ -0,0 +1,34 @@
+package matching
+
+import (
+	"math"
+	"testing"
+
+	"github.com/zemld/Scently/models"
+)
+
+func TestSmart_GetSimilarityScore(t *testing.T) {
+	t.Parallel()
+
+	weights := NewWeights(0.4, 0.55, 0.05, 1, 1, 1, 0.3, 0.5, 0.2)
+	first := models.Properties{
+		Family:               []string{"woody"},
+		EnrichedUpperNotes:   []models.EnrichedNote{{Name: "bergamot", Tags: []string{"fresh"}}},
+		UpperCharacteristics: map[string]float64{"freshness": 1},
+	}
+	second := models.Properties{
+		Family:               []string{"woody"},
+		EnrichedUpperNotes:   []models.EnrichedNote{{Name: "lemon", Tags: []string{"fresh"}}},
+		UpperCharacteristics: map[string]float64{"freshness": 1},
+	}
+
+	expected := weights.CharacteristicsWeight*NewCharacteristicsMatcher(*weights).GetSimilarityScore(first, second) +
+		weights.TagsWeight*NewTags(*weights).GetSimilarityScore(first, second)
+	score := NewSmart(*weights).GetSimilarityScore(first, second)
+	if math.Abs(score-expected) > 1e-9 {
+		t.Fatalf("expected %f, got %f", expected, score)
+	}
+	if combined := NewCombinedMatcher(*weights).GetSimilarityScore(first, second); combined <= score {
+		t.Fatalf("expected overlay to add to the enhanced score, got %f and %f", combined, score)
+	}
+}
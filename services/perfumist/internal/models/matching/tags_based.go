@@ -11,6 +11,10 @@ type TagsBasedAdapter struct {
 	Weights       Weights
 	TagsBased     *TagsBased
 	RequestedTags map[string]int
+	RequiredTags  []string
+	MinMatch      int
+
+	weighted bool
 }
 
 type TagsBased struct{}
@@ -20,7 +24,29 @@ func NewTagsBasedAdapter(weights Weights, requestedTags []string) *TagsBasedAdap
 	for _, tag := range requestedTags {
 		requestedTagsMap[tag]++
 	}
-	return &TagsBasedAdapter{Weights: weights, TagsBased: NewTagsBased(), RequestedTags: requestedTagsMap}
+	return newTagsBasedAdapter(weights, requestedTagsMap)
+}
+
+// NewWeightedTagsBasedAdapter scores with GetWeightedSimilarityScore, so a tag
+// of weight n counts n times. NewTagsBasedAdapter keeps the plain Jaccard index.
+func NewWeightedTagsBasedAdapter(weights Weights, requestedTags map[string]int) *TagsBasedAdapter {
+	adapter := newTagsBasedAdapter(weights, requestedTags)
+	adapter.weighted = true
+	return adapter
+}
+
+func newTagsBasedAdapter(weights Weights, requestedTags map[string]int) *TagsBasedAdapter {
+	return &TagsBasedAdapter{Weights: weights, TagsBased: NewTagsBased(), RequestedTags: requestedTags}
+}
+
+func (a *TagsBasedAdapter) WithRequiredTags(requiredTags []string) *TagsBasedAdapter {
+	a.RequiredTags = requiredTags
+	return a
+}
+
+func (a *TagsBasedAdapter) WithMinMatch(minMatch int) *TagsBasedAdapter {
+	a.MinMatch = minMatch
+	return a
 }
 
 func NewTagsBased() *TagsBased {
@@ -28,7 +54,35 @@ func NewTagsBased() *TagsBased {
 }
 
 func (a *TagsBasedAdapter) GetSimilarityScore(first models.Properties, second models.Properties) float64 {
-	return a.TagsBased.GetSimilarityScore(a.RequestedTags, CalculatePerfumeTags(&second, a.Weights))
+	return a.score(CalculatePerfumeTags(&second, a.Weights))
+}
+
+func (a *TagsBasedAdapter) score(perfumeTags map[string]int) float64 {
+	if a.weighted {
+		return a.TagsBased.GetWeightedSimilarityScore(a.RequestedTags, perfumeTags)
+	}
+	return a.TagsBased.GetSimilarityScore(a.RequestedTags, perfumeTags)
+}
+
+// Accepts rejects perfumes that miss a required tag or share fewer than
+// MinMatch of the requested tags.
+func (a *TagsBasedAdapter) Accepts(properties models.Properties) bool {
+	if len(a.RequiredTags) == 0 && a.MinMatch <= 0 {
+		return true
+	}
+	perfumeTags := CalculatePerfumeTags(&properties, a.Weights)
+	for _, tag := range a.RequiredTags {
+		if perfumeTags[tag] == 0 {
+			return false
+		}
+	}
+	matched := 0
+	for tag := range a.RequestedTags {
+		if perfumeTags[tag] > 0 {
+			matched++
+		}
+	}
+	return matched >= a.MinMatch
 }
 
 func (m *TagsBased) GetSimilarityScore(requestedTags map[string]int, perfumeTags map[string]int) float64 {
@@ -40,6 +94,28 @@ func (m *TagsBased) GetSimilarityScore(requestedTags map[string]int, perfumeTags
 	return float64(len(intersection)) / float64(len(union))
 }
 
+// GetWeightedSimilarityScore is the Jaccard index where every requested tag
+// counts as many times as its weight. With unit weights it is equal to
+// GetSimilarityScore.
+func (m *TagsBased) GetWeightedSimilarityScore(requestedTags map[string]int, perfumeTags map[string]int) float64 {
+	matched, total := 0, 0
+	for tag, weight := range requestedTags {
+		total += weight
+		if _, ok := perfumeTags[tag]; ok {
+			matched += weight
+		}
+	}
+	for tag := range perfumeTags {
+		if _, ok := requestedTags[tag]; !ok {
+			total++
+		}
+	}
+	if total == 0 {
+		return 0.0
+	}
+	return float64(matched) / float64(total)
+}
+
 func CalculatePerfumeTags(p *models.Properties, weights Weights) map[string]int {
 	perfumeUpperNotesTags := uniteTags(p.EnrichedUpperNotes)
 	perfumeCoreNotesTags := uniteTags(p.EnrichedCoreNotes)
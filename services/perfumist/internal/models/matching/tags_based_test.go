@@ -1,6 +1,7 @@
 package matching
 
 import (
+	"math"
 	"testing"
 
 	"github.com/zemld/Scently/models"
@@ -380,3 +381,72 @@ func TestTagsBasedAdapter_GetSimilarityScore_ComplexCase(t *testing.T) {
 		t.Fatalf("expected score %f, got %f (diff: %f)", expected, score, diff)
 	}
 }
+
+func TestTagsBasedAdapter_Accepts(t *testing.T) {
+	t.Parallel()
+
+	perfume := models.Properties{
+		EnrichedCoreNotes: []models.EnrichedNote{
+			{Name: "Cedar", Tags: []string{"woody", "warm"}},
+		},
+	}
+	weights := *NewBaseWeights(1, 1, 1)
+
+	if !NewWeightedTagsBasedAdapter(weights, map[string]int{"woody": 2, "sweet": 1}).Accepts(perfume) {
+		t.Fatal("expected perfume to be accepted without constraints")
+	}
+	if !NewWeightedTagsBasedAdapter(weights, map[string]int{"woody": 1, "warm": 1, "sweet": 1}).WithMinMatch(2).Accepts(perfume) {
+		t.Fatal("expected perfume sharing 2 tags to be accepted")
+	}
+	if NewWeightedTagsBasedAdapter(weights, map[string]int{"woody": 1, "sweet": 1}).WithMinMatch(2).Accepts(perfume) {
+		t.Fatal("expected perfume sharing 1 tag to be rejected")
+	}
+	if NewWeightedTagsBasedAdapter(weights, map[string]int{"woody": 1, "sweet": 1}).WithRequiredTags([]string{"sweet"}).Accepts(perfume) {
+		t.Fatal("expected perfume without required tag to be rejected")
+	}
+}
+
+func TestNewWeightedTagsBasedAdapter_WeightsScore(t *testing.T) {
+	t.Parallel()
+
+	perfume := models.Properties{
+		EnrichedCoreNotes: []models.EnrichedNote{
+			{Name: "Cedar", Tags: []string{"woody"}},
+			{Name: "Vetiver", Tags: []string{"woody"}},
+		},
+	}
+	weights := *NewBaseWeights(1, 1, 1)
+
+	plain := NewWeightedTagsBasedAdapter(weights, map[string]int{"woody": 1, "sweet": 1}).GetSimilarityScore(models.Properties{}, perfume)
+	weighted := NewWeightedTagsBasedAdapter(weights, map[string]int{"woody": 2, "sweet": 1}).GetSimilarityScore(models.Properties{}, perfume)
+	if weighted <= plain {
+		t.Fatalf("expected weight to boost the matched tag, got %f and %f", plain, weighted)
+	}
+}
+
+func TestNewWeightedTagsBasedAdapter_GetSimilarityScore_ComplexCase(t *testing.T) {
+	t.Parallel()
+
+	weights := NewBaseWeights(0.3, 0.4, 0.3)
+	adapter := NewWeightedTagsBasedAdapter(*weights, map[string]int{"floral": 2, "sweet": 1, "warm": 1})
+
+	second := models.Properties{
+		EnrichedUpperNotes: []models.EnrichedNote{
+			{Name: "Rose", Tags: []string{"floral", "romantic"}},
+			{Name: "Jasmine", Tags: []string{"floral", "sweet"}},
+		},
+		EnrichedCoreNotes: []models.EnrichedNote{
+			{Name: "Vanilla", Tags: []string{"sweet", "warm"}},
+		},
+		EnrichedBaseNotes: []models.EnrichedNote{
+			{Name: "Amber", Tags: []string{"warm", "oriental"}},
+		},
+	}
+
+	// perfumeTags: {"floral": 1}, as in the unweighted complex case
+	// requested weight: floral 2 + sweet 1 + warm 1 = 4, matched weight: floral 2
+	// score = 2/4 = 0.5
+	if score := adapter.GetSimilarityScore(models.Properties{}, second); math.Abs(score-0.5) > 0.0001 {
+		t.Fatalf("expected score 0.5, got %f", score)
+	}
+}
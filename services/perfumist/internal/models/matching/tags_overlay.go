@@ -0,0 +1,51 @@
+package matching
+
+import (
+	"math"
+
+	"github.com/zemld/Scently/models"
+)
+
+type TagsOverlay struct {
+	Weights
+}
+
+func NewTagsOverlay(weights Weights) *TagsOverlay {
+	return &TagsOverlay{Weights: weights}
+}
+
+func (m TagsOverlay) GetSimilarityScore(first models.Properties, second models.Properties) float64 {
+	upperNotesScore := m.getNotesSimilarityScore(uniteTags(first.EnrichedUpperNotes), uniteTags(second.EnrichedUpperNotes))
+	coreNotesScore := m.getNotesSimilarityScore(uniteTags(first.EnrichedCoreNotes), uniteTags(second.EnrichedCoreNotes))
+	baseNotesScore := m.getNotesSimilarityScore(uniteTags(first.EnrichedBaseNotes), uniteTags(second.EnrichedBaseNotes))
+
+	return (upperNotesScore*m.UpperNotesWeight +
+		coreNotesScore*m.CoreNotesWeight +
+		baseNotesScore*m.BaseNotesWeight)
+}
+
+// getNotesSimilarityScore mirrors the offline implementation in the algorithms
+// module, so that production scores match the evaluated ones.
+func (m TagsOverlay) getNotesSimilarityScore(first map[string]int, second map[string]int) float64 {
+	matches := 0
+	maxMatches := 0
+
+	for tag, firstCount := range first {
+		if secondCount, ok := second[tag]; !ok {
+			maxMatches += firstCount
+		} else {
+			matches += int(math.Min(float64(firstCount), float64(secondCount)))
+			maxMatches += firstCount + secondCount - matches
+			delete(second, tag)
+		}
+	}
+
+	for _, secondCount := range second {
+		maxMatches += secondCount
+	}
+
+	if maxMatches == 0 {
+		return 0
+	}
+	return float64(matches) / float64(maxMatches)
+}
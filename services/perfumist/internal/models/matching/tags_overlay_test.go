@@ -0,0 +1,41 @@
+package matching
+
+import (
+	"math"
+	"testing"
+
+	"github.com/zemld/Scently/models"
+)
+
+func TestTagsOverlay_GetSimilarityScore(t *testing.T) {
+	t.Parallel()
+
+	matcher := NewTagsOverlay(*NewBaseWeights(0.2, 0.35, 0.45))
+	first := models.Properties{
+		EnrichedUpperNotes: []models.EnrichedNote{{Name: "bergamot", Tags: []string{"fresh"}}, {Name: "mint", Tags: []string{"fresh"}}},
+		EnrichedBaseNotes:  []models.EnrichedNote{{Name: "vanilla", Tags: []string{"sweet"}}},
+	}
+	second := models.Properties{
+		EnrichedUpperNotes: []models.EnrichedNote{{Name: "lemon", Tags: []string{"fresh", "citrus"}}},
+		EnrichedBaseNotes:  []models.EnrichedNote{{Name: "oud", Tags: []string{"woody"}}},
+	}
+
+	// Upper: one fresh match out of three tags; base shares nothing.
+	expected := 0.2 / 3.0
+	if score := matcher.GetSimilarityScore(first, second); math.Abs(score-expected) > 1e-9 {
+		t.Fatalf("expected %f, got %f", expected, score)
+	}
+}
+
+func TestTagsOverlay_IdenticalPerfumes(t *testing.T) {
+	t.Parallel()
+
+	matcher := NewTagsOverlay(*NewBaseWeights(1, 0, 0))
+	perfume := models.Properties{EnrichedUpperNotes: []models.EnrichedNote{{Name: "rose", Tags: []string{"floral"}}}}
+	if score := matcher.GetSimilarityScore(perfume, perfume); math.Abs(score-1) > 1e-9 {
+		t.Fatalf("expected 1, got %f", score)
+	}
+	if score := matcher.GetSimilarityScore(models.Properties{}, models.Properties{}); score != 0 {
+		t.Fatalf("expected 0 for empty perfumes, got %f", score)
+	}
+}
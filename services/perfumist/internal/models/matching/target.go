@@ -0,0 +1,93 @@
+package matching
+
+import (
+	"math"
+
+	"github.com/zemld/Scently/models"
+)
+
+type CharacteristicsTarget struct {
+	Weights    Weights
+	Targets    map[NoteLevel]map[string]float64
+	Importance map[string]float64
+}
+
+func NewCharacteristicsTarget(
+	weights Weights,
+	targets map[NoteLevel]map[string]float64,
+	importance map[string]float64,
+) *CharacteristicsTarget {
+	return &CharacteristicsTarget{Weights: weights, Targets: targets, Importance: importance}
+}
+
+func (m *CharacteristicsTarget) GetSimilarityScore(first models.Properties, second models.Properties) float64 {
+	return sumContributions(m.levelDetails(second))
+}
+
+func (m *CharacteristicsTarget) Explain(first models.Properties, second models.Properties) Explanation {
+	levels := m.levelDetails(second)
+	characteristics := NewComponent(CharacteristicsComponent, sumContributions(levels), 1, levels...)
+	return Explanation{
+		Score:           characteristics.Contribution,
+		Components:      []Component{characteristics},
+		SharedNotes:     LevelNotes{Upper: []string{}, Core: []string{}, Base: []string{}},
+		SharedFamilies:  []string{},
+		OverlappingTags: map[string]int{},
+	}
+}
+
+func (m *CharacteristicsTarget) levelDetails(perfume models.Properties) []LevelDetail {
+	totalWeight := 0.0
+	for _, level := range NoteLevels {
+		if len(m.Targets[level]) > 0 {
+			totalWeight += m.levelWeight(level)
+		}
+	}
+
+	details := make([]LevelDetail, 0, len(NoteLevels))
+	for _, level := range NoteLevels {
+		if len(m.Targets[level]) == 0 || totalWeight == 0 {
+			continue
+		}
+		details = append(details, NewLevelDetail(
+			noteLevelNames[level],
+			m.levelScore(m.Targets[level], levelCharacteristics(perfume, level)),
+			m.levelWeight(level)/totalWeight,
+		))
+	}
+	return details
+}
+
+// levelScore turns the importance-weighted RMS distance between the target and
+// the perfume into a similarity. Characteristics are in [0, 1], so is the result.
+func (m *CharacteristicsTarget) levelScore(target map[string]float64, characteristics map[string]float64) float64 {
+	distance, totalImportance := 0.0, 0.0
+	for characteristic, value := range target {
+		importance := m.importance(characteristic)
+		diff := value - characteristics[characteristic]
+		distance += importance * diff * diff
+		totalImportance += importance
+	}
+	if totalImportance == 0 {
+		return 0.0
+	}
+	return 1 - math.Sqrt(distance/totalImportance)
+}
+
+func (m *CharacteristicsTarget) importance(characteristic string) float64 {
+	if importance, ok := m.Importance[characteristic]; ok {
+		return importance
+	}
+	return 1.0
+}
+
+func (m *CharacteristicsTarget) levelWeight(level NoteLevel) float64 {
+	switch level {
+	case UpperLevel:
+		return m.Weights.UpperNotesWeight
+	case CoreLevel:
+		return m.Weights.CoreNotesWeight
+	default:
+		return m.Weights.BaseNotesWeight
+	}
+}
@@ -0,0 +1,107 @@
+package matching
+
+import (
+	"math"
+	"testing"
+
+	"github.com/zemld/Scently/models"
+)
+
+func TestCharacteristicsTarget_ExactMatchScoresOne(t *testing.T) {
+	t.Parallel()
+
+	target := NewCharacteristicsTarget(
+		*NewBaseWeights(0.2, 0.35, 0.45),
+		map[NoteLevel]map[string]float64{
+			UpperLevel: {"freshness": 0.8},
+			BaseLevel:  {"woodiness": 0.6},
+		},
+		nil,
+	)
+	perfume := models.Properties{
+		UpperCharacteristics: map[string]float64{"freshness": 0.8, "sweetness": 0.4},
+		BaseCharacteristics:  map[string]float64{"woodiness": 0.6},
+	}
+
+	if score := target.GetSimilarityScore(models.Properties{}, perfume); math.Abs(score-1) > 1e-9 {
+		t.Fatalf("expected score 1, got %f", score)
+	}
+}
+
+func TestCharacteristicsTarget_CloserPerfumeScoresHigher(t *testing.T) {
+	t.Parallel()
+
+	target := NewCharacteristicsTarget(
+		*NewBaseWeights(1, 1, 1),
+		map[NoteLevel]map[string]float64{CoreLevel: {"sweetness": 1, "warmth": 0.5}},
+		nil,
+	)
+	near := models.Properties{CoreCharacteristics: map[string]float64{"sweetness": 0.9, "warmth": 0.5}}
+	far := models.Properties{CoreCharacteristics: map[string]float64{"sweetness": 0.1, "warmth": 0.5}}
+
+	if target.GetSimilarityScore(models.Properties{}, near) <= target.GetSimilarityScore(models.Properties{}, far) {
+		t.Fatal("expected closer perfume to score higher")
+	}
+}
+
+func TestCharacteristicsTarget_ImportanceWeightsAxes(t *testing.T) {
+	t.Parallel()
+
+	targets := map[NoteLevel]map[string]float64{UpperLevel: {"sweetness": 1, "freshness": 1}}
+	perfume := models.Properties{UpperCharacteristics: map[string]float64{"sweetness": 1, "freshness": 0}}
+
+	plain := NewCharacteristicsTarget(*NewBaseWeights(1, 0, 0), targets, nil)
+	sweetnessMatters := NewCharacteristicsTarget(*NewBaseWeights(1, 0, 0), targets, map[string]float64{"freshness": 0})
+
+	if math.Abs(plain.GetSimilarityScore(models.Properties{}, perfume)-(1-math.Sqrt(0.5))) > 1e-9 {
+		t.Fatalf("expected %f, got %f", 1-math.Sqrt(0.5), plain.GetSimilarityScore(models.Properties{}, perfume))
+	}
+	if math.Abs(sweetnessMatters.GetSimilarityScore(models.Properties{}, perfume)-1) > 1e-9 {
+		t.Fatalf("expected freshness to be ignored, got %f", sweetnessMatters.GetSimilarityScore(models.Properties{}, perfume))
+	}
+}
+
+func TestCharacteristicsTarget_UsesEnrichedNotesWhenNotPrepared(t *testing.T) {
+	t.Parallel()
+
+	target := NewCharacteristicsTarget(
+		*NewBaseWeights(1, 1, 1),
+		map[NoteLevel]map[string]float64{BaseLevel: {"woodiness": 1}},
+		nil,
+	)
+	perfume := models.Properties{
+		EnrichedBaseNotes: []models.EnrichedNote{{Name: "cedar", Characteristics: []models.NoteCharacteristic{{Name: "woodiness", Value: 1}}}},
+	}
+
+	if score := target.GetSimilarityScore(models.Properties{}, perfume); math.Abs(score-1) > 1e-9 {
+		t.Fatalf("expected score 1, got %f", score)
+	}
+}
+
+func TestCharacteristicsTarget_Explain(t *testing.T) {
+	t.Parallel()
+
+	target := NewCharacteristicsTarget(
+		*NewBaseWeights(0.2, 0.35, 0.45),
+		map[NoteLevel]map[string]float64{
+			UpperLevel: {"freshness": 1},
+			BaseLevel:  {"woodiness": 1},
+		},
+		nil,
+	)
+	perfume := models.Properties{
+		UpperCharacteristics: map[string]float64{"freshness": 0.5},
+		BaseCharacteristics:  map[string]float64{"woodiness": 1},
+	}
+
+	explanation := target.Explain(models.Properties{}, perfume)
+	if math.Abs(explanation.Score-target.GetSimilarityScore(models.Properties{}, perfume)) > 1e-9 {
+		t.Fatalf("expected explanation score to match similarity, got %f", explanation.Score)
+	}
+	if len(explanation.Components) != 1 || len(explanation.Components[0].Levels) != 2 {
+		t.Fatalf("expected one component with two levels, got %+v", explanation.Components)
+	}
+	if explanation.Components[0].Levels[0].Level != "upper" || explanation.Components[0].Levels[1].Level != "base" {
+		t.Fatalf("unexpected levels %+v", explanation.Components[0].Levels)
+	}
+}
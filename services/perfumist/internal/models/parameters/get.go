@@ -16,6 +16,46 @@ const (
 	BrandParamKey = "brand"
 	NameParamKey  = "name"
 	SexParamKey   = "sex"
+
+	AutoResolveParamKey = "auto_resolve"
+
+	ExplainParamKey  = "explain"
+	DescribeParamKey = "describe"
+
+	FavouritesParamKey = "perfume"
+	StrategyParamKey   = "strategy"
+
+	DislikedParamKey      = "dislike"
+	ExcludedNotesParamKey = "exclude_notes"
+	ExcludedTagsParamKey  = "exclude_tags"
+
+	TagsParamKey     = "tags"
+	MinMatchParamKey = "min_match"
+
+	NotesParamKey     = "notes"
+	NoteLevelParamKey = "level"
+
+	TargetsParamKey    = "targets"
+	ImportanceParamKey = "importance"
+
+	TextParamKey = "text"
+
+	MinPriceParamKey      = "min_price"
+	MaxPriceParamKey      = "max_price"
+	MaxPricePerMlParamKey = "max_price_per_ml"
+	VolumesParamKey       = "volumes"
+	ShopsParamKey         = "shops"
+
+	DiversifyParamKey       = "diversify"
+	DiversityLambdaParamKey = "diversity_lambda"
+	BrandCapParamKey        = "brand_cap"
+
+	MatcherParamKey = "matcher"
+)
+
+const (
+	ClientIDHeader  = "X-Client-Id"
+	VariantIDHeader = "X-Variant-Id"
 )
 
 type RequestPerfume struct {
@@ -23,6 +63,38 @@ type RequestPerfume struct {
 	Name  string
 	Sex   models.Sex
 	Page  uint32
+
+	Exclusions Exclusions
+	Offers     OfferFilter
+	Diversity  Diversity
+}
+
+type Exclusions struct {
+	Notes    []string
+	Tags     []string
+	Disliked []RequestPerfume
+}
+
+func (e Exclusions) IsEmpty() bool {
+	return len(e.Notes) == 0 && len(e.Tags) == 0 && len(e.Disliked) == 0
+}
+
+type Diversity struct {
+	Enabled  bool
+	Lambda   float64
+	BrandCap int
+}
+
+type OfferFilter struct {
+	MinPrice      int
+	MaxPrice      int
+	MaxPricePerMl float64
+	Volumes       []int
+	Shops         []string
+}
+
+func (f OfferFilter) IsEmpty() bool {
+	return f.MinPrice == 0 && f.MaxPrice == 0 && f.MaxPricePerMl == 0 && len(f.Volumes) == 0 && len(f.Shops) == 0
 }
 
 func (p *RequestPerfume) WithBrand(brand string) *RequestPerfume {
@@ -43,6 +115,21 @@ func (p *RequestPerfume) WithSex(sex models.Sex) *RequestPerfume {
 	return p
 }
 
+func (p *RequestPerfume) WithExclusions(exclusions Exclusions) *RequestPerfume {
+	p.Exclusions = exclusions
+	return p
+}
+
+func (p *RequestPerfume) WithOffers(offers OfferFilter) *RequestPerfume {
+	p.Offers = offers
+	return p
+}
+
+func (p *RequestPerfume) WithDiversity(diversity Diversity) *RequestPerfume {
+	p.Diversity = diversity
+	return p
+}
+
 func NewGet() *RequestPerfume {
 	return &RequestPerfume{Sex: models.Unisex}
 }
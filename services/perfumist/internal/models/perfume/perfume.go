@@ -1,6 +1,10 @@
 package perfume
 
-import "github.com/zemld/Scently/models"
+import (
+	"time"
+
+	"github.com/zemld/Scently/models"
+)
 
 type State struct {
 	SuccessfulCount int `json:"successful_count"`
@@ -11,3 +15,9 @@ type PerfumeResponse struct {
 	Perfumes []models.Perfume `json:"perfumes"`
 	State    State            `json:"state"`
 }
+
+// StreamHeader is the first line of the perfumes stream of perfume-hub.
+type StreamHeader struct {
+	Version time.Time `json:"version"`
+	Count   int       `json:"count"`
+}
@@ -0,0 +1,96 @@
+package service
+
+import (
+	"context"
+	"log"
+
+	"github.com/zemld/Scently/perfumist/internal/models/advising"
+	"github.com/zemld/Scently/perfumist/internal/models/matching"
+	"github.com/zemld/config-manager/pkg/cm"
+)
+
+const (
+	pureAIMode   = "ai"
+	hybridAIMode = "hybrid"
+)
+
+// NewSuggestAdvisor, NewAISuggestAdvisor and NewTagsAdvisor build the advisors
+// behind Suggest, AISuggest and SuggestByTags for callers that have no HTTP
+// request to tune them, like the gRPC server. They use the configured matcher.
+
+func NewSuggestAdvisor(cm cm.ConfigManager, autoResolve bool) (advising.Advisor, error) {
+	matcher, err := MatcherByAlg("", "", cm)
+	if err != nil {
+		return nil, err
+	}
+	return advising.NewBase(PerfumesCatalog(), matcher, cm).WithResolver(NewFavouriteResolver(cm, autoResolve)), nil
+}
+
+func NewAISuggestAdvisor(cm cm.ConfigManager, autoResolve bool) (advising.Advisor, error) {
+	return NewAIAdvisor(cm, NewFavouriteResolver(cm, autoResolve), false)
+}
+
+// NewTagsAdvisor reads tags in the format of the tags parameter of
+// SuggestByTags and also returns the excluded ones.
+func NewTagsAdvisor(ctx context.Context, cm cm.ConfigManager, items []string) (advising.Advisor, []string, error) {
+	vocabulary, err := CatalogVocabulary(ctx, cm)
+	if err != nil {
+		return nil, nil, err
+	}
+	tags, err := ParseTags(items, vocabulary.Tags)
+	if err != nil {
+		return nil, nil, err
+	}
+	minMatch := DefaultMinMatch(cm.GetIntWithDefault("minimal_tag_count", 3), len(tags.Weighted))
+	return NewTagsQueryAdvisor(tags, minMatch, cm), tags.Forbidden, nil
+}
+
+// NewAIAdvisor builds the AI advisor, which falls back to the base one, unless
+// ai_mode turns the hybrid advisor on. Only the hybrid one can describe.
+func NewAIAdvisor(cm cm.ConfigManager, resolver *advising.FavouriteResolver, describe bool) (advising.Advisor, error) {
+	if cm.GetStringWithDefault("ai_mode", pureAIMode) == hybridAIMode {
+		candidatesCount := cm.GetIntWithDefault("suggest_count", 4) *
+			cm.GetIntWithDefault("ai_candidates_factor", 3)
+		advisor := advising.NewHybrid(
+			NewAIFetcher(cm).WithCount(candidatesCount),
+			PerfumesCatalog(),
+			matching.NewCombinedMatcher(LoadWeights(cm, matching.SmartEnhancedAlg, "")),
+			cm,
+		).WithResolver(resolver)
+		if describe {
+			advisor.WithDescriber(NewDescriber(cm))
+		}
+		return advisor, nil
+	}
+
+	perfumeHubFetcher, err := NewPerfumeHubFetcher(cm)
+	if err != nil {
+		return nil, err
+	}
+	aiFetcher := NewAIFetcher(cm)
+	log.Printf("AI fetcher created: %+v\n", aiFetcher)
+	return advising.NewAI(
+		aiFetcher,
+		perfumeHubFetcher,
+		cm,
+	).WithFallback(advising.NewBase(
+		PerfumesCatalog(),
+		matching.NewCombinedMatcher(LoadWeights(cm, matching.SmartEnhancedAlg, "")),
+		cm,
+	).WithResolver(resolver)), nil
+}
+
+func NewTagsQueryAdvisor(tags TagsQuery, minMatch int, cm cm.ConfigManager) advising.Advisor {
+	return advising.NewTagsBased(
+		matching.NewWeightedTagsBasedAdapter(
+			matching.Weights{
+				UpperNotesWeight: cm.GetFloatWithDefault("upper_notes_weight", 0.2),
+				CoreNotesWeight:  cm.GetFloatWithDefault("core_notes_weight", 0.35),
+				BaseNotesWeight:  cm.GetFloatWithDefault("base_notes_weight", 0.45),
+			},
+			tags.Weighted,
+		).WithRequiredTags(tags.Required).WithMinMatch(minMatch),
+		PerfumesCatalog(),
+		cm,
+	)
+}
@@ -0,0 +1,106 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"testing"
+
+	"github.com/zemld/Scently/perfumist/internal/config"
+	"github.com/zemld/Scently/perfumist/internal/errors"
+	"github.com/zemld/Scently/perfumist/internal/models/advising"
+	"github.com/zemld/Scently/perfumist/internal/models/fetching"
+)
+
+var testTags = []string{"fresh", "sweet", "warm", "woody"}
+
+func vocabularyServer(t *testing.T) *httptest.Server {
+	t.Helper()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		words := testTags
+		if r.URL.Path == "/notes" {
+			words = []string{"vanilla", "oud"}
+		}
+		items := make([]fetching.VocabularyItem, 0, len(words))
+		for _, word := range words {
+			items = append(items, fetching.VocabularyItem{Name: word})
+		}
+		json.NewEncoder(w).Encode(fetching.VocabularyResponse{Items: items})
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func vocabularyConfig(url string) *config.MockConfigManager {
+	return &config.MockConfigManager{
+		GetStringFunc: func(key string) (string, error) {
+			if key == "vocabulary_url" {
+				return url, nil
+			}
+			return "PERFUME_HUB_INTERNAL_TOKEN", nil
+		},
+	}
+}
+
+func TestNewTagsAdvisor(t *testing.T) {
+	t.Parallel()
+
+	cm := vocabularyConfig(vocabularyServer(t).URL)
+	advisor, forbidden, err := NewTagsAdvisor(context.Background(), cm, []string{"warm:2", "-sweet"})
+	if err != nil || advisor == nil {
+		t.Fatalf("expected advisor, got %v", err)
+	}
+	if !slices.Equal(forbidden, []string{"sweet"}) {
+		t.Fatalf("expected sweet to be excluded, got %v", forbidden)
+	}
+
+	if _, _, err := NewTagsAdvisor(context.Background(), cm, []string{"-sweet"}); err == nil {
+		t.Fatalf("expected error without requested tags")
+	}
+	if _, _, err := NewTagsAdvisor(context.Background(), cm, []string{"cozy"}); err == nil {
+		t.Fatalf("expected error for tag outside of the tags table")
+	}
+}
+
+func TestNewTagsAdvisor_VocabularyUnavailable(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	_, _, err := NewTagsAdvisor(context.Background(), vocabularyConfig(server.URL), []string{"warm"})
+	if _, ok := err.(*errors.ServiceError); !ok {
+		t.Fatalf("expected ServiceError, got %v", err)
+	}
+}
+
+func TestCreateAIAdvisor_Mode(t *testing.T) {
+	t.Parallel()
+
+	for mode, isHybrid := range map[string]bool{"": false, "ai": false, "hybrid": true} {
+		cm := &config.MockConfigManager{
+			GetStringFunc: func(key string) (string, error) {
+				return "http://perfume-hub:8000/v1/perfumes/get", nil
+			},
+			GetStringWithDefaultFunc: func(key string, defaultValue string) string {
+				if key == "ai_mode" && mode != "" {
+					return mode
+				}
+				return defaultValue
+			},
+		}
+
+		advisor, err := NewAIAdvisor(cm, nil, false)
+		if err != nil {
+			t.Fatalf("%q: expected no error, got %v", mode, err)
+		}
+		if _, ok := advisor.(*advising.Hybrid); ok != isHybrid {
+			t.Fatalf("%q: expected hybrid advisor to be %v, got %T", mode, isHybrid, advisor)
+		}
+	}
+}
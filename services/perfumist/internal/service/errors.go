@@ -0,0 +1,31 @@
+package service
+
+import "github.com/zemld/Scently/perfumist/internal/errors"
+
+// ErrorKind is what a transport needs to know about an error to choose its
+// status or code.
+type ErrorKind int
+
+const (
+	InternalError ErrorKind = iota
+	ValidationError
+	NotFoundError
+	AuthError
+)
+
+// ClassifyError also returns the message to show to the client: errors of
+// unknown types are hidden behind a generic one.
+func ClassifyError(err error) (ErrorKind, string) {
+	switch e := err.(type) {
+	case *errors.ValidationError:
+		return ValidationError, e.Error()
+	case *errors.NotFoundError:
+		return NotFoundError, e.Error()
+	case *errors.AuthError:
+		return AuthError, e.Error()
+	case *errors.ServiceError:
+		return InternalError, e.Error()
+	default:
+		return InternalError, "internal server error"
+	}
+}
@@ -0,0 +1,75 @@
+package service
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/zemld/Scently/perfumist/internal/models/fetching"
+	"github.com/zemld/Scently/perfumist/internal/models/llm"
+	"github.com/zemld/config-manager/pkg/cm"
+)
+
+var (
+	descriptionCacheOnce sync.Once
+	descriptionCache     *fetching.DescriptionCache
+)
+
+// NewLLMProvider builds the llm_providers chain: every provider is retried on
+// its own and the next one is asked only when the previous one gave up.
+func NewLLMProvider(cm cm.ConfigManager) llm.Provider {
+	var providers []llm.Provider
+	for _, name := range strings.Split(cm.GetStringWithDefault("llm_providers", "yandex"), ",") {
+		name = strings.TrimSpace(name)
+		if name == "" {
+			continue
+		}
+		provider, err := newLLMProvider(name, cm)
+		if err != nil {
+			log.Printf("Cannot create llm provider: %v\n", err)
+			continue
+		}
+		providers = append(providers, llm.NewRetrying(
+			provider,
+			cm.GetIntWithDefault("llm_retries", 2),
+			cm.GetDurationWithDefault("llm_retry_backoff", 500*time.Millisecond),
+		))
+	}
+	return llm.NewFallback(providers...)
+}
+
+func newLLMProvider(name string, cm cm.ConfigManager) (llm.Provider, error) {
+	switch name {
+	case "yandex":
+		return llm.NewYandexGPT(
+			cm.GetStringWithDefault("yandex_url", os.Getenv("BASE_URL")),
+			os.Getenv("FOLDER_ID"),
+			cm.GetStringWithDefault("yandex_model", os.Getenv("MODEL_NAME")),
+			os.Getenv("API_KEY"),
+		), nil
+	case "openai":
+		return llm.NewOpenAI(
+			cm.GetStringWithDefault("openai_url", "https://api.openai.com/v1/chat/completions"),
+			cm.GetStringWithDefault("openai_model", "gpt-4o-mini"),
+			os.Getenv("OPENAI_API_KEY"),
+		), nil
+	default:
+		return nil, fmt.Errorf("unknown llm provider %q", name)
+	}
+}
+
+// NewDescriber shares the description cache between requests, the provider
+// chain follows the current config.
+func NewDescriber(cm cm.ConfigManager) *fetching.Describer {
+	descriptionCacheOnce.Do(func() {
+		descriptionCache = fetching.NewDescriptionCache(cm.GetIntWithDefault("describe_cache_size", 1000))
+	})
+	return fetching.NewDescriber(NewLLMProvider(cm), descriptionCache, cm)
+}
+
+func NewAIFetcher(cm cm.ConfigManager) *fetching.AI {
+	return fetching.NewAI(NewLLMProvider(cm), cm)
+}
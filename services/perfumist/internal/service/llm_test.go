@@ -0,0 +1,70 @@
+package service
+
+import (
+	"os"
+	"testing"
+
+	"github.com/zemld/Scently/perfumist/internal/config"
+)
+
+func TestCreateAIFetcher_Success(t *testing.T) {
+	t.Parallel()
+
+	originalBaseURL := os.Getenv("BASE_URL")
+	originalFolderID := os.Getenv("FOLDER_ID")
+	originalModelName := os.Getenv("MODEL_NAME")
+	originalAPIKey := os.Getenv("API_KEY")
+	defer func() {
+		if originalBaseURL != "" {
+			os.Setenv("BASE_URL", originalBaseURL)
+		} else {
+			os.Unsetenv("BASE_URL")
+		}
+		if originalFolderID != "" {
+			os.Setenv("FOLDER_ID", originalFolderID)
+		} else {
+			os.Unsetenv("FOLDER_ID")
+		}
+		if originalModelName != "" {
+			os.Setenv("MODEL_NAME", originalModelName)
+		} else {
+			os.Unsetenv("MODEL_NAME")
+		}
+		if originalAPIKey != "" {
+			os.Setenv("API_KEY", originalAPIKey)
+		} else {
+			os.Unsetenv("API_KEY")
+		}
+	}()
+
+	os.Setenv("BASE_URL", "http://test:8000")
+	os.Setenv("FOLDER_ID", "test-folder")
+	os.Setenv("MODEL_NAME", "test-model")
+	os.Setenv("API_KEY", "test-key")
+
+	mockCM := &config.MockConfigManager{}
+	fetcher := NewAIFetcher(mockCM)
+
+	if fetcher == nil {
+		t.Fatal("expected non-nil fetcher")
+	}
+}
+
+func TestCreateLLMProvider_Chain(t *testing.T) {
+	t.Parallel()
+
+	mockCM := &config.MockConfigManager{
+		GetStringWithDefaultFunc: func(key string, defaultValue string) string {
+			if key == "llm_providers" {
+				return "openai, unknown ,yandex"
+			}
+			return defaultValue
+		},
+	}
+	if name := NewLLMProvider(mockCM).Name(); name != "openai,yandex" {
+		t.Fatalf("expected openai,yandex chain, got %q", name)
+	}
+	if name := NewLLMProvider(&config.MockConfigManager{}).Name(); name != "yandex" {
+		t.Fatalf("expected yandex by default, got %q", name)
+	}
+}
@@ -0,0 +1,58 @@
+package service
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/zemld/Scently/perfumist/internal/errors"
+	"github.com/zemld/Scently/perfumist/internal/models/matching"
+	"github.com/zemld/Scently/perfumist/internal/models/parameters"
+	"github.com/zemld/config-manager/pkg/cm"
+)
+
+const DefaultMatcher = matching.SmartEnhancedAlg
+
+// MatcherByAlg uses the configured matcher when alg is empty.
+func MatcherByAlg(alg matching.AlgType, variantPrefix string, cm cm.ConfigManager) (matching.Matcher, error) {
+	if alg == "" {
+		alg = matching.AlgType(cm.GetStringWithDefault("matcher", DefaultMatcher.String()))
+	}
+
+	matcher, ok := matching.GetMatcherByAlg(alg, LoadWeights(cm, alg, variantPrefix))
+	if !ok {
+		algorithms := make([]string, 0, len(matching.Algorithms()))
+		for _, known := range matching.Algorithms() {
+			algorithms = append(algorithms, known.String())
+		}
+		return nil, errors.NewValidationError(
+			parameters.MatcherParamKey,
+			fmt.Sprintf("unknown matcher %q, expected one of %s", alg, strings.Join(algorithms, ", ")),
+		)
+	}
+	return matcher, nil
+}
+
+// LoadWeights reads "<alg>_<weight>" keys first, so that every algorithm can be
+// tuned separately, and falls back to the shared "<weight>" keys. An experiment
+// variant overrides both with "<experiment>_<variant>_<weight>" keys.
+func LoadWeights(cm cm.ConfigManager, alg matching.AlgType, variantPrefix string) matching.Weights {
+	weight := func(key string, defaultValue float64) float64 {
+		value := cm.GetFloatWithDefault(alg.String()+"_"+key, cm.GetFloatWithDefault(key, defaultValue))
+		if variantPrefix != "" {
+			value = cm.GetFloatWithDefault(variantPrefix+key, value)
+		}
+		return value
+	}
+
+	return *matching.NewWeights(
+		weight("family_weight", 0.4),
+		weight("notes_weight", 0.55),
+		weight("type_weight", 0.05),
+		weight("upper_notes_weight", 0.2),
+		weight("core_notes_weight", 0.35),
+		weight("base_notes_weight", 0.45),
+		weight("characteristics_weight", 0.3),
+		weight("tags_weight", 0.5),
+		weight("overlay_weight", 0.2),
+	)
+}
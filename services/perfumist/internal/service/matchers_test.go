@@ -0,0 +1,41 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/zemld/Scently/perfumist/internal/config"
+	"github.com/zemld/Scently/perfumist/internal/models/matching"
+)
+
+func TestLoadWeights_PerAlgorithmOverride(t *testing.T) {
+	t.Parallel()
+
+	mockCM := &config.MockConfigManager{
+		GetFloatWithDefaultFunc: func(key string, defaultValue float64) float64 {
+			switch key {
+			case "smart_tags_weight":
+				return 0.9
+			case "tags_weight":
+				return 0.6
+			case "characteristics_weight":
+				return 0.1
+			}
+			return defaultValue
+		},
+	}
+
+	weights := LoadWeights(mockCM, matching.SmartAlg, "")
+	if weights.TagsWeight != 0.9 {
+		t.Fatalf("expected algorithm specific tags weight 0.9, got %f", weights.TagsWeight)
+	}
+	if weights.CharacteristicsWeight != 0.1 {
+		t.Fatalf("expected shared characteristics weight 0.1, got %f", weights.CharacteristicsWeight)
+	}
+	if weights.FamilyWeight != 0.4 {
+		t.Fatalf("expected default family weight 0.4, got %f", weights.FamilyWeight)
+	}
+
+	if weights := LoadWeights(mockCM, matching.TagsAlg, ""); weights.TagsWeight != 0.6 {
+		t.Fatalf("expected shared tags weight 0.6, got %f", weights.TagsWeight)
+	}
+}
@@ -0,0 +1,144 @@
+// Package service builds the catalog, fetchers and advisors that the HTTP
+// handlers and the gRPC server both serve, so that neither transport depends on
+// the other.
+package service
+
+import (
+	"context"
+	"log"
+	"os"
+	"sync"
+
+	"github.com/zemld/Scently/perfumist/internal/config"
+	"github.com/zemld/Scently/perfumist/internal/errors"
+	"github.com/zemld/Scently/perfumist/internal/models/advising"
+	"github.com/zemld/Scently/perfumist/internal/models/catalog"
+	"github.com/zemld/Scently/perfumist/internal/models/fetching"
+	"github.com/zemld/Scently/perfumist/internal/models/parameters"
+	"github.com/zemld/config-manager/pkg/cm"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+)
+
+const (
+	httpTransport = "http"
+	grpcTransport = "grpc"
+)
+
+var (
+	catalogOnce     sync.Once
+	perfumesCatalog *catalog.Catalog
+
+	perfumeHubConnsMu sync.Mutex
+	perfumeHubConns   = make(map[string]*grpc.ClientConn)
+
+	vocabulariesMu sync.Mutex
+	vocabularies   = make(map[string]*fetching.PerfumeHubVocabulary)
+)
+
+func PerfumesCatalog() *catalog.Catalog {
+	catalogOnce.Do(func() {
+		perfumesCatalog = catalog.NewCatalog(
+			func() (fetching.Fetcher, error) {
+				return NewPerfumeHubFetcher(config.Manager())
+			},
+			config.Manager(),
+		)
+	})
+	return perfumesCatalog
+}
+
+// NewPerfumeHubFetcher talks to perfume-hub over JSON unless
+// perfume_hub_transport is "grpc", so that both transports can be compared.
+func NewPerfumeHubFetcher(cm cm.ConfigManager) (fetching.Fetcher, error) {
+	perfumeHubInternalTokenEnv, err := cm.GetString("perfume_hub_internal_token_env_name")
+	if err != nil {
+		return nil, errors.NewServiceError("failed to get perfume_hub_internal_token_env_name", err)
+	}
+
+	if cm.GetStringWithDefault("perfume_hub_transport", httpTransport) == grpcTransport {
+		conn, err := perfumeHubConn(cm)
+		if err != nil {
+			return nil, err
+		}
+		return fetching.NewPerfumeHubGRPC(conn, os.Getenv(perfumeHubInternalTokenEnv), cm), nil
+	}
+
+	getPerfumesUrl, err := cm.GetString("get_perfumes_url")
+	if err != nil {
+		return nil, errors.NewServiceError("failed to get get_perfumes_url", err)
+	}
+	return fetching.NewPerfumeHub(getPerfumesUrl, os.Getenv(perfumeHubInternalTokenEnv), cm).
+		WithStreamUrl(cm.GetStringWithDefault("stream_perfumes_url", "")), nil
+}
+
+// perfumeHubConn keeps a connection per address, as fetchers are created for
+// every request and the address can change with the config.
+func perfumeHubConn(cm cm.ConfigManager) (*grpc.ClientConn, error) {
+	address, err := cm.GetString("perfume_hub_grpc_address")
+	if err != nil {
+		return nil, errors.NewServiceError("failed to get perfume_hub_grpc_address", err)
+	}
+
+	perfumeHubConnsMu.Lock()
+	defer perfumeHubConnsMu.Unlock()
+	if conn, ok := perfumeHubConns[address]; ok {
+		return conn, nil
+	}
+	conn, err := grpc.NewClient(address, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		return nil, errors.NewServiceError("failed to connect to perfume hub", err)
+	}
+	perfumeHubConns[address] = conn
+	return conn, nil
+}
+
+// CatalogVocabulary reads the vocabulary tables of perfume-hub. Fetchers are
+// kept per url, so that the cached vocabulary outlives requests.
+func CatalogVocabulary(ctx context.Context, cm cm.ConfigManager) (fetching.Vocabulary, error) {
+	vocabularyUrl, err := cm.GetString("vocabulary_url")
+	if err != nil {
+		return fetching.Vocabulary{}, errors.NewServiceError("failed to get vocabulary_url", err)
+	}
+
+	vocabulariesMu.Lock()
+	vocabulary, ok := vocabularies[vocabularyUrl]
+	if !ok {
+		perfumeHubInternalTokenEnv, err := cm.GetString("perfume_hub_internal_token_env_name")
+		if err != nil {
+			vocabulariesMu.Unlock()
+			return fetching.Vocabulary{}, errors.NewServiceError("failed to get perfume_hub_internal_token_env_name", err)
+		}
+		vocabulary = fetching.NewPerfumeHubVocabulary(vocabularyUrl, os.Getenv(perfumeHubInternalTokenEnv), cm)
+		vocabularies[vocabularyUrl] = vocabulary
+	}
+	vocabulariesMu.Unlock()
+	return vocabulary.FetchVocabulary(ctx)
+}
+
+// NewFavouriteResolver returns nil when the search is not configured, then a
+// missing favourite perfume is reported without candidates.
+func NewFavouriteResolver(cm cm.ConfigManager, autoResolve bool) *advising.FavouriteResolver {
+	searchUrl, err := cm.GetString("search_perfumes_url")
+	if err != nil {
+		log.Printf("Cannot get search_perfumes_url: %v\n", err)
+		return nil
+	}
+	perfumeHubInternalTokenEnv, err := cm.GetString("perfume_hub_internal_token_env_name")
+	if err != nil {
+		log.Printf("Cannot get perfume_hub_internal_token_env_name: %v\n", err)
+		return nil
+	}
+	return advising.NewFavouriteResolver(
+		fetching.NewPerfumeHubSearch(searchUrl, os.Getenv(perfumeHubInternalTokenEnv)),
+		cm,
+	).WithAutoResolve(autoResolve)
+}
+
+func DefaultDiversity(cm cm.ConfigManager) parameters.Diversity {
+	return parameters.Diversity{
+		Enabled:  cm.GetBoolWithDefault("diversity_enabled", false),
+		Lambda:   cm.GetFloatWithDefault("diversity_lambda", 0.7),
+		BrandCap: cm.GetIntWithDefault("diversity_brand_cap", 2),
+	}
+}
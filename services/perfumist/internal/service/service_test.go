@@ -0,0 +1,107 @@
+package service
+
+import (
+	"os"
+	"testing"
+
+	"github.com/zemld/Scently/perfumist/internal/config"
+	"github.com/zemld/Scently/perfumist/internal/errors"
+)
+
+func TestCreatePerfumeHubFetcher_Success(t *testing.T) {
+	t.Parallel()
+
+	mockCM := &config.MockConfigManager{
+		GetStringFunc: func(key string) (string, error) {
+			switch key {
+			case "get_perfumes_url":
+				return "http://test:8000/v1/perfumes", nil
+			case "perfume_hub_internal_token_env_name":
+				return "TEST_TOKEN", nil
+			default:
+				return "", nil
+			}
+		},
+	}
+
+	originalToken := os.Getenv("TEST_TOKEN")
+	defer func() {
+		if originalToken != "" {
+			os.Setenv("TEST_TOKEN", originalToken)
+		} else {
+			os.Unsetenv("TEST_TOKEN")
+		}
+	}()
+	os.Setenv("TEST_TOKEN", "test-token-value")
+
+	fetcher, err := NewPerfumeHubFetcher(mockCM)
+
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if fetcher == nil {
+		t.Fatal("expected non-nil fetcher")
+	}
+}
+
+func TestCreatePerfumeHubFetcher_GetPerfumesUrlError(t *testing.T) {
+	t.Parallel()
+
+	mockCM := &config.MockConfigManager{
+		GetStringFunc: func(key string) (string, error) {
+			if key == "get_perfumes_url" {
+				return "", errors.NewServiceError("config error", nil)
+			}
+			return "", nil
+		},
+	}
+
+	fetcher, err := NewPerfumeHubFetcher(mockCM)
+
+	if err == nil {
+		t.Fatal("expected error when get_perfumes_url fails")
+	}
+	if fetcher != nil {
+		t.Fatal("expected nil fetcher on error")
+	}
+	serviceErr, ok := err.(*errors.ServiceError)
+	if !ok {
+		t.Fatalf("expected ServiceError, got %T", err)
+	}
+	if serviceErr.Message != "failed to get get_perfumes_url" {
+		t.Fatalf("expected message %q, got %q", "failed to get get_perfumes_url", serviceErr.Message)
+	}
+}
+
+func TestCreatePerfumeHubFetcher_PerfumeHubInternalTokenEnvError(t *testing.T) {
+	t.Parallel()
+
+	mockCM := &config.MockConfigManager{
+		GetStringFunc: func(key string) (string, error) {
+			switch key {
+			case "get_perfumes_url":
+				return "http://test:8000/v1/perfumes", nil
+			case "perfume_hub_internal_token_env_name":
+				return "", errors.NewServiceError("config error", nil)
+			default:
+				return "", nil
+			}
+		},
+	}
+
+	fetcher, err := NewPerfumeHubFetcher(mockCM)
+
+	if err == nil {
+		t.Fatal("expected error when perfume_hub_internal_token_env_name fails")
+	}
+	if fetcher != nil {
+		t.Fatal("expected nil fetcher on error")
+	}
+	serviceErr, ok := err.(*errors.ServiceError)
+	if !ok {
+		t.Fatalf("expected ServiceError, got %T", err)
+	}
+	if serviceErr.Message != "failed to get perfume_hub_internal_token_env_name" {
+		t.Fatalf("expected message %q, got %q", "failed to get perfume_hub_internal_token_env_name", serviceErr.Message)
+	}
+}
@@ -0,0 +1,92 @@
+package service
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/zemld/Scently/perfumist/internal/errors"
+	"github.com/zemld/Scently/perfumist/internal/models/parameters"
+)
+
+type TagsQuery struct {
+	Weighted  map[string]int
+	Required  []string
+	Forbidden []string
+}
+
+// ParseTags reads tags like "warm:3,*woody,-sweet". A weight counts as
+// repeating the tag, "*" marks a tag every suggestion must have and "-" one it
+// must not have. "+" is also read as required, but it only survives the query
+// string when encoded as %2B. Tags are checked against vocabulary.
+func ParseTags(items []string, vocabulary []string) (TagsQuery, error) {
+	if len(items) == 0 {
+		return TagsQuery{}, errors.NewValidationError(parameters.TagsParamKey, "are required")
+	}
+
+	known := make(map[string]string, len(vocabulary))
+	for _, tag := range vocabulary {
+		known[strings.ToLower(tag)] = tag
+	}
+
+	query := TagsQuery{Weighted: make(map[string]int), Required: make([]string, 0), Forbidden: make([]string, 0)}
+	for _, item := range items {
+		modifier := item[0]
+		if modifier == '+' {
+			modifier = '*'
+		}
+		if modifier == '*' || modifier == '-' {
+			item = strings.TrimSpace(item[1:])
+		}
+		name, rawWeight, hasWeight := strings.Cut(item, ":")
+		tag, err := knownTag(strings.TrimSpace(name), known, vocabulary)
+		if err != nil {
+			return TagsQuery{}, err
+		}
+
+		if modifier == '-' {
+			if hasWeight {
+				return TagsQuery{}, errors.NewValidationError(parameters.TagsParamKey, fmt.Sprintf("excluded tag %s cannot have a weight", tag))
+			}
+			query.Forbidden = append(query.Forbidden, tag)
+			continue
+		}
+		weight := 1
+		if hasWeight {
+			weight, err = strconv.Atoi(strings.TrimSpace(rawWeight))
+			if err != nil || weight <= 0 {
+				return TagsQuery{}, errors.NewValidationError(parameters.TagsParamKey, fmt.Sprintf("weight of %s must be a positive integer", tag))
+			}
+		}
+		if modifier == '*' {
+			query.Required = append(query.Required, tag)
+		}
+		query.Weighted[tag] += weight
+	}
+
+	if len(query.Weighted) == 0 {
+		return TagsQuery{}, errors.NewValidationError(parameters.TagsParamKey, "at least one tag must not be excluded")
+	}
+	for _, tag := range query.Forbidden {
+		if _, ok := query.Weighted[tag]; ok {
+			return TagsQuery{}, errors.NewValidationError(parameters.TagsParamKey, fmt.Sprintf("tag %s is both requested and excluded", tag))
+		}
+	}
+	return query, nil
+}
+
+func knownTag(tag string, known map[string]string, vocabulary []string) (string, error) {
+	if tag == "" {
+		return "", errors.NewValidationError(parameters.TagsParamKey, "must not contain empty tags")
+	}
+	if canonical, ok := known[strings.ToLower(tag)]; ok {
+		return canonical, nil
+	}
+	return "", errors.NewValidationError(parameters.TagsParamKey, fmt.Sprintf("unknown tag %q, expected one of %s", tag, strings.Join(vocabulary, ", ")))
+}
+
+// DefaultMinMatch caps minimal_tag_count by the number of requested tags, so
+// that short queries are not left without suggestions.
+func DefaultMinMatch(defaultValue int, tagsCount int) int {
+	return max(min(defaultValue, tagsCount), 0)
+}
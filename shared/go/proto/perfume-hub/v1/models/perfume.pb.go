@@ -0,0 +1,609 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.11
+// 	protoc        (unknown)
+// source: perfume-hub/v1/models/perfume.proto
+
+package models
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type Perfume_Sex int32
+
+const (
+	Perfume_UNISEX Perfume_Sex = 0
+	Perfume_MALE   Perfume_Sex = 1
+	Perfume_FEMALE Perfume_Sex = 2
+)
+
+// Enum value maps for Perfume_Sex.
+var (
+	Perfume_Sex_name = map[int32]string{
+		0: "UNISEX",
+		1: "MALE",
+		2: "FEMALE",
+	}
+	Perfume_Sex_value = map[string]int32{
+		"UNISEX": 0,
+		"MALE":   1,
+		"FEMALE": 2,
+	}
+)
+
+func (x Perfume_Sex) Enum() *Perfume_Sex {
+	p := new(Perfume_Sex)
+	*p = x
+	return p
+}
+
+func (x Perfume_Sex) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (Perfume_Sex) Descriptor() protoreflect.EnumDescriptor {
+	return file_perfume_hub_v1_models_perfume_proto_enumTypes[0].Descriptor()
+}
+
+func (Perfume_Sex) Type() protoreflect.EnumType {
+	return &file_perfume_hub_v1_models_perfume_proto_enumTypes[0]
+}
+
+func (x Perfume_Sex) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use Perfume_Sex.Descriptor instead.
+func (Perfume_Sex) EnumDescriptor() ([]byte, []int) {
+	return file_perfume_hub_v1_models_perfume_proto_rawDescGZIP(), []int{0, 0}
+}
+
+type Perfume struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Brand         string                 `protobuf:"bytes,1,opt,name=brand,proto3" json:"brand,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Sex           Perfume_Sex            `protobuf:"varint,3,opt,name=sex,proto3,enum=perfume_hub.v1.models.Perfume_Sex" json:"sex,omitempty"`
+	ImageUrl      string                 `protobuf:"bytes,4,opt,name=image_url,json=imageUrl,proto3" json:"image_url,omitempty"`
+	Properties    *Perfume_Properties    `protobuf:"bytes,5,opt,name=properties,proto3" json:"properties,omitempty"`
+	Shops         []*Perfume_Shop        `protobuf:"bytes,6,rep,name=shops,proto3" json:"shops,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Perfume) Reset() {
+	*x = Perfume{}
+	mi := &file_perfume_hub_v1_models_perfume_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Perfume) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Perfume) ProtoMessage() {}
+
+func (x *Perfume) ProtoReflect() protoreflect.Message {
+	mi := &file_perfume_hub_v1_models_perfume_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Perfume.ProtoReflect.Descriptor instead.
+func (*Perfume) Descriptor() ([]byte, []int) {
+	return file_perfume_hub_v1_models_perfume_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Perfume) GetBrand() string {
+	if x != nil {
+		return x.Brand
+	}
+	return ""
+}
+
+func (x *Perfume) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Perfume) GetSex() Perfume_Sex {
+	if x != nil {
+		return x.Sex
+	}
+	return Perfume_UNISEX
+}
+
+func (x *Perfume) GetImageUrl() string {
+	if x != nil {
+		return x.ImageUrl
+	}
+	return ""
+}
+
+func (x *Perfume) GetProperties() *Perfume_Properties {
+	if x != nil {
+		return x.Properties
+	}
+	return nil
+}
+
+func (x *Perfume) GetShops() []*Perfume_Shop {
+	if x != nil {
+		return x.Shops
+	}
+	return nil
+}
+
+type Perfume_Properties struct {
+	state              protoimpl.MessageState             `protogen:"open.v1"`
+	Type               string                             `protobuf:"bytes,1,opt,name=type,proto3" json:"type,omitempty"`
+	Family             []string                           `protobuf:"bytes,2,rep,name=family,proto3" json:"family,omitempty"`
+	UpperNotes         []string                           `protobuf:"bytes,3,rep,name=upper_notes,json=upperNotes,proto3" json:"upper_notes,omitempty"`
+	CoreNotes          []string                           `protobuf:"bytes,4,rep,name=core_notes,json=coreNotes,proto3" json:"core_notes,omitempty"`
+	BaseNotes          []string                           `protobuf:"bytes,5,rep,name=base_notes,json=baseNotes,proto3" json:"base_notes,omitempty"`
+	EnrichedUpperNotes []*Perfume_Properties_EnrichedNote `protobuf:"bytes,6,rep,name=enriched_upper_notes,json=enrichedUpperNotes,proto3" json:"enriched_upper_notes,omitempty"`
+	EnrichedCoreNotes  []*Perfume_Properties_EnrichedNote `protobuf:"bytes,7,rep,name=enriched_core_notes,json=enrichedCoreNotes,proto3" json:"enriched_core_notes,omitempty"`
+	EnrichedBaseNotes  []*Perfume_Properties_EnrichedNote `protobuf:"bytes,8,rep,name=enriched_base_notes,json=enrichedBaseNotes,proto3" json:"enriched_base_notes,omitempty"`
+	unknownFields      protoimpl.UnknownFields
+	sizeCache          protoimpl.SizeCache
+}
+
+func (x *Perfume_Properties) Reset() {
+	*x = Perfume_Properties{}
+	mi := &file_perfume_hub_v1_models_perfume_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Perfume_Properties) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Perfume_Properties) ProtoMessage() {}
+
+func (x *Perfume_Properties) ProtoReflect() protoreflect.Message {
+	mi := &file_perfume_hub_v1_models_perfume_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Perfume_Properties.ProtoReflect.Descriptor instead.
+func (*Perfume_Properties) Descriptor() ([]byte, []int) {
+	return file_perfume_hub_v1_models_perfume_proto_rawDescGZIP(), []int{0, 0}
+}
+
+func (x *Perfume_Properties) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *Perfume_Properties) GetFamily() []string {
+	if x != nil {
+		return x.Family
+	}
+	return nil
+}
+
+func (x *Perfume_Properties) GetUpperNotes() []string {
+	if x != nil {
+		return x.UpperNotes
+	}
+	return nil
+}
+
+func (x *Perfume_Properties) GetCoreNotes() []string {
+	if x != nil {
+		return x.CoreNotes
+	}
+	return nil
+}
+
+func (x *Perfume_Properties) GetBaseNotes() []string {
+	if x != nil {
+		return x.BaseNotes
+	}
+	return nil
+}
+
+func (x *Perfume_Properties) GetEnrichedUpperNotes() []*Perfume_Properties_EnrichedNote {
+	if x != nil {
+		return x.EnrichedUpperNotes
+	}
+	return nil
+}
+
+func (x *Perfume_Properties) GetEnrichedCoreNotes() []*Perfume_Properties_EnrichedNote {
+	if x != nil {
+		return x.EnrichedCoreNotes
+	}
+	return nil
+}
+
+func (x *Perfume_Properties) GetEnrichedBaseNotes() []*Perfume_Properties_EnrichedNote {
+	if x != nil {
+		return x.EnrichedBaseNotes
+	}
+	return nil
+}
+
+type Perfume_Shop struct {
+	state         protoimpl.MessageState  `protogen:"open.v1"`
+	Name          string                  `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Domain        string                  `protobuf:"bytes,2,opt,name=domain,proto3" json:"domain,omitempty"`
+	ImageUrl      string                  `protobuf:"bytes,3,opt,name=image_url,json=imageUrl,proto3" json:"image_url,omitempty"`
+	Variants      []*Perfume_Shop_Variant `protobuf:"bytes,4,rep,name=variants,proto3" json:"variants,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Perfume_Shop) Reset() {
+	*x = Perfume_Shop{}
+	mi := &file_perfume_hub_v1_models_perfume_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Perfume_Shop) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Perfume_Shop) ProtoMessage() {}
+
+func (x *Perfume_Shop) ProtoReflect() protoreflect.Message {
+	mi := &file_perfume_hub_v1_models_perfume_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Perfume_Shop.ProtoReflect.Descriptor instead.
+func (*Perfume_Shop) Descriptor() ([]byte, []int) {
+	return file_perfume_hub_v1_models_perfume_proto_rawDescGZIP(), []int{0, 1}
+}
+
+func (x *Perfume_Shop) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Perfume_Shop) GetDomain() string {
+	if x != nil {
+		return x.Domain
+	}
+	return ""
+}
+
+func (x *Perfume_Shop) GetImageUrl() string {
+	if x != nil {
+		return x.ImageUrl
+	}
+	return ""
+}
+
+func (x *Perfume_Shop) GetVariants() []*Perfume_Shop_Variant {
+	if x != nil {
+		return x.Variants
+	}
+	return nil
+}
+
+type Perfume_Properties_EnrichedNote struct {
+	state           protoimpl.MessageState                                `protogen:"open.v1"`
+	Name            string                                                `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Tags            []string                                              `protobuf:"bytes,2,rep,name=tags,proto3" json:"tags,omitempty"`
+	Characteristics []*Perfume_Properties_EnrichedNote_NoteCharacteristic `protobuf:"bytes,3,rep,name=characteristics,proto3" json:"characteristics,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *Perfume_Properties_EnrichedNote) Reset() {
+	*x = Perfume_Properties_EnrichedNote{}
+	mi := &file_perfume_hub_v1_models_perfume_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Perfume_Properties_EnrichedNote) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Perfume_Properties_EnrichedNote) ProtoMessage() {}
+
+func (x *Perfume_Properties_EnrichedNote) ProtoReflect() protoreflect.Message {
+	mi := &file_perfume_hub_v1_models_perfume_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Perfume_Properties_EnrichedNote.ProtoReflect.Descriptor instead.
+func (*Perfume_Properties_EnrichedNote) Descriptor() ([]byte, []int) {
+	return file_perfume_hub_v1_models_perfume_proto_rawDescGZIP(), []int{0, 0, 0}
+}
+
+func (x *Perfume_Properties_EnrichedNote) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Perfume_Properties_EnrichedNote) GetTags() []string {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
+}
+
+func (x *Perfume_Properties_EnrichedNote) GetCharacteristics() []*Perfume_Properties_EnrichedNote_NoteCharacteristic {
+	if x != nil {
+		return x.Characteristics
+	}
+	return nil
+}
+
+type Perfume_Properties_EnrichedNote_NoteCharacteristic struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Value         float64                `protobuf:"fixed64,2,opt,name=value,proto3" json:"value,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Perfume_Properties_EnrichedNote_NoteCharacteristic) Reset() {
+	*x = Perfume_Properties_EnrichedNote_NoteCharacteristic{}
+	mi := &file_perfume_hub_v1_models_perfume_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Perfume_Properties_EnrichedNote_NoteCharacteristic) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Perfume_Properties_EnrichedNote_NoteCharacteristic) ProtoMessage() {}
+
+func (x *Perfume_Properties_EnrichedNote_NoteCharacteristic) ProtoReflect() protoreflect.Message {
+	mi := &file_perfume_hub_v1_models_perfume_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Perfume_Properties_EnrichedNote_NoteCharacteristic.ProtoReflect.Descriptor instead.
+func (*Perfume_Properties_EnrichedNote_NoteCharacteristic) Descriptor() ([]byte, []int) {
+	return file_perfume_hub_v1_models_perfume_proto_rawDescGZIP(), []int{0, 0, 0, 0}
+}
+
+func (x *Perfume_Properties_EnrichedNote_NoteCharacteristic) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Perfume_Properties_EnrichedNote_NoteCharacteristic) GetValue() float64 {
+	if x != nil {
+		return x.Value
+	}
+	return 0
+}
+
+type Perfume_Shop_Variant struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Volume        int32                  `protobuf:"varint,1,opt,name=volume,proto3" json:"volume,omitempty"`
+	Link          string                 `protobuf:"bytes,2,opt,name=link,proto3" json:"link,omitempty"`
+	Price         int32                  `protobuf:"varint,3,opt,name=price,proto3" json:"price,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Perfume_Shop_Variant) Reset() {
+	*x = Perfume_Shop_Variant{}
+	mi := &file_perfume_hub_v1_models_perfume_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Perfume_Shop_Variant) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Perfume_Shop_Variant) ProtoMessage() {}
+
+func (x *Perfume_Shop_Variant) ProtoReflect() protoreflect.Message {
+	mi := &file_perfume_hub_v1_models_perfume_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Perfume_Shop_Variant.ProtoReflect.Descriptor instead.
+func (*Perfume_Shop_Variant) Descriptor() ([]byte, []int) {
+	return file_perfume_hub_v1_models_perfume_proto_rawDescGZIP(), []int{0, 1, 0}
+}
+
+func (x *Perfume_Shop_Variant) GetVolume() int32 {
+	if x != nil {
+		return x.Volume
+	}
+	return 0
+}
+
+func (x *Perfume_Shop_Variant) GetLink() string {
+	if x != nil {
+		return x.Link
+	}
+	return ""
+}
+
+func (x *Perfume_Shop_Variant) GetPrice() int32 {
+	if x != nil {
+		return x.Price
+	}
+	return 0
+}
+
+var File_perfume_hub_v1_models_perfume_proto protoreflect.FileDescriptor
+
+const file_perfume_hub_v1_models_perfume_proto_rawDesc = "" +
+	"\n" +
+	"#perfume-hub/v1/models/perfume.proto\x12\x15perfume_hub.v1.models\"\xdf\t\n" +
+	"\aPerfume\x12\x14\n" +
+	"\x05brand\x18\x01 \x01(\tR\x05brand\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x124\n" +
+	"\x03sex\x18\x03 \x01(\x0e2\".perfume_hub.v1.models.Perfume.SexR\x03sex\x12\x1b\n" +
+	"\timage_url\x18\x04 \x01(\tR\bimageUrl\x12I\n" +
+	"\n" +
+	"properties\x18\x05 \x01(\v2).perfume_hub.v1.models.Perfume.PropertiesR\n" +
+	"properties\x129\n" +
+	"\x05shops\x18\x06 \x03(\v2#.perfume_hub.v1.models.Perfume.ShopR\x05shops\x1a\xbf\x05\n" +
+	"\n" +
+	"Properties\x12\x12\n" +
+	"\x04type\x18\x01 \x01(\tR\x04type\x12\x16\n" +
+	"\x06family\x18\x02 \x03(\tR\x06family\x12\x1f\n" +
+	"\vupper_notes\x18\x03 \x03(\tR\n" +
+	"upperNotes\x12\x1d\n" +
+	"\n" +
+	"core_notes\x18\x04 \x03(\tR\tcoreNotes\x12\x1d\n" +
+	"\n" +
+	"base_notes\x18\x05 \x03(\tR\tbaseNotes\x12h\n" +
+	"\x14enriched_upper_notes\x18\x06 \x03(\v26.perfume_hub.v1.models.Perfume.Properties.EnrichedNoteR\x12enrichedUpperNotes\x12f\n" +
+	"\x13enriched_core_notes\x18\a \x03(\v26.perfume_hub.v1.models.Perfume.Properties.EnrichedNoteR\x11enrichedCoreNotes\x12f\n" +
+	"\x13enriched_base_notes\x18\b \x03(\v26.perfume_hub.v1.models.Perfume.Properties.EnrichedNoteR\x11enrichedBaseNotes\x1a\xeb\x01\n" +
+	"\fEnrichedNote\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x12\n" +
+	"\x04tags\x18\x02 \x03(\tR\x04tags\x12s\n" +
+	"\x0fcharacteristics\x18\x03 \x03(\v2I.perfume_hub.v1.models.Perfume.Properties.EnrichedNote.NoteCharacteristicR\x0fcharacteristics\x1a>\n" +
+	"\x12NoteCharacteristic\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\x01R\x05value\x1a\xe5\x01\n" +
+	"\x04Shop\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x16\n" +
+	"\x06domain\x18\x02 \x01(\tR\x06domain\x12\x1b\n" +
+	"\timage_url\x18\x03 \x01(\tR\bimageUrl\x12G\n" +
+	"\bvariants\x18\x04 \x03(\v2+.perfume_hub.v1.models.Perfume.Shop.VariantR\bvariants\x1aK\n" +
+	"\aVariant\x12\x16\n" +
+	"\x06volume\x18\x01 \x01(\x05R\x06volume\x12\x12\n" +
+	"\x04link\x18\x02 \x01(\tR\x04link\x12\x14\n" +
+	"\x05price\x18\x03 \x01(\x05R\x05price\"'\n" +
+	"\x03Sex\x12\n" +
+	"\n" +
+	"\x06UNISEX\x10\x00\x12\b\n" +
+	"\x04MALE\x10\x01\x12\n" +
+	"\n" +
+	"\x06FEMALE\x10\x02BAZ?github.com/zemld/Scently/shared/proto/perfume-hub/models;modelsb\x06proto3"
+
+var (
+	file_perfume_hub_v1_models_perfume_proto_rawDescOnce sync.Once
+	file_perfume_hub_v1_models_perfume_proto_rawDescData []byte
+)
+
+func file_perfume_hub_v1_models_perfume_proto_rawDescGZIP() []byte {
+	file_perfume_hub_v1_models_perfume_proto_rawDescOnce.Do(func() {
+		file_perfume_hub_v1_models_perfume_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_perfume_hub_v1_models_perfume_proto_rawDesc), len(file_perfume_hub_v1_models_perfume_proto_rawDesc)))
+	})
+	return file_perfume_hub_v1_models_perfume_proto_rawDescData
+}
+
+var file_perfume_hub_v1_models_perfume_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_perfume_hub_v1_models_perfume_proto_msgTypes = make([]protoimpl.MessageInfo, 6)
+var file_perfume_hub_v1_models_perfume_proto_goTypes = []any{
+	(Perfume_Sex)(0),                        // 0: perfume_hub.v1.models.Perfume.Sex
+	(*Perfume)(nil),                         // 1: perfume_hub.v1.models.Perfume
+	(*Perfume_Properties)(nil),              // 2: perfume_hub.v1.models.Perfume.Properties
+	(*Perfume_Shop)(nil),                    // 3: perfume_hub.v1.models.Perfume.Shop
+	(*Perfume_Properties_EnrichedNote)(nil), // 4: perfume_hub.v1.models.Perfume.Properties.EnrichedNote
+	(*Perfume_Properties_EnrichedNote_NoteCharacteristic)(nil), // 5: perfume_hub.v1.models.Perfume.Properties.EnrichedNote.NoteCharacteristic
+	(*Perfume_Shop_Variant)(nil),                               // 6: perfume_hub.v1.models.Perfume.Shop.Variant
+}
+var file_perfume_hub_v1_models_perfume_proto_depIdxs = []int32{
+	0, // 0: perfume_hub.v1.models.Perfume.sex:type_name -> perfume_hub.v1.models.Perfume.Sex
+	2, // 1: perfume_hub.v1.models.Perfume.properties:type_name -> perfume_hub.v1.models.Perfume.Properties
+	3, // 2: perfume_hub.v1.models.Perfume.shops:type_name -> perfume_hub.v1.models.Perfume.Shop
+	4, // 3: perfume_hub.v1.models.Perfume.Properties.enriched_upper_notes:type_name -> perfume_hub.v1.models.Perfume.Properties.EnrichedNote
+	4, // 4: perfume_hub.v1.models.Perfume.Properties.enriched_core_notes:type_name -> perfume_hub.v1.models.Perfume.Properties.EnrichedNote
+	4, // 5: perfume_hub.v1.models.Perfume.Properties.enriched_base_notes:type_name -> perfume_hub.v1.models.Perfume.Properties.EnrichedNote
+	6, // 6: perfume_hub.v1.models.Perfume.Shop.variants:type_name -> perfume_hub.v1.models.Perfume.Shop.Variant
+	5, // 7: perfume_hub.v1.models.Perfume.Properties.EnrichedNote.characteristics:type_name -> perfume_hub.v1.models.Perfume.Properties.EnrichedNote.NoteCharacteristic
+	8, // [8:8] is the sub-list for method output_type
+	8, // [8:8] is the sub-list for method input_type
+	8, // [8:8] is the sub-list for extension type_name
+	8, // [8:8] is the sub-list for extension extendee
+	0, // [0:8] is the sub-list for field type_name
+}
+
+func init() { file_perfume_hub_v1_models_perfume_proto_init() }
+func file_perfume_hub_v1_models_perfume_proto_init() {
+	if File_perfume_hub_v1_models_perfume_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_perfume_hub_v1_models_perfume_proto_rawDesc), len(file_perfume_hub_v1_models_perfume_proto_rawDesc)),
+			NumEnums:      1,
+			NumMessages:   6,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_perfume_hub_v1_models_perfume_proto_goTypes,
+		DependencyIndexes: file_perfume_hub_v1_models_perfume_proto_depIdxs,
+		EnumInfos:         file_perfume_hub_v1_models_perfume_proto_enumTypes,
+		MessageInfos:      file_perfume_hub_v1_models_perfume_proto_msgTypes,
+	}.Build()
+	File_perfume_hub_v1_models_perfume_proto = out.File
+	file_perfume_hub_v1_models_perfume_proto_goTypes = nil
+	file_perfume_hub_v1_models_perfume_proto_depIdxs = nil
+}
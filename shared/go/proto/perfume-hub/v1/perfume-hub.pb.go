@@ -0,0 +1,261 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.11
+// 	protoc        (unknown)
+// source: perfume-hub/v1/perfume-hub.proto
+
+package perfume_hub
+
+import (
+	models "github.com/zemld/Scently/shared/proto/perfume-hub/models"
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type GetPerfumeRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Brand         *string                `protobuf:"bytes,1,opt,name=brand,proto3,oneof" json:"brand,omitempty"`
+	Name          *string                `protobuf:"bytes,2,opt,name=name,proto3,oneof" json:"name,omitempty"`
+	Sex           models.Perfume_Sex     `protobuf:"varint,3,opt,name=sex,proto3,enum=perfume_hub.v1.models.Perfume_Sex" json:"sex,omitempty"`
+	Page          *int32                 `protobuf:"varint,4,opt,name=page,proto3,oneof" json:"page,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetPerfumeRequest) Reset() {
+	*x = GetPerfumeRequest{}
+	mi := &file_perfume_hub_v1_perfume_hub_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetPerfumeRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetPerfumeRequest) ProtoMessage() {}
+
+func (x *GetPerfumeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_perfume_hub_v1_perfume_hub_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetPerfumeRequest.ProtoReflect.Descriptor instead.
+func (*GetPerfumeRequest) Descriptor() ([]byte, []int) {
+	return file_perfume_hub_v1_perfume_hub_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *GetPerfumeRequest) GetBrand() string {
+	if x != nil && x.Brand != nil {
+		return *x.Brand
+	}
+	return ""
+}
+
+func (x *GetPerfumeRequest) GetName() string {
+	if x != nil && x.Name != nil {
+		return *x.Name
+	}
+	return ""
+}
+
+func (x *GetPerfumeRequest) GetSex() models.Perfume_Sex {
+	if x != nil {
+		return x.Sex
+	}
+	return models.Perfume_Sex(0)
+}
+
+func (x *GetPerfumeRequest) GetPage() int32 {
+	if x != nil && x.Page != nil {
+		return *x.Page
+	}
+	return 0
+}
+
+type GetPerfumeResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Perfumes      []*models.Perfume      `protobuf:"bytes,1,rep,name=perfumes,proto3" json:"perfumes,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetPerfumeResponse) Reset() {
+	*x = GetPerfumeResponse{}
+	mi := &file_perfume_hub_v1_perfume_hub_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetPerfumeResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetPerfumeResponse) ProtoMessage() {}
+
+func (x *GetPerfumeResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_perfume_hub_v1_perfume_hub_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetPerfumeResponse.ProtoReflect.Descriptor instead.
+func (*GetPerfumeResponse) Descriptor() ([]byte, []int) {
+	return file_perfume_hub_v1_perfume_hub_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *GetPerfumeResponse) GetPerfumes() []*models.Perfume {
+	if x != nil {
+		return x.Perfumes
+	}
+	return nil
+}
+
+type StreamPerfumesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Sex           models.Perfume_Sex     `protobuf:"varint,1,opt,name=sex,proto3,enum=perfume_hub.v1.models.Perfume_Sex" json:"sex,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StreamPerfumesRequest) Reset() {
+	*x = StreamPerfumesRequest{}
+	mi := &file_perfume_hub_v1_perfume_hub_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StreamPerfumesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StreamPerfumesRequest) ProtoMessage() {}
+
+func (x *StreamPerfumesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_perfume_hub_v1_perfume_hub_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StreamPerfumesRequest.ProtoReflect.Descriptor instead.
+func (*StreamPerfumesRequest) Descriptor() ([]byte, []int) {
+	return file_perfume_hub_v1_perfume_hub_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *StreamPerfumesRequest) GetSex() models.Perfume_Sex {
+	if x != nil {
+		return x.Sex
+	}
+	return models.Perfume_Sex(0)
+}
+
+var File_perfume_hub_v1_perfume_hub_proto protoreflect.FileDescriptor
+
+const file_perfume_hub_v1_perfume_hub_proto_rawDesc = "" +
+	"\n" +
+	" perfume-hub/v1/perfume-hub.proto\x12\x0eperfume_hub.v1\x1a#perfume-hub/v1/models/perfume.proto\"\xb2\x01\n" +
+	"\x11GetPerfumeRequest\x12\x19\n" +
+	"\x05brand\x18\x01 \x01(\tH\x00R\x05brand\x88\x01\x01\x12\x17\n" +
+	"\x04name\x18\x02 \x01(\tH\x01R\x04name\x88\x01\x01\x124\n" +
+	"\x03sex\x18\x03 \x01(\x0e2\".perfume_hub.v1.models.Perfume.SexR\x03sex\x12\x17\n" +
+	"\x04page\x18\x04 \x01(\x05H\x02R\x04page\x88\x01\x01B\b\n" +
+	"\x06_brandB\a\n" +
+	"\x05_nameB\a\n" +
+	"\x05_page\"P\n" +
+	"\x12GetPerfumeResponse\x12:\n" +
+	"\bperfumes\x18\x01 \x03(\v2\x1e.perfume_hub.v1.models.PerfumeR\bperfumes\"M\n" +
+	"\x15StreamPerfumesRequest\x124\n" +
+	"\x03sex\x18\x01 \x01(\x0e2\".perfume_hub.v1.models.Perfume.SexR\x03sex2\xc3\x01\n" +
+	"\x11PerfumeHubService\x12S\n" +
+	"\n" +
+	"GetPerfume\x12!.perfume_hub.v1.GetPerfumeRequest\x1a\".perfume_hub.v1.GetPerfumeResponse\x12Y\n" +
+	"\x0eStreamPerfumes\x12%.perfume_hub.v1.StreamPerfumesRequest\x1a\x1e.perfume_hub.v1.models.Perfume0\x01B3Z1github.com/zemld/Scently/shared/proto/perfume-hubb\x06proto3"
+
+var (
+	file_perfume_hub_v1_perfume_hub_proto_rawDescOnce sync.Once
+	file_perfume_hub_v1_perfume_hub_proto_rawDescData []byte
+)
+
+func file_perfume_hub_v1_perfume_hub_proto_rawDescGZIP() []byte {
+	file_perfume_hub_v1_perfume_hub_proto_rawDescOnce.Do(func() {
+		file_perfume_hub_v1_perfume_hub_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_perfume_hub_v1_perfume_hub_proto_rawDesc), len(file_perfume_hub_v1_perfume_hub_proto_rawDesc)))
+	})
+	return file_perfume_hub_v1_perfume_hub_proto_rawDescData
+}
+
+var file_perfume_hub_v1_perfume_hub_proto_msgTypes = make([]protoimpl.MessageInfo, 3)
+var file_perfume_hub_v1_perfume_hub_proto_goTypes = []any{
+	(*GetPerfumeRequest)(nil),     // 0: perfume_hub.v1.GetPerfumeRequest
+	(*GetPerfumeResponse)(nil),    // 1: perfume_hub.v1.GetPerfumeResponse
+	(*StreamPerfumesRequest)(nil), // 2: perfume_hub.v1.StreamPerfumesRequest
+	(models.Perfume_Sex)(0),       // 3: perfume_hub.v1.models.Perfume.Sex
+	(*models.Perfume)(nil),        // 4: perfume_hub.v1.models.Perfume
+}
+var file_perfume_hub_v1_perfume_hub_proto_depIdxs = []int32{
+	3, // 0: perfume_hub.v1.GetPerfumeRequest.sex:type_name -> perfume_hub.v1.models.Perfume.Sex
+	4, // 1: perfume_hub.v1.GetPerfumeResponse.perfumes:type_name -> perfume_hub.v1.models.Perfume
+	3, // 2: perfume_hub.v1.StreamPerfumesRequest.sex:type_name -> perfume_hub.v1.models.Perfume.Sex
+	0, // 3: perfume_hub.v1.PerfumeHubService.GetPerfume:input_type -> perfume_hub.v1.GetPerfumeRequest
+	2, // 4: perfume_hub.v1.PerfumeHubService.StreamPerfumes:input_type -> perfume_hub.v1.StreamPerfumesRequest
+	1, // 5: perfume_hub.v1.PerfumeHubService.GetPerfume:output_type -> perfume_hub.v1.GetPerfumeResponse
+	4, // 6: perfume_hub.v1.PerfumeHubService.StreamPerfumes:output_type -> perfume_hub.v1.models.Perfume
+	5, // [5:7] is the sub-list for method output_type
+	3, // [3:5] is the sub-list for method input_type
+	3, // [3:3] is the sub-list for extension type_name
+	3, // [3:3] is the sub-list for extension extendee
+	0, // [0:3] is the sub-list for field type_name
+}
+
+func init() { file_perfume_hub_v1_perfume_hub_proto_init() }
+func file_perfume_hub_v1_perfume_hub_proto_init() {
+	if File_perfume_hub_v1_perfume_hub_proto != nil {
+		return
+	}
+	file_perfume_hub_v1_perfume_hub_proto_msgTypes[0].OneofWrappers = []any{}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_perfume_hub_v1_perfume_hub_proto_rawDesc), len(file_perfume_hub_v1_perfume_hub_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   3,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_perfume_hub_v1_perfume_hub_proto_goTypes,
+		DependencyIndexes: file_perfume_hub_v1_perfume_hub_proto_depIdxs,
+		MessageInfos:      file_perfume_hub_v1_perfume_hub_proto_msgTypes,
+	}.Build()
+	File_perfume_hub_v1_perfume_hub_proto = out.File
+	file_perfume_hub_v1_perfume_hub_proto_goTypes = nil
+	file_perfume_hub_v1_perfume_hub_proto_depIdxs = nil
+}
@@ -0,0 +1,164 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             (unknown)
+// source: perfume-hub/v1/perfume-hub.proto
+
+package perfume_hub
+
+import (
+	context "context"
+	models "github.com/zemld/Scently/shared/proto/perfume-hub/models"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	PerfumeHubService_GetPerfume_FullMethodName     = "/perfume_hub.v1.PerfumeHubService/GetPerfume"
+	PerfumeHubService_StreamPerfumes_FullMethodName = "/perfume_hub.v1.PerfumeHubService/StreamPerfumes"
+)
+
+// PerfumeHubServiceClient is the client API for PerfumeHubService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type PerfumeHubServiceClient interface {
+	GetPerfume(ctx context.Context, in *GetPerfumeRequest, opts ...grpc.CallOption) (*GetPerfumeResponse, error)
+	StreamPerfumes(ctx context.Context, in *StreamPerfumesRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[models.Perfume], error)
+}
+
+type perfumeHubServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewPerfumeHubServiceClient(cc grpc.ClientConnInterface) PerfumeHubServiceClient {
+	return &perfumeHubServiceClient{cc}
+}
+
+func (c *perfumeHubServiceClient) GetPerfume(ctx context.Context, in *GetPerfumeRequest, opts ...grpc.CallOption) (*GetPerfumeResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetPerfumeResponse)
+	err := c.cc.Invoke(ctx, PerfumeHubService_GetPerfume_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *perfumeHubServiceClient) StreamPerfumes(ctx context.Context, in *StreamPerfumesRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[models.Perfume], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &PerfumeHubService_ServiceDesc.Streams[0], PerfumeHubService_StreamPerfumes_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[StreamPerfumesRequest, models.Perfume]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type PerfumeHubService_StreamPerfumesClient = grpc.ServerStreamingClient[models.Perfume]
+
+// PerfumeHubServiceServer is the server API for PerfumeHubService service.
+// All implementations must embed UnimplementedPerfumeHubServiceServer
+// for forward compatibility.
+type PerfumeHubServiceServer interface {
+	GetPerfume(context.Context, *GetPerfumeRequest) (*GetPerfumeResponse, error)
+	StreamPerfumes(*StreamPerfumesRequest, grpc.ServerStreamingServer[models.Perfume]) error
+	mustEmbedUnimplementedPerfumeHubServiceServer()
+}
+
+// UnimplementedPerfumeHubServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedPerfumeHubServiceServer struct{}
+
+func (UnimplementedPerfumeHubServiceServer) GetPerfume(context.Context, *GetPerfumeRequest) (*GetPerfumeResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetPerfume not implemented")
+}
+func (UnimplementedPerfumeHubServiceServer) StreamPerfumes(*StreamPerfumesRequest, grpc.ServerStreamingServer[models.Perfume]) error {
+	return status.Errorf(codes.Unimplemented, "method StreamPerfumes not implemented")
+}
+func (UnimplementedPerfumeHubServiceServer) mustEmbedUnimplementedPerfumeHubServiceServer() {}
+func (UnimplementedPerfumeHubServiceServer) testEmbeddedByValue()                           {}
+
+// UnsafePerfumeHubServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to PerfumeHubServiceServer will
+// result in compilation errors.
+type UnsafePerfumeHubServiceServer interface {
+	mustEmbedUnimplementedPerfumeHubServiceServer()
+}
+
+func RegisterPerfumeHubServiceServer(s grpc.ServiceRegistrar, srv PerfumeHubServiceServer) {
+	// If the following call pancis, it indicates UnimplementedPerfumeHubServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&PerfumeHubService_ServiceDesc, srv)
+}
+
+func _PerfumeHubService_GetPerfume_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetPerfumeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PerfumeHubServiceServer).GetPerfume(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PerfumeHubService_GetPerfume_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PerfumeHubServiceServer).GetPerfume(ctx, req.(*GetPerfumeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PerfumeHubService_StreamPerfumes_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(StreamPerfumesRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(PerfumeHubServiceServer).StreamPerfumes(m, &grpc.GenericServerStream[StreamPerfumesRequest, models.Perfume]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type PerfumeHubService_StreamPerfumesServer = grpc.ServerStreamingServer[models.Perfume]
+
+// PerfumeHubService_ServiceDesc is the grpc.ServiceDesc for PerfumeHubService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var PerfumeHubService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "perfume_hub.v1.PerfumeHubService",
+	HandlerType: (*PerfumeHubServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetPerfume",
+			Handler:    _PerfumeHubService_GetPerfume_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "StreamPerfumes",
+			Handler:       _PerfumeHubService_StreamPerfumes_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "perfume-hub/v1/perfume-hub.proto",
+}
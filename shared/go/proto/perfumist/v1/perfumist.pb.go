@@ -0,0 +1,271 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.11
+// 	protoc        (unknown)
+// source: perfumist/v1/perfumist.proto
+
+package perfumist
+
+import (
+	models "github.com/zemld/Scently/shared/proto/perfumist/models"
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type SuggestRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Brand         string                 `protobuf:"bytes,1,opt,name=brand,proto3" json:"brand,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Sex           models.Perfume_Sex     `protobuf:"varint,3,opt,name=sex,proto3,enum=perfumist.v1.models.Perfume_Sex" json:"sex,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SuggestRequest) Reset() {
+	*x = SuggestRequest{}
+	mi := &file_perfumist_v1_perfumist_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SuggestRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SuggestRequest) ProtoMessage() {}
+
+func (x *SuggestRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_perfumist_v1_perfumist_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SuggestRequest.ProtoReflect.Descriptor instead.
+func (*SuggestRequest) Descriptor() ([]byte, []int) {
+	return file_perfumist_v1_perfumist_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *SuggestRequest) GetBrand() string {
+	if x != nil {
+		return x.Brand
+	}
+	return ""
+}
+
+func (x *SuggestRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *SuggestRequest) GetSex() models.Perfume_Sex {
+	if x != nil {
+		return x.Sex
+	}
+	return models.Perfume_Sex(0)
+}
+
+type SuggestByTagsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Tags          []string               `protobuf:"bytes,1,rep,name=tags,proto3" json:"tags,omitempty"`
+	Sex           models.Perfume_Sex     `protobuf:"varint,2,opt,name=sex,proto3,enum=perfumist.v1.models.Perfume_Sex" json:"sex,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SuggestByTagsRequest) Reset() {
+	*x = SuggestByTagsRequest{}
+	mi := &file_perfumist_v1_perfumist_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SuggestByTagsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SuggestByTagsRequest) ProtoMessage() {}
+
+func (x *SuggestByTagsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_perfumist_v1_perfumist_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SuggestByTagsRequest.ProtoReflect.Descriptor instead.
+func (*SuggestByTagsRequest) Descriptor() ([]byte, []int) {
+	return file_perfumist_v1_perfumist_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *SuggestByTagsRequest) GetTags() []string {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
+}
+
+func (x *SuggestByTagsRequest) GetSex() models.Perfume_Sex {
+	if x != nil {
+		return x.Sex
+	}
+	return models.Perfume_Sex(0)
+}
+
+type SuggestResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Same perfumes as suggested, in rank order, for clients built before ranks.
+	Perfumes      []*models.Perfume       `protobuf:"bytes,1,rep,name=perfumes,proto3" json:"perfumes,omitempty"`
+	Suggested     []*models.RankedPerfume `protobuf:"bytes,2,rep,name=suggested,proto3" json:"suggested,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SuggestResponse) Reset() {
+	*x = SuggestResponse{}
+	mi := &file_perfumist_v1_perfumist_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SuggestResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SuggestResponse) ProtoMessage() {}
+
+func (x *SuggestResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_perfumist_v1_perfumist_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SuggestResponse.ProtoReflect.Descriptor instead.
+func (*SuggestResponse) Descriptor() ([]byte, []int) {
+	return file_perfumist_v1_perfumist_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *SuggestResponse) GetPerfumes() []*models.Perfume {
+	if x != nil {
+		return x.Perfumes
+	}
+	return nil
+}
+
+func (x *SuggestResponse) GetSuggested() []*models.RankedPerfume {
+	if x != nil {
+		return x.Suggested
+	}
+	return nil
+}
+
+var File_perfumist_v1_perfumist_proto protoreflect.FileDescriptor
+
+const file_perfumist_v1_perfumist_proto_rawDesc = "" +
+	"\n" +
+	"\x1cperfumist/v1/perfumist.proto\x12\fperfumist.v1\x1a!perfumist/v1/models/perfume.proto\"n\n" +
+	"\x0eSuggestRequest\x12\x14\n" +
+	"\x05brand\x18\x01 \x01(\tR\x05brand\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x122\n" +
+	"\x03sex\x18\x03 \x01(\x0e2 .perfumist.v1.models.Perfume.SexR\x03sex\"^\n" +
+	"\x14SuggestByTagsRequest\x12\x12\n" +
+	"\x04tags\x18\x01 \x03(\tR\x04tags\x122\n" +
+	"\x03sex\x18\x02 \x01(\x0e2 .perfumist.v1.models.Perfume.SexR\x03sex\"\x8d\x01\n" +
+	"\x0fSuggestResponse\x128\n" +
+	"\bperfumes\x18\x01 \x03(\v2\x1c.perfumist.v1.models.PerfumeR\bperfumes\x12@\n" +
+	"\tsuggested\x18\x02 \x03(\v2\".perfumist.v1.models.RankedPerfumeR\tsuggested2\xf8\x01\n" +
+	"\x10PerfumistService\x12F\n" +
+	"\aSuggest\x12\x1c.perfumist.v1.SuggestRequest\x1a\x1d.perfumist.v1.SuggestResponse\x12H\n" +
+	"\tAISuggest\x12\x1c.perfumist.v1.SuggestRequest\x1a\x1d.perfumist.v1.SuggestResponse\x12R\n" +
+	"\rSuggestByTags\x12\".perfumist.v1.SuggestByTagsRequest\x1a\x1d.perfumist.v1.SuggestResponseB1Z/github.com/zemld/Scently/shared/proto/perfumistb\x06proto3"
+
+var (
+	file_perfumist_v1_perfumist_proto_rawDescOnce sync.Once
+	file_perfumist_v1_perfumist_proto_rawDescData []byte
+)
+
+func file_perfumist_v1_perfumist_proto_rawDescGZIP() []byte {
+	file_perfumist_v1_perfumist_proto_rawDescOnce.Do(func() {
+		file_perfumist_v1_perfumist_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_perfumist_v1_perfumist_proto_rawDesc), len(file_perfumist_v1_perfumist_proto_rawDesc)))
+	})
+	return file_perfumist_v1_perfumist_proto_rawDescData
+}
+
+var file_perfumist_v1_perfumist_proto_msgTypes = make([]protoimpl.MessageInfo, 3)
+var file_perfumist_v1_perfumist_proto_goTypes = []any{
+	(*SuggestRequest)(nil),       // 0: perfumist.v1.SuggestRequest
+	(*SuggestByTagsRequest)(nil), // 1: perfumist.v1.SuggestByTagsRequest
+	(*SuggestResponse)(nil),      // 2: perfumist.v1.SuggestResponse
+	(models.Perfume_Sex)(0),      // 3: perfumist.v1.models.Perfume.Sex
+	(*models.Perfume)(nil),       // 4: perfumist.v1.models.Perfume
+	(*models.RankedPerfume)(nil), // 5: perfumist.v1.models.RankedPerfume
+}
+var file_perfumist_v1_perfumist_proto_depIdxs = []int32{
+	3, // 0: perfumist.v1.SuggestRequest.sex:type_name -> perfumist.v1.models.Perfume.Sex
+	3, // 1: perfumist.v1.SuggestByTagsRequest.sex:type_name -> perfumist.v1.models.Perfume.Sex
+	4, // 2: perfumist.v1.SuggestResponse.perfumes:type_name -> perfumist.v1.models.Perfume
+	5, // 3: perfumist.v1.SuggestResponse.suggested:type_name -> perfumist.v1.models.RankedPerfume
+	0, // 4: perfumist.v1.PerfumistService.Suggest:input_type -> perfumist.v1.SuggestRequest
+	0, // 5: perfumist.v1.PerfumistService.AISuggest:input_type -> perfumist.v1.SuggestRequest
+	1, // 6: perfumist.v1.PerfumistService.SuggestByTags:input_type -> perfumist.v1.SuggestByTagsRequest
+	2, // 7: perfumist.v1.PerfumistService.Suggest:output_type -> perfumist.v1.SuggestResponse
+	2, // 8: perfumist.v1.PerfumistService.AISuggest:output_type -> perfumist.v1.SuggestResponse
+	2, // 9: perfumist.v1.PerfumistService.SuggestByTags:output_type -> perfumist.v1.SuggestResponse
+	7, // [7:10] is the sub-list for method output_type
+	4, // [4:7] is the sub-list for method input_type
+	4, // [4:4] is the sub-list for extension type_name
+	4, // [4:4] is the sub-list for extension extendee
+	0, // [0:4] is the sub-list for field type_name
+}
+
+func init() { file_perfumist_v1_perfumist_proto_init() }
+func file_perfumist_v1_perfumist_proto_init() {
+	if File_perfumist_v1_perfumist_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_perfumist_v1_perfumist_proto_rawDesc), len(file_perfumist_v1_perfumist_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   3,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_perfumist_v1_perfumist_proto_goTypes,
+		DependencyIndexes: file_perfumist_v1_perfumist_proto_depIdxs,
+		MessageInfos:      file_perfumist_v1_perfumist_proto_msgTypes,
+	}.Build()
+	File_perfumist_v1_perfumist_proto = out.File
+	file_perfumist_v1_perfumist_proto_goTypes = nil
+	file_perfumist_v1_perfumist_proto_depIdxs = nil
+}
@@ -0,0 +1,197 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             (unknown)
+// source: perfumist/v1/perfumist.proto
+
+package perfumist
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	PerfumistService_Suggest_FullMethodName       = "/perfumist.v1.PerfumistService/Suggest"
+	PerfumistService_AISuggest_FullMethodName     = "/perfumist.v1.PerfumistService/AISuggest"
+	PerfumistService_SuggestByTags_FullMethodName = "/perfumist.v1.PerfumistService/SuggestByTags"
+)
+
+// PerfumistServiceClient is the client API for PerfumistService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type PerfumistServiceClient interface {
+	Suggest(ctx context.Context, in *SuggestRequest, opts ...grpc.CallOption) (*SuggestResponse, error)
+	AISuggest(ctx context.Context, in *SuggestRequest, opts ...grpc.CallOption) (*SuggestResponse, error)
+	SuggestByTags(ctx context.Context, in *SuggestByTagsRequest, opts ...grpc.CallOption) (*SuggestResponse, error)
+}
+
+type perfumistServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewPerfumistServiceClient(cc grpc.ClientConnInterface) PerfumistServiceClient {
+	return &perfumistServiceClient{cc}
+}
+
+func (c *perfumistServiceClient) Suggest(ctx context.Context, in *SuggestRequest, opts ...grpc.CallOption) (*SuggestResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SuggestResponse)
+	err := c.cc.Invoke(ctx, PerfumistService_Suggest_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *perfumistServiceClient) AISuggest(ctx context.Context, in *SuggestRequest, opts ...grpc.CallOption) (*SuggestResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SuggestResponse)
+	err := c.cc.Invoke(ctx, PerfumistService_AISuggest_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *perfumistServiceClient) SuggestByTags(ctx context.Context, in *SuggestByTagsRequest, opts ...grpc.CallOption) (*SuggestResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SuggestResponse)
+	err := c.cc.Invoke(ctx, PerfumistService_SuggestByTags_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// PerfumistServiceServer is the server API for PerfumistService service.
+// All implementations must embed UnimplementedPerfumistServiceServer
+// for forward compatibility.
+type PerfumistServiceServer interface {
+	Suggest(context.Context, *SuggestRequest) (*SuggestResponse, error)
+	AISuggest(context.Context, *SuggestRequest) (*SuggestResponse, error)
+	SuggestByTags(context.Context, *SuggestByTagsRequest) (*SuggestResponse, error)
+	mustEmbedUnimplementedPerfumistServiceServer()
+}
+
+// UnimplementedPerfumistServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedPerfumistServiceServer struct{}
+
+func (UnimplementedPerfumistServiceServer) Suggest(context.Context, *SuggestRequest) (*SuggestResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Suggest not implemented")
+}
+func (UnimplementedPerfumistServiceServer) AISuggest(context.Context, *SuggestRequest) (*SuggestResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AISuggest not implemented")
+}
+func (UnimplementedPerfumistServiceServer) SuggestByTags(context.Context, *SuggestByTagsRequest) (*SuggestResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SuggestByTags not implemented")
+}
+func (UnimplementedPerfumistServiceServer) mustEmbedUnimplementedPerfumistServiceServer() {}
+func (UnimplementedPerfumistServiceServer) testEmbeddedByValue()                          {}
+
+// UnsafePerfumistServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to PerfumistServiceServer will
+// result in compilation errors.
+type UnsafePerfumistServiceServer interface {
+	mustEmbedUnimplementedPerfumistServiceServer()
+}
+
+func RegisterPerfumistServiceServer(s grpc.ServiceRegistrar, srv PerfumistServiceServer) {
+	// If the following call pancis, it indicates UnimplementedPerfumistServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&PerfumistService_ServiceDesc, srv)
+}
+
+func _PerfumistService_Suggest_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SuggestRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PerfumistServiceServer).Suggest(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PerfumistService_Suggest_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PerfumistServiceServer).Suggest(ctx, req.(*SuggestRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PerfumistService_AISuggest_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SuggestRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PerfumistServiceServer).AISuggest(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PerfumistService_AISuggest_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PerfumistServiceServer).AISuggest(ctx, req.(*SuggestRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PerfumistService_SuggestByTags_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SuggestByTagsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PerfumistServiceServer).SuggestByTags(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PerfumistService_SuggestByTags_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PerfumistServiceServer).SuggestByTags(ctx, req.(*SuggestByTagsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// PerfumistService_ServiceDesc is the grpc.ServiceDesc for PerfumistService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var PerfumistService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "perfumist.v1.PerfumistService",
+	HandlerType: (*PerfumistServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Suggest",
+			Handler:    _PerfumistService_Suggest_Handler,
+		},
+		{
+			MethodName: "AISuggest",
+			Handler:    _PerfumistService_AISuggest_Handler,
+		},
+		{
+			MethodName: "SuggestByTags",
+			Handler:    _PerfumistService_SuggestByTags_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "perfumist/v1/perfumist.proto",
+}